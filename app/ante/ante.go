@@ -18,13 +18,21 @@ const (
 	// which currently defaults at 10, if intended
 	// memoCostPerByte     sdk.Gas = 3
 	secp256k1VerifyCost uint64 = 21000
+
+	// MaxNonceGap is the maximum difference between the nonce of a transaction
+	// and the nonce of its sender account for the transaction to be accepted in
+	// the mempool.
+	MaxNonceGap uint64 = 16
 )
 
 // NewAnteHandler returns an ante handler responsible for attempting to route an
 // Ethereum or SDK transaction to an internal ante handler for performing
 // transaction-level processing (e.g. fee payment, signature verification) before
-// being passed onto it's respective handler.
-func NewAnteHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.AnteHandler {
+// being passed onto it's respective handler. The pending Ethereum transactions
+// of the mempool can be replaced if pendingTxs is not nil.
+func NewAnteHandler(
+	ak auth.AccountKeeper, evmKeeper EVMKeeper, sk types.SupplyKeeper, pendingTxs *PendingTxs,
+) sdk.AnteHandler {
 	return func(
 		ctx sdk.Context, tx sdk.Tx, sim bool,
 	) (newCtx sdk.Context, err error) {
@@ -47,13 +55,15 @@ func NewAnteHandler(ak auth.AccountKeeper, sk types.SupplyKeeper) sdk.AnteHandle
 
 		case evmtypes.MsgEthereumTx:
 			anteHandler = sdk.ChainAnteDecorators(
-				NewEthSetupContextDecorator(), // outermost AnteDecorator. EthSetUpContext must be called first
-				NewEthMempoolFeeDecorator(),
-				NewEthSigVerificationDecorator(),
-				NewAccountVerificationDecorator(ak),
-				NewNonceVerificationDecorator(ak),
-				NewEthGasConsumeDecorator(ak, sk),
-				NewIncrementSenderSequenceDecorator(ak), // innermost AnteDecorator.
+				NewEthSetupContextDecorator(),       // outermost AnteDecorator. EthSetUpContext must be called first
+				NewEthTxSizeDecorator(evmKeeper),    // oversized payloads are rejected before recovering the signer
+				NewEthRecipientDecorator(evmKeeper), // zero address recipients are rejected if the params require it
+				NewEthSigVerificationDecorator(ak),  // signature must be verified before the gas and fee decorators
+				NewEthMempoolFeeDecorator(evmKeeper),
+				NewEthGasPriceDecorator(evmKeeper),
+				NewAccountVerificationDecorator(ak, evmKeeper),
+				NewEthNonceVerificationDecorator(ak, pendingTxs),
+				NewEthGasConsumeDecorator(ak, sk, evmKeeper), // innermost AnteDecorator.
 			)
 		default:
 			return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
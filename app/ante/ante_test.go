@@ -13,6 +13,7 @@ import (
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	"github.com/cosmos/ethermint/app"
 	"github.com/cosmos/ethermint/app/ante"
@@ -188,6 +189,63 @@ func (suite *AnteTestSuite) TestEthInvalidSig() {
 	requireInvalidTx(suite.T(), suite.anteHandler, ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthMalformedSig() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+
+	// require a signature with invalid values to fail before the fee checks
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1).(evmtypes.MsgEthereumTx)
+	tx.Data.S = big.NewInt(0)
+
+	ctx := suite.ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewCoins(sdk.NewCoin(types.DenomDefault, sdk.NewInt(500000)))))
+	_, err = suite.anteHandler(ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(types.ErrInvalidSignature.Is(err), err.Error())
+}
+
+func (suite *AnteTestSuite) TestEthSigVerificationSender() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+	gas := big.NewInt(20)
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, gas, []byte("test"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	decorator := ante.NewEthSigVerificationDecorator(suite.app.AccountKeeper)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	// require a signer without account to fail
+	_, err := decorator.AnteHandle(suite.ctx, tx, false, next)
+	suite.Require().Error(err)
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	// require the recovered signer to be set on the context
+	ctx, err := decorator.AnteHandle(suite.ctx, tx, false, next)
+	suite.Require().NoError(err)
+
+	sender, ok := evmtypes.SenderFromContext(ctx)
+	suite.Require().True(ok)
+	suite.Require().Equal(ethcmn.BytesToAddress(addr1.Bytes()), sender)
+}
+
 func (suite *AnteTestSuite) TestEthInvalidNonce() {
 
 	suite.ctx = suite.ctx.WithBlockHeight(1)
@@ -212,6 +270,238 @@ func (suite *AnteTestSuite) TestEthInvalidNonce() {
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthNonceVerification() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+
+	decorator := ante.NewEthNonceVerificationDecorator(suite.app.AccountKeeper, nil)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	testCases := []struct {
+		name     string
+		nonce    uint64
+		checkTx  bool
+		expSeq   uint64
+		expError *sdkerrors.Error
+	}{
+		{"exact", 5, false, 6, nil},
+		{"too low", 4, false, 5, types.ErrNonceTooLow},
+		{"too high", 6, false, 5, types.ErrNonceTooHigh},
+		{"queued", 5 + ante.MaxNonceGap, false, 5, types.ErrNonceTooHigh},
+		{"exact on check", 5, true, 6, nil},
+		{"too low on check", 4, true, 5, types.ErrNonceTooLow},
+		{"queued on check", 5 + ante.MaxNonceGap, true, 5, nil},
+		{"gap too large on check", 6 + ante.MaxNonceGap, true, 5, types.ErrNonceTooHigh},
+	}
+
+	for _, tc := range testCases {
+		ctx, _ := suite.ctx.WithIsCheckTx(tc.checkTx).CacheContext()
+
+		acc := suite.app.AccountKeeper.NewAccountWithAddress(ctx, addr1)
+		suite.Require().NoError(acc.SetSequence(5))
+		suite.app.AccountKeeper.SetAccount(ctx, acc)
+
+		ethMsg := evmtypes.NewMsgEthereumTx(tc.nonce, &to, big.NewInt(32), 22000, big.NewInt(20), []byte("test"))
+		tx := newTestEthTx(ctx, ethMsg, priv1).(evmtypes.MsgEthereumTx)
+		_, err := tx.VerifySig(big.NewInt(3))
+		suite.Require().NoError(err, tc.name)
+
+		_, err = decorator.AnteHandle(ctx, tx, false, next)
+		if tc.expError != nil {
+			suite.Require().Error(err, tc.name)
+			suite.Require().True(tc.expError.Is(err), tc.name)
+		} else {
+			suite.Require().NoError(err, tc.name)
+		}
+
+		suite.Require().Equal(tc.expSeq, suite.app.AccountKeeper.GetAccount(ctx, addr1).GetSequence(), tc.name)
+	}
+}
+
+func (suite *AnteTestSuite) TestEthNonceTooHighDeliver() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	suite.Require().NoError(acc.SetCoins(newTestCoins()))
+	suite.Require().NoError(acc.SetSequence(5))
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	balance := acc.GetCoins().AmountOf(types.DenomDefault)
+
+	// a transaction queued in the mempool is accepted by CheckTx
+	ethMsg := evmtypes.NewMsgEthereumTx(7, &to, big.NewInt(32), 22000, big.NewInt(20), []byte("test"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	checkCtx, _ := suite.ctx.WithIsCheckTx(true).CacheContext()
+	requireValidTx(suite.T(), suite.anteHandler, checkCtx, tx, false)
+
+	// require the transaction to be rejected when delivered ahead of its nonce,
+	// before its fee is deducted
+	_, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(types.ErrNonceTooHigh.Is(err), err.Error())
+
+	acc = suite.app.AccountKeeper.GetAccount(suite.ctx, addr1)
+	suite.Require().Equal(uint64(5), acc.GetSequence())
+	suite.Require().True(balance.Equal(acc.GetCoins().AmountOf(types.DenomDefault)))
+}
+
+func (suite *AnteTestSuite) TestEthNonceReplacement() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+
+	pendingTxs := ante.NewPendingTxs(ante.DefaultPriceBump)
+	decorator := ante.NewEthNonceVerificationDecorator(suite.app.AccountKeeper, pendingTxs)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	checkCtx, _ := suite.ctx.WithIsCheckTx(true).CacheContext()
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(checkCtx, addr1)
+	suite.Require().NoError(acc.SetSequence(5))
+	suite.app.AccountKeeper.SetAccount(checkCtx, acc)
+
+	newTx := func(nonce uint64, gasPrice int64) evmtypes.MsgEthereumTx {
+		ethMsg := evmtypes.NewMsgEthereumTx(nonce, &to, big.NewInt(32), 22000, big.NewInt(gasPrice), []byte("test"))
+		tx := newTestEthTx(checkCtx, ethMsg, priv1).(evmtypes.MsgEthereumTx)
+		_, err := tx.VerifySig(big.NewInt(3))
+		suite.Require().NoError(err)
+		return tx
+	}
+
+	newDynamicFeeTx := func(nonce uint64, gasTipCap, gasFeeCap int64) evmtypes.MsgEthereumTx {
+		ethMsg := evmtypes.NewMsgEthereumTxDynamicFee(
+			nonce, &to, big.NewInt(32), 22000, big.NewInt(gasTipCap), big.NewInt(gasFeeCap), []byte("test"),
+		)
+		tx := newTestEthTx(checkCtx, ethMsg, priv1).(evmtypes.MsgEthereumTx)
+		_, err := tx.VerifySig(big.NewInt(3))
+		suite.Require().NoError(err)
+		return tx
+	}
+
+	pending := newTx(5, 20)
+	underpriced := newTx(5, 21)
+	replacement := newTx(5, 22)
+	next6 := newTx(6, 20)
+
+	// the EIP-1559 replacements must bump both the gas fee cap and the gas tip cap
+	pending7 := newDynamicFeeTx(7, 10, 100)
+	feeCapBumpOnly := newDynamicFeeTx(7, 10, 200)
+	tipCapBumpOnly := newDynamicFeeTx(7, 20, 100)
+	replacement7 := newDynamicFeeTx(7, 11, 110)
+
+	testCases := []struct {
+		name     string
+		tx       evmtypes.MsgEthereumTx
+		expSeq   uint64
+		expError *sdkerrors.Error
+	}{
+		{"pending", pending, 6, nil},
+		{"insufficient price bump", underpriced, 6, types.ErrReplacementUnderpriced},
+		{"replacement", replacement, 6, nil},
+		{"same transaction", pending, 6, types.ErrReplacementUnderpriced},
+		{"different nonce", next6, 7, nil},
+		{"pending dynamic fee", pending7, 8, nil},
+		{"gas fee cap bump only", feeCapBumpOnly, 8, types.ErrReplacementUnderpriced},
+		{"gas tip cap bump only", tipCapBumpOnly, 8, types.ErrReplacementUnderpriced},
+		{"dynamic fee replacement", replacement7, 8, nil},
+	}
+
+	for _, tc := range testCases {
+		_, err := decorator.AnteHandle(checkCtx, tc.tx, false, next)
+		if tc.expError != nil {
+			suite.Require().Error(err, tc.name)
+			suite.Require().True(tc.expError.Is(err), tc.name)
+		} else {
+			suite.Require().NoError(err, tc.name)
+		}
+
+		suite.Require().Equal(tc.expSeq, suite.app.AccountKeeper.GetAccount(checkCtx, addr1).GetSequence(), tc.name)
+	}
+
+	// the replaced transaction is evicted from the mempool on recheck
+	recheckCtx, _ := suite.ctx.WithIsReCheckTx(true).CacheContext()
+	acc = suite.app.AccountKeeper.NewAccountWithAddress(recheckCtx, addr1)
+	suite.Require().NoError(acc.SetSequence(5))
+	suite.app.AccountKeeper.SetAccount(recheckCtx, acc)
+
+	_, err := decorator.AnteHandle(recheckCtx, pending, false, next)
+	suite.Require().True(types.ErrTxReplaced.Is(err))
+	_, err = decorator.AnteHandle(recheckCtx, replacement, false, next)
+	suite.Require().NoError(err)
+
+	// the delivered transaction is no longer pending, so a transaction with the
+	// same nonce is rejected as its nonce is too low
+	deliverCtx, _ := suite.ctx.CacheContext()
+	acc = suite.app.AccountKeeper.NewAccountWithAddress(deliverCtx, addr1)
+	suite.Require().NoError(acc.SetSequence(5))
+	suite.app.AccountKeeper.SetAccount(deliverCtx, acc)
+
+	_, err = decorator.AnteHandle(deliverCtx, replacement, false, next)
+	suite.Require().NoError(err)
+
+	_, err = decorator.AnteHandle(checkCtx, newTx(5, 100), false, next)
+	suite.Require().True(types.ErrNonceTooLow.Is(err))
+}
+
+func (suite *AnteTestSuite) TestEthNoncePendingTxsPruneAndBound() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+
+	pendingTxs := ante.NewPendingTxs(ante.DefaultPriceBump)
+	pendingTxs.SetMaxTxs(2)
+	decorator := ante.NewEthNonceVerificationDecorator(suite.app.AccountKeeper, pendingTxs)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	checkCtx, _ := suite.ctx.WithIsCheckTx(true).CacheContext()
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(checkCtx, addr1)
+	suite.Require().NoError(acc.SetSequence(5))
+	suite.app.AccountKeeper.SetAccount(checkCtx, acc)
+
+	newTx := func(nonce uint64, gasPrice int64) evmtypes.MsgEthereumTx {
+		ethMsg := evmtypes.NewMsgEthereumTx(nonce, &to, big.NewInt(32), 22000, big.NewInt(gasPrice), []byte("test"))
+		tx := newTestEthTx(checkCtx, ethMsg, priv1).(evmtypes.MsgEthereumTx)
+		_, err := tx.VerifySig(big.NewInt(3))
+		suite.Require().NoError(err)
+		return tx
+	}
+
+	// the transactions accepted beyond the max pending transactions aren't
+	// tracked, so they can't be replaced
+	for nonce := uint64(5); nonce < 8; nonce++ {
+		_, err := decorator.AnteHandle(checkCtx, newTx(nonce, 20), false, next)
+		suite.Require().NoError(err)
+	}
+	suite.Require().Equal(2, pendingTxs.Len())
+
+	_, err := decorator.AnteHandle(checkCtx, newTx(6, 100), false, next)
+	suite.Require().NoError(err)
+	_, err = decorator.AnteHandle(checkCtx, newTx(7, 100), false, next)
+	suite.Require().True(types.ErrNonceTooLow.Is(err))
+	suite.Require().Equal(2, pendingTxs.Len())
+
+	// the pending transactions whose nonce was consumed by a block proposed
+	// without them are pruned on recheck
+	recheckCtx, _ := suite.ctx.WithIsReCheckTx(true).CacheContext()
+	acc = suite.app.AccountKeeper.NewAccountWithAddress(recheckCtx, addr1)
+	suite.Require().NoError(acc.SetSequence(7))
+	suite.app.AccountKeeper.SetAccount(recheckCtx, acc)
+
+	_, err = decorator.AnteHandle(recheckCtx, newTx(7, 20), false, next)
+	suite.Require().NoError(err)
+	suite.Require().Equal(0, pendingTxs.Len())
+}
+
 func (suite *AnteTestSuite) TestEthInsufficientBalance() {
 	suite.ctx = suite.ctx.WithBlockHeight(1)
 
@@ -231,6 +521,169 @@ func (suite *AnteTestSuite) TestEthInsufficientBalance() {
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthGasConsumeFees() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	gasLimit := uint64(22000)
+
+	// require the fee of the gas limit to be deducted and the gas meter limit set
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), gasLimit, big.NewInt(20), []byte("test"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	ctx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(gasLimit, ctx.GasMeter().Limit())
+
+	fee := sdk.NewInt(20 * int64(gasLimit))
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)
+	suite.Require().True(newTestCoins().AmountOf(types.DenomDefault).Sub(fee).Equal(balance))
+
+	// require the EIP-1559 transactions to pay their priority fee, capped to the max fee
+	ethMsg = evmtypes.NewMsgEthereumTxDynamicFee(1, &to, big.NewInt(32), gasLimit, big.NewInt(5), big.NewInt(30), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	balance = balance.Sub(sdk.NewInt(5 * int64(gasLimit)))
+	suite.Require().True(balance.Equal(suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)))
+
+	// require a fee above the balance to fail
+	ethMsg = evmtypes.NewMsgEthereumTx(2, &to, big.NewInt(32), gasLimit, big.NewInt(100000), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrInsufficientFunds.Is(err), err.Error())
+
+	// require a gas price not representable in the coin denomination to fail
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.CoinDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// NOTE: the nonce of the failed transaction is incremented as the test state isn't cached
+	ethMsg = evmtypes.NewMsgEthereumTx(3, &to, big.NewInt(32), gasLimit, big.NewInt(20), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(types.ErrInvalidValue.Is(err), err.Error())
+}
+
+func (suite *AnteTestSuite) TestEthZeroGasPrice() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	gasLimit := uint64(22000)
+
+	// require the nil and zero gas prices, rejected by ValidateBasic, to be
+	// handled without deducting any fee
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), gasLimit, nil, []byte("test"))
+	ethMsg.Data.Price = nil
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NotNil(tx.(evmtypes.MsgEthereumTx).ValidateBasic())
+
+	ctx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(gasLimit, ctx.GasMeter().Limit())
+
+	ethMsg = evmtypes.NewMsgEthereumTx(1, &to, big.NewInt(32), gasLimit, big.NewInt(0), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().NotNil(tx.(evmtypes.MsgEthereumTx).ValidateBasic())
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	balance := suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)
+	suite.Require().True(newTestCoins().AmountOf(types.DenomDefault).Equal(balance))
+
+	// require a positive gas price to be charged
+	ethMsg = evmtypes.NewMsgEthereumTx(2, &to, big.NewInt(32), gasLimit, big.NewInt(20), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	suite.Require().Nil(tx.(evmtypes.MsgEthereumTx).ValidateBasic())
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	balance = balance.Sub(sdk.NewInt(20 * int64(gasLimit)))
+	suite.Require().True(balance.Equal(suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)))
+
+	// require the nil gas price to be below a positive MinGasPrice param
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.MinGasPrice = sdk.NewInt(1)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	ethMsg = evmtypes.NewMsgEthereumTx(3, &to, big.NewInt(32), gasLimit, nil, []byte("test"))
+	ethMsg.Data.Price = nil
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrInsufficientFee.Is(err), err.Error())
+}
+
+func (suite *AnteTestSuite) TestEthBaseFee() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, big.NewInt(25))
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	gasLimit := uint64(22000)
+
+	// require a gas fee cap below the base fee to fail
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), gasLimit, big.NewInt(20), []byte("test"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrInsufficientFee.Is(err), err.Error())
+
+	// require the EIP-1559 transactions to pay the base fee plus their priority
+	// fee, capped to the max fee
+	ethMsg = evmtypes.NewMsgEthereumTxDynamicFee(0, &to, big.NewInt(32), gasLimit, big.NewInt(2), big.NewInt(30), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	balance := newTestCoins().AmountOf(types.DenomDefault).Sub(sdk.NewInt(27 * int64(gasLimit)))
+	suite.Require().True(balance.Equal(suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)))
+
+	ethMsg = evmtypes.NewMsgEthereumTxDynamicFee(1, &to, big.NewInt(32), gasLimit, big.NewInt(10), big.NewInt(30), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+
+	balance = balance.Sub(sdk.NewInt(30 * int64(gasLimit)))
+	suite.Require().True(balance.Equal(suite.app.AccountKeeper.GetAccount(suite.ctx, addr1).GetCoins().AmountOf(types.DenomDefault)))
+}
+
 func (suite *AnteTestSuite) TestEthInvalidIntrinsicGas() {
 	suite.ctx = suite.ctx.WithBlockHeight(1)
 
@@ -253,11 +706,44 @@ func (suite *AnteTestSuite) TestEthInvalidIntrinsicGas() {
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthAccessListIntrinsicGas() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	accesses := evmtypes.AccessList{
+		{Address: to, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(2))}},
+	}
+
+	// 21000 base + 2400 per address + 1900 per storage key
+	intrinsicGas := uint64(21000 + 2400 + 2*1900)
+
+	// require the gas limit to cover the access list gas
+	ethMsg := evmtypes.NewMsgEthereumTxAccessList(0, &to, big.NewInt(32), intrinsicGas-1, big.NewInt(20), nil, accesses)
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+	cacheCtx, _ := suite.ctx.CacheContext()
+	requireInvalidTx(suite.T(), suite.anteHandler, cacheCtx, tx, false)
+
+	// require the access list gas to be consumed as intrinsic gas
+	ethMsg = evmtypes.NewMsgEthereumTxAccessList(0, &to, big.NewInt(32), 100000, big.NewInt(20), nil, accesses)
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	newCtx, err := suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(intrinsicGas, newCtx.GasMeter().GasConsumed())
+}
+
 func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	// setup app with checkTx = true
 	suite.app = app.Setup(true)
 	suite.ctx = suite.app.BaseApp.NewContext(true, abci.Header{Height: 1, ChainID: "3", Time: time.Now().UTC()})
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.SupplyKeeper)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, &suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
 
 	suite.ctx = suite.ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewCoins(sdk.NewCoin(types.DenomDefault, sdk.NewInt(500000)))))
 	addr1, priv1 := newTestAddrKey()
@@ -278,6 +764,145 @@ func (suite *AnteTestSuite) TestEthInvalidMempoolFees() {
 	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
 }
 
+func (suite *AnteTestSuite) TestEthMempoolFeeCoinDenom() {
+	suite.ctx = suite.ctx.WithBlockHeight(1).WithIsCheckTx(true)
+
+	_, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	gasLimit := uint64(22000)
+
+	decorator := ante.NewEthMempoolFeeDecorator(&suite.app.EvmKeeper)
+	next := func(ctx sdk.Context, _ sdk.Tx, _ bool) (sdk.Context, error) { return ctx, nil }
+
+	// a gas price of one coin per gas with 6 decimals
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.CoinDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+	coinPrice := big.NewInt(1000000000000)
+
+	newTx := func(gasPrice *big.Int) sdk.Tx {
+		ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), gasLimit, gasPrice, []byte("test"))
+		return newTestEthTx(suite.ctx, ethMsg, priv1)
+	}
+	minGasPrices := func(amount int64) sdk.Context {
+		return suite.ctx.WithMinGasPrices(sdk.NewDecCoins(sdk.NewCoins(sdk.NewCoin(params.EvmDenom, sdk.NewInt(amount)))))
+	}
+
+	// require the fee to be compared in the coin denomination
+	_, err := decorator.AnteHandle(minGasPrices(int64(gasLimit)), newTx(coinPrice), false, next)
+	suite.Require().NoError(err)
+
+	_, err = decorator.AnteHandle(minGasPrices(int64(gasLimit)+1), newTx(coinPrice), false, next)
+	suite.Require().True(sdkerrors.ErrInsufficientFee.Is(err))
+
+	// require a gas price not representable in the coin denomination to fail
+	_, err = decorator.AnteHandle(minGasPrices(1), newTx(new(big.Int).Add(coinPrice, big.NewInt(1))), false, next)
+	suite.Require().True(types.ErrInvalidValue.Is(err))
+
+	// require a fee above the int64 range to pass
+	params.CoinDecimals = evmtypes.DefaultCoinDecimals
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	_, err = decorator.AnteHandle(minGasPrices(1), newTx(new(big.Int).Lsh(big.NewInt(1), 62)), false, next)
+	suite.Require().NoError(err)
+}
+
+func (suite *AnteTestSuite) TestEthInvalidGasPrice() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	suite.app.EvmKeeper.SetParams(suite.ctx, evmtypes.NewParams(sdk.NewInt(10), sdk.NewInt(100)))
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+	amt := big.NewInt(32)
+
+	// require a gas price below the minimum to fail
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, big.NewInt(9), []byte("test"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// require a gas price above the maximum to fail
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &to, amt, 22000, big.NewInt(101), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireInvalidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	// require a gas price within the bounds to pass
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &to, amt, 100000, big.NewInt(20), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthTxSize() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+	addr2, _ := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	params := evmtypes.DefaultParams()
+	params.MaxTxSize = 4
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	to := ethcmn.BytesToAddress(addr2.Bytes())
+
+	// require a payload above the max tx size to fail
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), 22000, big.NewInt(20), []byte("tests"))
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(types.ErrTxSizeExceeded.Is(err), err.Error())
+
+	// require a payload within the max tx size to pass
+	ethMsg = evmtypes.NewMsgEthereumTx(0, &to, big.NewInt(32), 22000, big.NewInt(20), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
+func (suite *AnteTestSuite) TestEthZeroAddressRecipient() {
+	suite.ctx = suite.ctx.WithBlockHeight(1)
+
+	addr1, priv1 := newTestAddrKey()
+
+	acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, addr1)
+	err := acc.SetCoins(newTestCoins())
+	suite.Require().NoError(err)
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+	zero := ethcmn.Address{}
+
+	// require the zero address recipient to pass by default
+	ethMsg := evmtypes.NewMsgEthereumTx(0, &zero, big.NewInt(32), 22000, big.NewInt(20), nil)
+	tx := newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+
+	params := evmtypes.DefaultParams()
+	params.RejectZeroAddressRecipient = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// require the zero address recipient to fail once rejected by the params
+	_, err = suite.anteHandler(suite.ctx, tx, false)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrInvalidAddress.Is(err), err.Error())
+
+	// require a contract creation to pass
+	ethMsg = evmtypes.NewMsgEthereumTxContract(1, big.NewInt(0), 100000, big.NewInt(20), []byte("test"))
+	tx = newTestEthTx(suite.ctx, ethMsg, priv1)
+	requireValidTx(suite.T(), suite.anteHandler, suite.ctx, tx, false)
+}
+
 func (suite *AnteTestSuite) TestEthInvalidChainID() {
 	suite.ctx = suite.ctx.WithBlockHeight(1)
 
@@ -1,6 +1,7 @@
 package ante
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -12,8 +13,6 @@ import (
 
 	emint "github.com/cosmos/ethermint/types"
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
-
-	ethcore "github.com/ethereum/go-ethereum/core"
 )
 
 // EthSetupContextDecorator sets the infinite GasMeter in the Context and wraps
@@ -66,11 +65,15 @@ func (escd EthSetupContextDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simu
 
 // EthMempoolFeeDecorator validates that sufficient fees have been provided that
 // meet a minimum threshold defined by the proposer (for mempool purposes during CheckTx).
-type EthMempoolFeeDecorator struct{}
+type EthMempoolFeeDecorator struct {
+	evmKeeper EVMKeeper
+}
 
 // NewEthMempoolFeeDecorator creates a new EthMempoolFeeDecorator
-func NewEthMempoolFeeDecorator() EthMempoolFeeDecorator {
-	return EthMempoolFeeDecorator{}
+func NewEthMempoolFeeDecorator(ek EVMKeeper) EthMempoolFeeDecorator {
+	return EthMempoolFeeDecorator{
+		evmKeeper: ek,
+	}
 }
 
 // AnteHandle verifies that enough fees have been provided by the
@@ -88,20 +91,18 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 	}
 
-	// fee = GP * GL
-	fee := sdk.NewInt64DecCoin(emint.DenomDefault, msgEthTx.Fee().Int64())
-
-	minGasPrices := ctx.MinGasPrices()
-
-	allGTE := true
-	for _, v := range minGasPrices {
-		if !fee.IsGTE(v) {
-			allGTE = false
-		}
+	// fee = GP * GL, in the coin denomination as deducted by the gas consume
+	// decorator
+	params := emfd.evmKeeper.GetParams(ctx)
+	gasPrice, err := evmtypes.WeiToCoin(msgEthTx.EffectiveGasPrice(emfd.evmKeeper.GetBaseFee(ctx)), params.CoinDecimals)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(err, "invalid gas price")
 	}
+	fee := sdk.NewCoin(params.EvmDenom, gasPrice.Mul(sdk.NewIntFromBigInt(new(big.Int).SetUint64(msgEthTx.GetGas()))))
 
 	// it is assumed that the minimum fees will only include the single valid denom
-	if !ctx.MinGasPrices().IsZero() && !allGTE {
+	minFee := ctx.MinGasPrices().AmountOf(params.EvmDenom).Ceil().TruncateInt()
+	if fee.Amount.LT(minFee) {
 		// reject the transaction that does not meet the minimum fee
 		return ctx, sdkerrors.Wrap(
 			sdkerrors.ErrInsufficientFee,
@@ -112,46 +113,166 @@ func (emfd EthMempoolFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	return next(ctx, tx, simulate)
 }
 
-// EthSigVerificationDecorator validates an ethereum signature
-type EthSigVerificationDecorator struct{}
+// EVMKeeper defines the expected keeper interface used on the Eth AnteHandler
+type EVMKeeper interface {
+	GetParams(ctx sdk.Context) evmtypes.Params
+	GetBaseFee(ctx sdk.Context) *big.Int
+}
+
+// EthGasPriceDecorator validates that the gas price of an Ethereum transaction
+// is within the bounds defined by the EVM module parameters.
+type EthGasPriceDecorator struct {
+	evmKeeper EVMKeeper
+}
 
-// NewEthSigVerificationDecorator creates a new EthSigVerificationDecorator
-func NewEthSigVerificationDecorator() EthSigVerificationDecorator {
-	return EthSigVerificationDecorator{}
+// NewEthGasPriceDecorator creates a new EthGasPriceDecorator
+func NewEthGasPriceDecorator(ek EVMKeeper) EthGasPriceDecorator {
+	return EthGasPriceDecorator{
+		evmKeeper: ek,
+	}
 }
 
-// AnteHandle validates the signature and returns sender address
-func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+// AnteHandle rejects the Ethereum transactions with a gas price below the
+// MinGasPrice or above the MaxGasPrice parameters, or with a gas fee cap below
+// the base fee of the block. Unlike the mempool fee check, the bounds are
+// enforced by all the validators in every mode.
+func (egpd EthGasPriceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	params := egpd.evmKeeper.GetParams(ctx)
+	if err := params.ValidateGasPrice(sdk.NewIntFromBigInt(msgEthTx.GasPrice())); err != nil {
+		return ctx, err
+	}
+
+	baseFee := egpd.evmKeeper.GetBaseFee(ctx)
+	if gasFeeCap := msgEthTx.GasFeeCap(); gasFeeCap.Cmp(baseFee) < 0 {
+		return ctx, sdkerrors.Wrapf(
+			sdkerrors.ErrInsufficientFee, "gas fee cap %s wei is lower than the base fee %s wei", gasFeeCap, baseFee,
+		)
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthTxSizeDecorator validates that the payload of an Ethereum transaction
+// doesn't exceed the MaxTxSize defined by the EVM module parameters.
+type EthTxSizeDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewEthTxSizeDecorator creates a new EthTxSizeDecorator
+func NewEthTxSizeDecorator(ek EVMKeeper) EthTxSizeDecorator {
+	return EthTxSizeDecorator{
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle rejects the Ethereum transactions with a payload larger than the
+// MaxTxSize parameter, in every mode.
+func (etsd EthTxSizeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
 	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
 	if !ok {
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
 	}
 
-	// parse the chainID from a string to a base-10 integer
-	chainID, ok := new(big.Int).SetString(ctx.ChainID(), 10)
+	if err := etsd.evmKeeper.GetParams(ctx).ValidateTxSize(msgEthTx.Data.Payload); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthRecipientDecorator validates the recipient of an Ethereum transaction
+// against the RejectZeroAddressRecipient parameter of the EVM module.
+type EthRecipientDecorator struct {
+	evmKeeper EVMKeeper
+}
+
+// NewEthRecipientDecorator creates a new EthRecipientDecorator
+func NewEthRecipientDecorator(ek EVMKeeper) EthRecipientDecorator {
+	return EthRecipientDecorator{
+		evmKeeper: ek,
+	}
+}
+
+// AnteHandle rejects the Ethereum transactions sent to the zero address if the
+// RejectZeroAddressRecipient parameter is set, in every mode. The contract
+// creations, without recipient, are accepted.
+func (erd EthRecipientDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
 	if !ok {
-		return ctx, sdkerrors.Wrap(emint.ErrInvalidChainID, ctx.ChainID())
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	if err := erd.evmKeeper.GetParams(ctx).ValidateRecipient(msgEthTx.To()); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// EthSigVerificationDecorator validates an ethereum signature and requires the
+// recovered signer to have an account. The signer is set on the context so that
+// the EVM handler doesn't recover it again.
+type EthSigVerificationDecorator struct {
+	ak       auth.AccountKeeper
+	sigCache *evmtypes.SigCache
+}
+
+// NewEthSigVerificationDecorator creates a new EthSigVerificationDecorator.
+// The recovered signers are cached so that a transaction verified during
+// CheckTx isn't recovered again during DeliverTx.
+func NewEthSigVerificationDecorator(ak auth.AccountKeeper) EthSigVerificationDecorator {
+	return EthSigVerificationDecorator{
+		ak:       ak,
+		sigCache: evmtypes.NewSigCache(evmtypes.DefaultSigCacheSize),
+	}
+}
+
+// AnteHandle validates the signature and sets the sender address on the context
+func (esvd EthSigVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
+	if !ok {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
+	}
+
+	// parse the EIP-155 chain ID from the chain-id string
+	chainID, err := emint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return ctx, err
 	}
 
 	// validate sender/signature
-	// NOTE: signer is retrieved from the transaction on the next AnteDecorator
-	_, err = msgEthTx.VerifySig(chainID)
+	// NOTE: the signer is cached on the transaction for the next AnteDecorators
+	sender, err := esvd.sigCache.VerifySig(&msgEthTx, chainID)
 	if err != nil {
-		return ctx, sdkerrors.Wrap(sdkerrors.ErrUnauthorized, fmt.Sprintf("signature verification failed"))
+		if errors.Is(err, emint.ErrInvalidChainID) {
+			return ctx, sdkerrors.Wrap(emint.ErrInvalidChainID, err.Error())
+		}
+		return ctx, sdkerrors.Wrapf(emint.ErrInvalidSignature, "signature verification failed: %s", err)
+	}
+
+	if acc := esvd.ak.GetAccount(ctx, sender.Bytes()); acc == nil {
+		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "signer %s has no account", sender.Hex())
 	}
 
-	return next(ctx, msgEthTx, simulate)
+	return next(evmtypes.WithSender(ctx, sender), msgEthTx, simulate)
 }
 
 // AccountVerificationDecorator validates an account balance checks
 type AccountVerificationDecorator struct {
-	ak auth.AccountKeeper
+	ak        auth.AccountKeeper
+	evmKeeper EVMKeeper
 }
 
 // NewAccountVerificationDecorator creates a new AccountVerificationDecorator
-func NewAccountVerificationDecorator(ak auth.AccountKeeper) AccountVerificationDecorator {
+func NewAccountVerificationDecorator(ak auth.AccountKeeper, ek EVMKeeper) AccountVerificationDecorator {
 	return AccountVerificationDecorator{
-		ak: ak,
+		ak:        ak,
+		evmKeeper: ek,
 	}
 }
 
@@ -185,33 +306,45 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		)
 	}
 
-	// validate sender has enough funds
-	balance := acc.GetCoins().AmountOf(emint.DenomDefault)
-	if balance.BigInt().Cmp(msgEthTx.Cost()) < 0 {
+	// validate sender has enough funds, the cost being denominated in wei
+	params := avd.evmKeeper.GetParams(ctx)
+	balance := evmtypes.CoinToWei(acc.GetCoins().AmountOf(params.EvmDenom), params.CoinDecimals)
+	if cost := msgEthTx.Cost(avd.evmKeeper.GetBaseFee(ctx)); balance.Cmp(cost) < 0 {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
-			"%s < %s%s", balance.String(), msgEthTx.Cost().String(), emint.DenomDefault,
+			"%s < %s wei of %s", balance.String(), cost.String(), params.EvmDenom,
 		)
 	}
 
 	return next(ctx, tx, simulate)
 }
 
-// NonceVerificationDecorator that the nonce matches
-type NonceVerificationDecorator struct {
-	ak auth.AccountKeeper
+// EthNonceVerificationDecorator validates the transaction nonce against the
+// sequence of the sender account, which is incremented on success.
+//
+// CONTRACT: must be called after msg.VerifySig in order to cache the sender address.
+type EthNonceVerificationDecorator struct {
+	ak         auth.AccountKeeper
+	pendingTxs *PendingTxs
 }
 
-// NewNonceVerificationDecorator creates a new NonceVerificationDecorator
-func NewNonceVerificationDecorator(ak auth.AccountKeeper) NonceVerificationDecorator {
-	return NonceVerificationDecorator{
-		ak: ak,
+// NewEthNonceVerificationDecorator creates a new EthNonceVerificationDecorator.
+// The pending transactions of the mempool can be replaced if pendingTxs is not
+// nil.
+func NewEthNonceVerificationDecorator(ak auth.AccountKeeper, pendingTxs *PendingTxs) EthNonceVerificationDecorator {
+	return EthNonceVerificationDecorator{
+		ak:         ak,
+		pendingTxs: pendingTxs,
 	}
 }
 
-// AnteHandle validates that the transaction nonce is valid (equivalent to the sender account’s
-// current nonce).
-func (nvd NonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
+// AnteHandle validates that the transaction nonce is equal to the sender account’s
+// current nonce and increments it. During CheckTx, the nonces up to MaxNonceGap
+// above the account nonce are accepted for the transactions queued in the mempool,
+// without incrementing the account nonce, as well as the transactions replacing
+// a pending transaction with the same nonce and a higher gas price. During
+// DeliverTx, the nonce must match the account nonce exactly.
+func (envd EthNonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (newCtx sdk.Context, err error) {
 	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
 	if !ok {
 		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
@@ -223,39 +356,96 @@ func (nvd NonceVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, sim
 		panic("sender address is nil")
 	}
 
-	acc := nvd.ak.GetAccount(ctx, address)
+	// get and set account must be called with an infinite gas meter in order to prevent
+	// additional gas from being deducted.
+	infCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	acc := envd.ak.GetAccount(infCtx, address)
 	if acc == nil {
 		return ctx, fmt.Errorf("account %s is nil", address)
 	}
 
-	seq := acc.GetSequence()
-	if msgEthTx.Data.AccountNonce != seq {
-		return ctx, sdkerrors.Wrap(
-			sdkerrors.ErrInvalidSequence,
-			fmt.Sprintf("invalid nonce; got %d, expected %d", msgEthTx.Data.AccountNonce, seq),
+	nonce, seq := msgEthTx.Data.AccountNonce, acc.GetSequence()
+	sender := address.String()
+	trackPending := envd.pendingTxs != nil && !simulate
+
+	// the delivered transactions are no longer pending and the replaced ones are
+	// evicted from the mempool when rechecked, along with the pending transactions
+	// whose nonce was consumed by a block
+	replacement := false
+	if trackPending {
+		switch {
+		case !ctx.IsCheckTx():
+			envd.pendingTxs.Remove(sender, nonce)
+		case ctx.IsReCheckTx():
+			envd.pendingTxs.Prune(sender, seq)
+			if envd.pendingTxs.IsReplaced(sender, &msgEthTx) {
+				return ctx, sdkerrors.Wrapf(emint.ErrTxReplaced, "nonce %d", nonce)
+			}
+		default:
+			if replacement, err = envd.pendingTxs.CheckReplacement(sender, &msgEthTx); err != nil {
+				return ctx, err
+			}
+		}
+	}
+
+	switch {
+	case nonce < seq && !replacement:
+		return ctx, sdkerrors.Wrapf(
+			emint.ErrNonceTooLow, "got %d, expected %d", nonce, seq,
+		)
+	case nonce > seq && (!ctx.IsCheckTx() || nonce-seq > MaxNonceGap):
+		return ctx, sdkerrors.Wrapf(
+			emint.ErrNonceTooHigh, "got %d, expected %d", nonce, seq,
 		)
+	case nonce == seq && (!ctx.IsReCheckTx() || simulate):
+		// no need to increment sequence on RecheckTx, nor for the queued
+		// transactions, the account nonce being incremented once the
+		// transactions of the gap are checked, nor for the replacements
+		if err := acc.SetSequence(seq + 1); err != nil {
+			panic(err)
+		}
+		envd.ak.SetAccount(infCtx, acc)
 	}
 
-	return next(ctx, tx, simulate)
+	newCtx, err = next(ctx, tx, simulate)
+	if !trackPending || !ctx.IsCheckTx() {
+		return newCtx, err
+	}
+
+	switch {
+	case err != nil && ctx.IsReCheckTx():
+		// the transaction is evicted from the mempool
+		envd.pendingTxs.RemoveTx(sender, &msgEthTx)
+	case err == nil && !ctx.IsReCheckTx():
+		envd.pendingTxs.Add(sender, &msgEthTx)
+	}
+
+	return newCtx, err
 }
 
 // EthGasConsumeDecorator validates enough intrinsic gas for the transaction and
 // gas consumption.
 type EthGasConsumeDecorator struct {
-	ak auth.AccountKeeper
-	sk types.SupplyKeeper
+	ak        auth.AccountKeeper
+	sk        types.SupplyKeeper
+	evmKeeper EVMKeeper
 }
 
 // NewEthGasConsumeDecorator creates a new EthGasConsumeDecorator
-func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper) EthGasConsumeDecorator {
+func NewEthGasConsumeDecorator(ak auth.AccountKeeper, sk types.SupplyKeeper, ek EVMKeeper) EthGasConsumeDecorator {
 	return EthGasConsumeDecorator{
-		ak: ak,
-		sk: sk,
+		ak:        ak,
+		sk:        sk,
+		evmKeeper: ek,
 	}
 }
 
 // AnteHandle validates that the Ethereum tx message has enough to cover intrinsic gas
 // (during CheckTx only) and that the sender has enough balance to pay for the gas cost.
+// The fee of the gas limit at the effective gas price is deducted from the sender in
+// the coin denomination and the fee of the unused gas is refunded by the EVM handler
+// at the end of the state transition.
 //
 // Intrinsic gas for a transaction is the amount of gas
 // that the transaction uses before the transaction is executed. The gas is a
@@ -284,7 +474,7 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	}
 
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.To() == nil, true)
+	gas, err := evmtypes.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.Data.Accesses, msgEthTx.To() == nil)
 	if err != nil {
 		return ctx, sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -294,15 +484,29 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 		return ctx, fmt.Errorf("intrinsic gas too low: %d < %d", gasLimit, gas)
 	}
 
-	// Charge sender for gas up to limit
-	if gasLimit != 0 {
-		// Cost calculates the fees paid to validators based on gas limit and price
-		cost := new(big.Int).Mul(msgEthTx.Data.Price, new(big.Int).SetUint64(gasLimit))
+	// the gas price must be a whole amount of the coin denomination so that the
+	// fee of the unused gas can be refunded exactly
+	params := egcd.evmKeeper.GetParams(ctx)
+	gasPrice, err := evmtypes.WeiToCoin(msgEthTx.EffectiveGasPrice(egcd.evmKeeper.GetBaseFee(ctx)), params.CoinDecimals)
+	if err != nil {
+		return ctx, sdkerrors.Wrap(err, "invalid gas price")
+	}
 
+	// Charge sender for gas up to limit
+	if gasLimit != 0 && gasPrice.IsPositive() {
+		// the fee paid to validators is based on gas limit and price
 		feeAmt := sdk.NewCoins(
-			sdk.NewCoin(emint.DenomDefault, sdk.NewIntFromBigInt(cost)),
+			sdk.NewCoin(params.EvmDenom, gasPrice.Mul(sdk.NewIntFromBigInt(new(big.Int).SetUint64(gasLimit)))),
 		)
 
+		balance := senderAcc.GetCoins()
+		if !balance.IsAllGTE(feeAmt) {
+			return ctx, sdkerrors.Wrapf(
+				sdkerrors.ErrInsufficientFunds,
+				"insufficient balance to pay for the gas fee: %s < %s", balance, feeAmt,
+			)
+		}
+
 		err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
 		if err != nil {
 			return ctx, err
@@ -315,47 +519,3 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 
 	return next(newCtx, tx, simulate)
 }
-
-// IncrementSenderSequenceDecorator increments the sequence of the signers. The
-// main difference with the SDK's IncrementSequenceDecorator is that the MsgEthereumTx
-// doesn't implement the SigVerifiableTx interface.
-//
-// CONTRACT: must be called after msg.VerifySig in order to cache the sender address.
-type IncrementSenderSequenceDecorator struct {
-	ak auth.AccountKeeper
-}
-
-// NewIncrementSenderSequenceDecorator creates a new IncrementSenderSequenceDecorator.
-func NewIncrementSenderSequenceDecorator(ak auth.AccountKeeper) IncrementSenderSequenceDecorator {
-	return IncrementSenderSequenceDecorator{
-		ak: ak,
-	}
-}
-
-// AnteHandle handles incrementing the sequence of the sender.
-func (issd IncrementSenderSequenceDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
-	// no need to increment sequence on RecheckTx
-	if ctx.IsReCheckTx() && !simulate {
-		return next(ctx, tx, simulate)
-	}
-
-	// get and set account must be called with an infinite gas meter in order to prevent
-	// additional gas from being deducted.
-	oldCtx := ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
-
-	msgEthTx, ok := tx.(evmtypes.MsgEthereumTx)
-	if !ok {
-		return ctx, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "invalid transaction type: %T", tx)
-	}
-
-	// increment sequence of all signers
-	for _, addr := range msgEthTx.GetSigners() {
-		acc := issd.ak.GetAccount(oldCtx, addr)
-		if err := acc.SetSequence(acc.GetSequence() + 1); err != nil {
-			panic(err)
-		}
-		issd.ak.SetAccount(oldCtx, acc)
-	}
-
-	return next(ctx, tx, simulate)
-}
@@ -0,0 +1,214 @@
+package ante
+
+import (
+	"math/big"
+	"sync"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	emint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultPriceBump is the default minimum gas price increase, in percent, for a
+// pending Ethereum transaction to be replaced by a transaction with the same
+// sender and nonce. It applies to both the gas fee cap and the gas tip cap of
+// the EIP-1559 transactions.
+const DefaultPriceBump uint64 = 10
+
+// DefaultMaxPendingTxs is the default maximum number of pending Ethereum
+// transactions tracked for replacement, the size of the default Tendermint
+// mempool.
+const DefaultMaxPendingTxs = 5000
+
+// pendingTx defines a pending Ethereum transaction of the mempool.
+type pendingTx struct {
+	hash      ethcmn.Hash
+	gasFeeCap *big.Int
+	gasTipCap *big.Int
+}
+
+// PendingTxs tracks the pending Ethereum transactions accepted by the mempool by
+// sender and nonce, so that a pending transaction can be replaced by another
+// transaction with the same nonce and a gas fee cap and a gas tip cap higher by
+// at least the price bump, eg. to speed it up. Both caps are the gas price of
+// the non EIP-1559 transactions. The replaced transaction is rejected, and thus
+// evicted from the mempool, when it is rechecked after the next block.
+//
+// The pending transactions are local to the node and don't affect the delivery
+// of the transactions, they are removed once delivered or once their nonce is
+// below the sender account nonce on recheck. Hence the replacement check only
+// applies to the local mempool: the mempools of the other nodes may still hold
+// the original transaction, so both the original and its replacement can be
+// proposed, the first delivered one consuming the nonce. At most the max pending
+// transactions are tracked, the transactions accepted beyond it can't be
+// replaced.
+type PendingTxs struct {
+	mtx       sync.Mutex
+	priceBump uint64
+	maxTxs    int
+	size      int
+	txs       map[string]map[uint64]pendingTx // sender to nonce to transaction
+}
+
+// NewPendingTxs returns new pending transactions with the given price bump, in
+// percent.
+func NewPendingTxs(priceBump uint64) *PendingTxs {
+	return &PendingTxs{
+		priceBump: priceBump,
+		maxTxs:    DefaultMaxPendingTxs,
+		txs:       make(map[string]map[uint64]pendingTx),
+	}
+}
+
+// SetPriceBump sets the minimum gas fee cap and gas tip cap increase, in
+// percent, of the replacement transactions.
+func (p *PendingTxs) SetPriceBump(priceBump uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.priceBump = priceBump
+}
+
+// SetMaxTxs sets the maximum number of pending transactions tracked.
+func (p *PendingTxs) SetMaxTxs(maxTxs int) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.maxTxs = maxTxs
+}
+
+// Len returns the number of pending transactions tracked.
+func (p *PendingTxs) Len() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.size
+}
+
+// CheckReplacement returns true if the transaction replaces a pending
+// transaction with the same sender and nonce. An error is returned if the gas
+// fee cap or the gas tip cap of the transaction isn't higher than the one of the
+// pending transaction by at least the price bump.
+func (p *PendingTxs) CheckReplacement(sender string, msg *evmtypes.MsgEthereumTx) (bool, error) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pending, ok := p.txs[sender][msg.Data.AccountNonce]
+	if !ok || pending.hash == msg.Hash() {
+		return false, nil
+	}
+
+	caps := []struct {
+		name    string
+		price   *big.Int
+		pending *big.Int
+	}{
+		{"gas fee cap", msg.GasFeeCap(), pending.gasFeeCap},
+		{"gas tip cap", msg.GasTipCap(), pending.gasTipCap},
+	}
+
+	for _, c := range caps {
+		// the cap must be at least pending * (100 + bump) / 100
+		minPrice := new(big.Int).Mul(c.pending, new(big.Int).SetUint64(100+p.priceBump))
+		minPrice.Div(minPrice, big.NewInt(100))
+
+		if c.price.Cmp(minPrice) < 0 || c.price.Cmp(c.pending) <= 0 {
+			return false, sdkerrors.Wrapf(
+				emint.ErrReplacementUnderpriced,
+				"%s %s of nonce %d below %s (%d%% above pending transaction %s)",
+				c.name, c.price, msg.Data.AccountNonce, minPrice, p.priceBump, pending.hash.Hex(),
+			)
+		}
+	}
+
+	return true, nil
+}
+
+// IsReplaced returns true if the pending transaction of the sender and nonce of
+// the transaction has been replaced by another transaction.
+func (p *PendingTxs) IsReplaced(sender string, msg *evmtypes.MsgEthereumTx) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	pending, ok := p.txs[sender][msg.Data.AccountNonce]
+	return ok && pending.hash != msg.Hash()
+}
+
+// Add sets the transaction as the pending transaction of its sender and nonce.
+// The transaction isn't tracked if the max pending transactions are tracked,
+// unless it replaces one of them.
+func (p *PendingTxs) Add(sender string, msg *evmtypes.MsgEthereumTx) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if _, ok := p.txs[sender][msg.Data.AccountNonce]; !ok {
+		if p.size >= p.maxTxs {
+			return
+		}
+		p.size++
+	}
+
+	nonces, ok := p.txs[sender]
+	if !ok {
+		nonces = make(map[uint64]pendingTx)
+		p.txs[sender] = nonces
+	}
+
+	nonces[msg.Data.AccountNonce] = pendingTx{
+		hash:      msg.Hash(),
+		gasFeeCap: msg.GasFeeCap(),
+		gasTipCap: msg.GasTipCap(),
+	}
+}
+
+// Remove removes the pending transaction of the sender and nonce.
+func (p *PendingTxs) Remove(sender string, nonce uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.remove(sender, nonce)
+}
+
+// RemoveTx removes the transaction if it is the pending transaction of its
+// sender and nonce.
+func (p *PendingTxs) RemoveTx(sender string, msg *evmtypes.MsgEthereumTx) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if pending, ok := p.txs[sender][msg.Data.AccountNonce]; ok && pending.hash == msg.Hash() {
+		p.remove(sender, msg.Data.AccountNonce)
+	}
+}
+
+// Prune removes the pending transactions of the sender with a nonce below the
+// given account nonce, which were delivered by a block not including them from
+// this mempool.
+func (p *PendingTxs) Prune(sender string, seq uint64) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for nonce := range p.txs[sender] {
+		if nonce < seq {
+			p.remove(sender, nonce)
+		}
+	}
+}
+
+func (p *PendingTxs) remove(sender string, nonce uint64) {
+	nonces, ok := p.txs[sender]
+	if !ok {
+		return
+	}
+	if _, ok := nonces[nonce]; !ok {
+		return
+	}
+
+	delete(nonces, nonce)
+	p.size--
+	if len(nonces) == 0 {
+		delete(p.txs, sender)
+	}
+}
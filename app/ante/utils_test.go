@@ -2,7 +2,6 @@ package ante_test
 
 import (
 	"fmt"
-	"math/big"
 	"testing"
 	"time"
 
@@ -38,7 +37,7 @@ func (suite *AnteTestSuite) SetupTest() {
 	suite.app.Codec().RegisterConcrete(&sdk.TestMsg{}, "test/TestMsg", nil)
 
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "3", Time: time.Now().UTC()})
-	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, suite.app.SupplyKeeper)
+	suite.anteHandler = ante.NewAnteHandler(suite.app.AccountKeeper, &suite.app.EvmKeeper, suite.app.SupplyKeeper, nil)
 }
 
 func TestAnteTestSuite(t *testing.T) {
@@ -89,9 +88,9 @@ func newTestSDKTx(
 }
 
 func newTestEthTx(ctx sdk.Context, msg evmtypes.MsgEthereumTx, priv tmcrypto.PrivKey) sdk.Tx {
-	chainID, ok := new(big.Int).SetString(ctx.ChainID(), 10)
-	if !ok {
-		panic(fmt.Sprintf("invalid chainID: %s", ctx.ChainID()))
+	chainID, err := emint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		panic(err)
 	}
 
 	privkey, ok := priv.(crypto.PrivKeySecp256k1)
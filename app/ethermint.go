@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	cryptokeys "github.com/cosmos/cosmos-sdk/crypto/keys"
 	"github.com/cosmos/cosmos-sdk/simapp"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -28,6 +29,7 @@ import (
 	emintcrypto "github.com/cosmos/ethermint/crypto"
 	eminttypes "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
@@ -72,6 +74,7 @@ var (
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
+		evm.ModuleName:            {supply.Burner},
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -101,8 +104,14 @@ type EthermintApp struct {
 	*bam.BaseApp
 	cdc *codec.Codec
 
+	// root multistore, loading the past versions of the state
+	cms sdk.CommitMultiStore
+
 	invCheckPeriod uint
 
+	// pending Ethereum transactions of the mempool, which can be replaced
+	PendingTxs *ante.PendingTxs
+
 	// keys to access the substores
 	keys  map[string]*sdk.KVStoreKey
 	tkeys map[string]*sdk.TransientStoreKey
@@ -140,6 +149,11 @@ func NewEthermintApp(
 
 	cdc := MakeCodec()
 
+	// set the root multistore before the other options, eg. the pruning, so that
+	// the app keeps a reference to it for the historical state queries
+	cms := store.NewCommitMultiStore(db)
+	baseAppOptions = append([]func(*bam.BaseApp){func(bApp *bam.BaseApp) { bApp.SetCMS(cms) }}, baseAppOptions...)
+
 	// use custom Ethermint transaction decoder
 	bApp := bam.NewBaseApp(appName, logger, db, evm.TxDecoder(cdc), baseAppOptions...)
 	bApp.SetCommitMultiStoreTracer(traceStore)
@@ -157,6 +171,7 @@ func NewEthermintApp(
 	app := &EthermintApp{
 		BaseApp:        bApp,
 		cdc:            cdc,
+		cms:            cms,
 		invCheckPeriod: invCheckPeriod,
 		keys:           keys,
 		tkeys:          tkeys,
@@ -173,6 +188,7 @@ func NewEthermintApp(
 	app.subspaces[slashing.ModuleName] = app.ParamsKeeper.Subspace(slashing.DefaultParamspace)
 	app.subspaces[gov.ModuleName] = app.ParamsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
 	app.subspaces[crisis.ModuleName] = app.ParamsKeeper.Subspace(crisis.DefaultParamspace)
+	app.subspaces[evm.ModuleName] = app.ParamsKeeper.Subspace(evm.DefaultParamspace)
 
 	// use custom Ethermint account for contracts
 	app.AccountKeeper = auth.NewAccountKeeper(
@@ -204,7 +220,8 @@ func NewEthermintApp(
 		app.subspaces[crisis.ModuleName], invCheckPeriod, app.SupplyKeeper, auth.FeeCollectorName,
 	)
 	app.EvmKeeper = evm.NewKeeper(
-		app.cdc, blockKey, keys[evm.CodeKey], keys[evm.StoreKey], app.AccountKeeper,
+		app.cdc, blockKey, keys[evm.CodeKey], keys[evm.StoreKey], app.subspaces[evm.ModuleName],
+		app.AccountKeeper, app.SupplyKeeper,
 	)
 
 	// register the proposal types
@@ -236,7 +253,7 @@ func NewEthermintApp(
 		slashing.NewAppModule(app.SlashingKeeper, app.AccountKeeper, app.StakingKeeper),
 		distr.NewAppModule(app.DistrKeeper, app.AccountKeeper, app.SupplyKeeper, app.StakingKeeper),
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
-		evm.NewAppModule(app.EvmKeeper),
+		evm.NewAppModule(app.EvmKeeper, app.AccountKeeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -271,7 +288,8 @@ func NewEthermintApp(
 	// initialize BaseApp
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
-	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, app.SupplyKeeper))
+	app.PendingTxs = ante.NewPendingTxs(ante.DefaultPriceBump)
+	app.SetAnteHandler(ante.NewAnteHandler(app.AccountKeeper, &app.EvmKeeper, app.SupplyKeeper, app.PendingTxs))
 	app.SetEndBlocker(app.EndBlocker)
 
 	if loadLatest {
@@ -292,6 +310,22 @@ func (app *EthermintApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBloc
 	return app.mm.BeginBlock(ctx, req)
 }
 
+// DeliverTx delivers a transaction and counts the Ethereum transactions of the
+// block, whether they succeed or not. The index of an Ethereum transaction in
+// the block is thus its position among the Ethereum transactions of the block,
+// as returned by the Web3 API.
+func (app *EthermintApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := app.BaseApp.DeliverTx(req)
+
+	if tx, err := evmtypes.TxDecoder(app.cdc)(req.Tx); err == nil {
+		if _, ok := tx.(evmtypes.MsgEthereumTx); ok {
+			app.EvmKeeper.IncrementTxCount()
+		}
+	}
+
+	return res
+}
+
 // EndBlocker updates every end block
 func (app *EthermintApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return app.mm.EndBlock(ctx, req)
@@ -336,6 +370,12 @@ func (app *EthermintApp) GetKey(storeKey string) *sdk.KVStoreKey {
 	return app.keys[storeKey]
 }
 
+// CommitMultiStore returns the root multistore of the app. A context built on it
+// can query the state of a past block height through the EVM keeper WithHeight.
+func (app *EthermintApp) CommitMultiStore() sdk.CommitMultiStore {
+	return app.cms
+}
+
 // Codec returns Ethermint's codec.
 //
 // NOTE: This is solely to be used for testing purposes as it may be desirable
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"math/big"
 	"os"
 	"testing"
 
@@ -10,6 +11,8 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
@@ -35,3 +38,29 @@ func TestEthermintAppExport(t *testing.T) {
 	_, _, err = app2.ExportAppStateAndValidators(false, []string{})
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestEthermintAppDeliverTxCount(t *testing.T) {
+	app := Setup(false)
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	// an unsigned Ethereum transaction fails, but is counted in the block
+	ethTx := evmtypes.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), nil)
+	bz, err := app.cdc.MarshalBinaryLengthPrefixed(ethTx)
+	require.NoError(t, err)
+
+	res := app.DeliverTx(abci.RequestDeliverTx{Tx: bz})
+	require.False(t, res.IsOK())
+	require.Equal(t, 1, app.EvmKeeper.GetTxCount())
+
+	// the other transactions aren't counted
+	res = app.DeliverTx(abci.RequestDeliverTx{Tx: []byte("invalid")})
+	require.False(t, res.IsOK())
+	require.Equal(t, 1, app.EvmKeeper.GetTxCount())
+
+	app.EndBlock(abci.RequestEndBlock{Height: 1})
+	app.Commit()
+
+	// require the count to be reset for the next block
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	require.Equal(t, 0, app.EvmKeeper.GetTxCount())
+}
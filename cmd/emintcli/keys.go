@@ -4,8 +4,6 @@ import (
 	"bufio"
 	"io"
 
-	tmcrypto "github.com/tendermint/tendermint/crypto"
-
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	clientkeys "github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
@@ -54,10 +52,10 @@ func keyCommands() *cobra.Command {
 
 func getKeybase(dryrun bool, buf io.Reader) (keys.Keybase, error) {
 	if dryrun {
-		return keys.NewInMemory(keys.WithKeygenFunc(ethermintKeygenFunc)), nil
+		return keys.NewInMemory(keys.WithKeygenFunc(emintCrypto.EthermintKeygenFunc)), nil
 	}
 
-	return clientkeys.NewKeyringFromHomeFlag(buf, keys.WithKeygenFunc(ethermintKeygenFunc))
+	return clientkeys.NewKeyringFromHomeFlag(buf, keys.WithKeygenFunc(emintCrypto.EthermintKeygenFunc))
 }
 
 func runAddCmd(cmd *cobra.Command, args []string) error {
@@ -69,7 +67,3 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 
 	return clientkeys.RunAddCmd(cmd, args, kb, inBuf)
 }
-
-func ethermintKeygenFunc(bz [32]byte) tmcrypto.PrivKey {
-	return emintCrypto.PrivKeySecp256k1(bz[:])
-}
@@ -45,6 +45,9 @@ func main() {
 	config.SetBech32PrefixForAccount(sdk.Bech32PrefixAccAddr, sdk.Bech32PrefixAccPub)
 	config.SetBech32PrefixForValidator(sdk.Bech32PrefixValAddr, sdk.Bech32PrefixValPub)
 	config.SetBech32PrefixForConsensusNode(sdk.Bech32PrefixConsAddr, sdk.Bech32PrefixConsPub)
+	// derive the keys on the Ethereum BIP-44 path so that they match other wallets
+	config.SetCoinType(emintcrypto.EthereumCoinType)
+	config.SetFullFundraiserPath(emintcrypto.EthereumFullFundraiserPath)
 	config.Seal()
 
 	rootCmd := &cobra.Command{
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
@@ -23,7 +22,10 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/app/ante"
 	emintcrypto "github.com/cosmos/ethermint/crypto"
+	emint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmamino "github.com/tendermint/tendermint/crypto/encoding/amino"
@@ -33,9 +35,23 @@ import (
 	dbm "github.com/tendermint/tm-db"
 )
 
-const flagInvCheckPeriod = "inv-check-period"
+const (
+	flagInvCheckPeriod  = "inv-check-period"
+	flagTxPriceBump     = "tx-price-bump"
+	flagMaxPendingTxs   = "max-pending-txs"
+	flagMaxQueryTxs     = "max-query-txs"
+	flagMaxFilterBlocks = "max-filter-blocks"
+	flagMaxFilterLogs   = "max-filter-logs"
+)
 
-var invCheckPeriod uint
+var (
+	invCheckPeriod  uint
+	txPriceBump     uint64
+	maxPendingTxs   int
+	maxQueryTxs     int
+	maxFilterBlocks int64
+	maxFilterLogs   int
+)
 
 func main() {
 	cobra.EnableCommandSorting = false
@@ -85,6 +101,16 @@ func main() {
 	executor := cli.PrepareBaseCmd(rootCmd, "EM", app.DefaultNodeHome)
 	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
 		0, "Assert registered invariants every N blocks")
+	rootCmd.PersistentFlags().Uint64Var(&txPriceBump, flagTxPriceBump,
+		ante.DefaultPriceBump, "Minimum gas price increase, in percent, to replace a pending Ethereum transaction with the same nonce, applied to both fee caps of the EIP-1559 transactions")
+	rootCmd.PersistentFlags().IntVar(&maxPendingTxs, flagMaxPendingTxs,
+		ante.DefaultMaxPendingTxs, "Maximum number of pending Ethereum transactions tracked for replacement")
+	rootCmd.PersistentFlags().IntVar(&maxQueryTxs, flagMaxQueryTxs,
+		evm.DefaultMaxRecipientTxs, "Maximum number of Ethereum transactions returned by the transactions by recipient query")
+	rootCmd.PersistentFlags().Int64Var(&maxFilterBlocks, flagMaxFilterBlocks,
+		evm.DefaultMaxFilterBlocks, "Maximum number of blocks of the range of the filter logs query")
+	rootCmd.PersistentFlags().IntVar(&maxFilterLogs, flagMaxFilterLogs,
+		evm.DefaultMaxFilterLogs, "Maximum number of logs returned by the filter logs query")
 	err := executor.Execute()
 	if err != nil {
 		panic(err)
@@ -92,8 +118,14 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewEthermintApp(logger, db, traceStore, true, 0,
+	emintApp := app.NewEthermintApp(logger, db, traceStore, true, 0,
 		baseapp.SetPruning(store.NewPruningOptionsFromString(viper.GetString("pruning"))))
+	emintApp.PendingTxs.SetPriceBump(txPriceBump)
+	emintApp.PendingTxs.SetMaxTxs(maxPendingTxs)
+	emintApp.EvmKeeper.SetMaxRecipientTxs(maxQueryTxs)
+	emintApp.EvmKeeper.SetMaxFilterBlocks(maxFilterBlocks)
+	emintApp.EvmKeeper.SetMaxFilterLogs(maxFilterLogs)
+	return emintApp
 }
 
 func exportAppStateAndTMValidators(
@@ -114,7 +146,7 @@ func exportAppStateAndTMValidators(
 	return emintApp.ExportAppStateAndValidators(forZeroHeight, jailWhiteList)
 }
 
-// Wraps cobra command with a RunE function with integer chain-id verification
+// Wraps cobra command with a RunE function with EIP-155 chain-id verification
 func withChainIDValidation(baseCmd *cobra.Command) *cobra.Command {
 	// Copy base run command to be used after chain verification
 	baseRunE := baseCmd.RunE
@@ -123,10 +155,9 @@ func withChainIDValidation(baseCmd *cobra.Command) *cobra.Command {
 	chainIDVerify := func(cmd *cobra.Command, args []string) error {
 		chainIDFlag := viper.GetString(client.FlagChainID)
 
-		// Verify that the chain-id entered is a base 10 integer
-		_, ok := new(big.Int).SetString(chainIDFlag, 10)
-		if !ok {
-			return fmt.Errorf("invalid chainID: %s, must be base-10 integer format", chainIDFlag)
+		// Verify that the chain-id entered has a trailing base-10 integer
+		if _, err := emint.ParseChainID(chainIDFlag); err != nil {
+			return fmt.Errorf("invalid chainID: %s, must have a trailing base-10 integer (eg. ethermint-7): %w", chainIDFlag, err)
 		}
 
 		return baseRunE(cmd, args)
@@ -0,0 +1,39 @@
+package crypto
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	"github.com/cosmos/go-bip39"
+)
+
+// BIP44HDPath is the default BIP-44 derivation path of the first Ethereum
+// account, as used by MetaMask and Ledger (m/44'/60'/0'/0/x).
+const BIP44HDPath = "m/44'/60'/0'/0/0"
+
+// DeriveKeyFromMnemonic derives a private key from a BIP-39 mnemonic following
+// the given BIP-44 derivation path (eg. m/44'/60'/0'/0/0). The mnemonic seed is
+// generated without passphrase so the same addresses than MetaMask and Ledger
+// are derived for a given mnemonic.
+func DeriveKeyFromMnemonic(mnemonic, hdPath string) (*PrivKeySecp256k1, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+
+	// validate the path levels and their hardening
+	params, err := hd.NewParamsFromPath(strings.TrimPrefix(hdPath, "m/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid HD path %s: %w", hdPath, err)
+	}
+
+	masterPriv, chainCode := hd.ComputeMastersFromSeed(seed)
+	derivedKey, err := hd.DerivePrivateKeyForPath(masterPriv, chainCode, params.String())
+	if err != nil {
+		return nil, err
+	}
+
+	privKey := PrivKeySecp256k1(derivedKey[:])
+	return &privKey, nil
+}
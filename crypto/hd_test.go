@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"fmt"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveKeyFromMnemonic(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+
+	// addresses derived by MetaMask and Ledger for the mnemonic above
+	expAddresses := []string{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+		"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+	}
+
+	for i, expAddr := range expAddresses {
+		privKey, err := DeriveKeyFromMnemonic(mnemonic, fmt.Sprintf("m/44'/60'/0'/0/%d", i))
+		require.NoError(t, err)
+
+		addr := ethcrypto.PubkeyToAddress(privKey.ToECDSA().PublicKey)
+		require.Equal(t, expAddr, addr.Hex())
+	}
+
+	// require the default path to derive the first account
+	privKey, err := DeriveKeyFromMnemonic(mnemonic, BIP44HDPath)
+	require.NoError(t, err)
+	require.Equal(t, expAddresses[0], ethcrypto.PubkeyToAddress(privKey.ToECDSA().PublicKey).Hex())
+
+	// require invalid mnemonics and paths to fail
+	_, err = DeriveKeyFromMnemonic("test test test test test test test test test test test test", BIP44HDPath)
+	require.Error(t, err)
+
+	_, err = DeriveKeyFromMnemonic(mnemonic, "m/44'/60'/0'/0")
+	require.Error(t, err)
+
+	_, err = DeriveKeyFromMnemonic(mnemonic, "m/44/60/0/0/0")
+	require.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package crypto
+
+import (
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+const (
+	// EthereumCoinType is the BIP-44 coin type of Ethereum as defined in SLIP-44
+	EthereumCoinType = 60
+
+	// EthereumFullFundraiserPath is the BIP-44 path of the first Ethereum account
+	// without the master key prefix, as expected by the SDK keybase configuration.
+	EthereumFullFundraiserPath = "44'/60'/0'/0/0"
+)
+
+// EthermintKeygenFunc is the key generation function of the SDK keybase for the
+// Ethermint secp256k1 keys. Keybases created with this function through
+// keys.WithKeygenFunc store PrivKeySecp256k1 keys, which sign with the Keccak256
+// hash of the message and have an address equal to the Ethereum address.
+//
+// NOTE: the Ethermint key types must be registered on the Tendermint amino codec
+// (see tmamino.RegisterKeyType) so that the keys can be stored and armored.
+func EthermintKeygenFunc(bz [32]byte) tmcrypto.PrivKey {
+	return PrivKeySecp256k1(bz[:])
+}
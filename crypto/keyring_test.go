@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	tmamino "github.com/tendermint/tendermint/crypto/encoding/amino"
+)
+
+func init() {
+	tmamino.RegisterKeyType(PubKeySecp256k1{}, PubKeyAminoName)
+	tmamino.RegisterKeyType(PrivKeySecp256k1{}, PrivKeyAminoName)
+
+	// the keybase info codec must know the Ethermint key types
+	cdc := codec.New()
+	codec.RegisterCrypto(cdc)
+	RegisterCodec(cdc)
+	keys.RegisterCodec(cdc)
+	keys.CryptoCdc = cdc
+}
+
+func TestKeybase(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	password := "12345678"
+
+	kb := keys.NewInMemory(keys.WithKeygenFunc(EthermintKeygenFunc))
+
+	// require the keys to be derived on the Ethereum path
+	params, err := hd.NewParamsFromPath(EthereumFullFundraiserPath)
+	require.NoError(t, err)
+
+	info, err := kb.Derive("key", mnemonic, "", password, *params)
+	require.NoError(t, err)
+	require.IsType(t, PubKeySecp256k1{}, info.GetPubKey())
+
+	// require the keybase address to match the Ethereum address of the key
+	privKey, err := DeriveKeyFromMnemonic(mnemonic, BIP44HDPath)
+	require.NoError(t, err)
+	require.Equal(t, privKey.PubKey(), info.GetPubKey())
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", ethcmn.BytesToAddress(info.GetAddress().Bytes()).Hex())
+
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, info.GetAddress(), infos[0].GetAddress())
+
+	// require the keybase to sign with the Ethermint key
+	msg := []byte("message")
+	sig, pubKey, err := kb.Sign("key", password, msg)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), pubKey)
+	require.True(t, pubKey.VerifyBytes(msg, sig))
+
+	expSig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Equal(t, expSig, sig)
+
+	// require the armored private key to round-trip
+	armor, err := kb.ExportPrivKey("key", password, "new_password")
+	require.NoError(t, err)
+
+	kb2 := keys.NewInMemory(keys.WithKeygenFunc(EthermintKeygenFunc))
+	err = kb2.ImportPrivKey("imported", armor, "new_password")
+	require.NoError(t, err)
+
+	imported, err := kb2.Get("imported")
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), imported.GetPubKey())
+
+	exported, err := kb2.ExportPrivateKeyObject("imported", "new_password")
+	require.NoError(t, err)
+	require.Equal(t, *privKey, exported)
+
+	// require a wrong passphrase to fail the import
+	err = kb2.ImportPrivKey("wrong", armor, "wrong_password")
+	require.Error(t, err)
+}
@@ -3,7 +3,10 @@ package crypto
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
 
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	ethsecp256k1 "github.com/ethereum/go-ethereum/crypto/secp256k1"
 
@@ -55,6 +58,20 @@ func (privkey PrivKeySecp256k1) Sign(msg []byte) ([]byte, error) {
 	return ethcrypto.Sign(ethcrypto.Keccak256Hash(msg).Bytes(), privkey.ToECDSA())
 }
 
+// SignPersonalMessage creates a recoverable ECDSA signature over the Keccak256
+// hash of the personal message of the provided data, as produced by
+// personal_sign. The produced signature is 65 bytes where the last byte is the
+// recovery ID plus 27, ie. 27 or 28 for legacy reasons.
+func (privkey PrivKeySecp256k1) SignPersonalMessage(data []byte) ([]byte, error) {
+	sig, err := privkey.Sign(PersonalMessage(data))
+	if err != nil {
+		return nil, err
+	}
+
+	sig[recoveryIDOffset] += 27
+	return sig, nil
+}
+
 // Equals returns true if two ECDSA private keys are equal and false otherwise.
 func (privkey PrivKeySecp256k1) Equals(other tmcrypto.PrivKey) bool {
 	if other, ok := other.(PrivKeySecp256k1); ok {
@@ -115,3 +132,45 @@ func (key PubKeySecp256k1) Equals(other tmcrypto.PubKey) bool {
 
 	return false
 }
+
+// ----------------------------------------------------------------------------
+// Personal messages
+
+const (
+	// signatureLength is the length of a recoverable signature [R || S || V]
+	signatureLength = 65
+	// recoveryIDOffset is the offset of the recovery ID V in a signature
+	recoveryIDOffset = 64
+)
+
+// PersonalMessage returns the provided data prefixed as the personal messages
+// signed by personal_sign and eth_sign:
+// "\x19Ethereum Signed Message:\n" + len(data) + data
+func PersonalMessage(data []byte) []byte {
+	return append([]byte(fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(data))), data...)
+}
+
+// RecoverPersonalMessageSigner returns the address of the key that produced the
+// signature over the personal message of the provided data. The recovery ID of
+// the 65 bytes signature may be 0/1 or 27/28.
+func RecoverPersonalMessageSigner(data, sig []byte) (ethcmn.Address, error) {
+	if len(sig) != signatureLength {
+		return ethcmn.Address{}, fmt.Errorf("invalid signature length %d, expected %d", len(sig), signatureLength)
+	}
+
+	// transform V from 27/28 to 0/1 without modifying the provided signature
+	sig = append([]byte{}, sig...)
+	if v := sig[recoveryIDOffset]; v == 27 || v == 28 {
+		sig[recoveryIDOffset] -= 27
+	}
+	if sig[recoveryIDOffset] > 1 {
+		return ethcmn.Address{}, errors.New("invalid signature recovery ID, expected 0, 1, 27 or 28")
+	}
+
+	pubKey, err := ethcrypto.SigToPub(ethcrypto.Keccak256(PersonalMessage(data)), sig)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	return ethcrypto.PubkeyToAddress(*pubKey), nil
+}
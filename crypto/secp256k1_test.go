@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts"
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	ethsecp256k1 "github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/stretchr/testify/require"
@@ -59,3 +61,49 @@ func TestPrivKeySecp256k1PubKey(t *testing.T) {
 	res := pubKey.VerifyBytes(msg, sig)
 	require.True(t, res)
 }
+
+func TestPersonalMessage(t *testing.T) {
+	privKey := PrivKeySecp256k1(ethcmn.FromHex("0xb71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291"))
+	addr := ethcmn.BytesToAddress(privKey.PubKey().Address().Bytes())
+	msg := []byte("Sign in to ethermint")
+
+	// require the personal message hash to match the one of geth
+	require.Equal(t, []byte("\x19Ethereum Signed Message:\n20Sign in to ethermint"), PersonalMessage(msg))
+	require.Equal(t, accounts.TextHash(msg), ethcrypto.Keccak256(PersonalMessage(msg)))
+
+	sig, err := privKey.SignPersonalMessage(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+	require.True(t, sig[64] == 27 || sig[64] == 28)
+
+	expectedSig, err := ethcrypto.Sign(accounts.TextHash(msg), privKey.ToECDSA())
+	require.NoError(t, err)
+	require.Equal(t, expectedSig[:64], sig[:64])
+	require.Equal(t, expectedSig[64]+27, sig[64])
+
+	// require the signer to be recovered with a V value of 27/28 or 0/1
+	signer, err := RecoverPersonalMessageSigner(msg, sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, signer)
+
+	signer, err = RecoverPersonalMessageSigner(msg, expectedSig)
+	require.NoError(t, err)
+	require.Equal(t, addr, signer)
+
+	// require the provided signature to be left unmodified
+	require.True(t, sig[64] == 27 || sig[64] == 28)
+
+	// require another message to recover another address
+	signer, err = RecoverPersonalMessageSigner([]byte("Sign in to ethermint!"), sig)
+	require.NoError(t, err)
+	require.NotEqual(t, addr, signer)
+
+	// require invalid signatures to fail
+	_, err = RecoverPersonalMessageSigner(msg, sig[:64])
+	require.Error(t, err)
+
+	invalidSig := append([]byte{}, sig...)
+	invalidSig[64] = 29
+	_, err = RecoverPersonalMessageSigner(msg, invalidSig)
+	require.Error(t, err)
+}
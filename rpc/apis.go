@@ -12,6 +12,8 @@ const Web3Namespace = "web3"
 const EthNamespace = "eth"
 const PersonalNamespace = "personal"
 const NetNamespace = "net"
+const DebugNamespace = "debug"
+const TxPoolNamespace = "txpool"
 
 // GetRPCAPIs returns the list of all APIs
 func GetRPCAPIs(cliCtx context.CLIContext, key emintcrypto.PrivKeySecp256k1) []rpc.API {
@@ -33,7 +35,7 @@ func GetRPCAPIs(cliCtx context.CLIContext, key emintcrypto.PrivKeySecp256k1) []r
 		{
 			Namespace: PersonalNamespace,
 			Version:   "1.0",
-			Service:   NewPersonalEthAPI(cliCtx, nonceLock),
+			Service:   NewPersonalEthAPI(cliCtx, nonceLock, key),
 			Public:    false,
 		},
 		{
@@ -42,11 +44,29 @@ func GetRPCAPIs(cliCtx context.CLIContext, key emintcrypto.PrivKeySecp256k1) []r
 			Service:   NewPublicFilterAPI(cliCtx, backend),
 			Public:    true,
 		},
+		{
+			Namespace: EthNamespace,
+			Version:   "1.0",
+			Service:   NewPubSubAPI(cliCtx, backend),
+			Public:    true,
+		},
 		{
 			Namespace: NetNamespace,
 			Version:   "1.0",
 			Service:   NewPublicNetAPI(cliCtx),
 			Public:    true,
 		},
+		{
+			Namespace: DebugNamespace,
+			Version:   "1.0",
+			Service:   NewPublicDebugAPI(cliCtx),
+			Public:    true,
+		},
+		{
+			Namespace: TxPoolNamespace,
+			Version:   "1.0",
+			Service:   NewPublicTxPoolAPI(cliCtx),
+			Public:    true,
+		},
 	}
 }
@@ -6,14 +6,25 @@ import (
 	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/ethermint/utils"
 	"github.com/cosmos/ethermint/x/evm"
 	"github.com/cosmos/ethermint/x/evm/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/spf13/viper"
 )
 
+// flagTxOrdering sets the ordering of the pending transactions
+const flagTxOrdering = "tx-ordering"
+
+// maxUnconfirmedTxs is the maximum number of mempool transactions returned by
+// the Tendermint unconfirmed_txs RPC, which caps its limit to its max page size,
+// hence the transactions beyond it aren't accounted for by the Web3 API.
+const maxUnconfirmedTxs = 100
+
 // Backend implements the functionality needed to filter changes.
 // Implemented by EthermintBackend.
 type Backend interface {
@@ -23,6 +34,7 @@ type Backend interface {
 	GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error)
 	getEthBlockByNumber(height int64, fullTx bool) (map[string]interface{}, error)
 	getGasLimit() (int64, error)
+	getBaseFee(height int64) (*big.Int, error)
 
 	// Used by pending transaction filter
 	PendingTransactions() ([]*Transaction, error)
@@ -34,14 +46,21 @@ type Backend interface {
 
 // EmintBackend implements Backend
 type EthermintBackend struct {
-	cliCtx   context.CLIContext
-	gasLimit int64
+	cliCtx     context.CLIContext
+	gasLimit   int64
+	txOrdering string
 }
 
 func NewEthermintBackend(cliCtx context.CLIContext) *EthermintBackend {
+	txOrdering := viper.GetString(flagTxOrdering)
+	if types.ValidateTxOrdering(txOrdering) != nil {
+		txOrdering = types.TxOrderingFIFO
+	}
+
 	return &EthermintBackend{
-		cliCtx:   cliCtx,
-		gasLimit: int64(^uint32(0)),
+		cliCtx:     cliCtx,
+		gasLimit:   int64(^uint32(0)),
+		txOrdering: txOrdering,
 	}
 }
 
@@ -96,26 +115,28 @@ func (e *EthermintBackend) getEthBlockByNumber(height int64, fullTx bool) (map[s
 		return nil, err
 	}
 
-	var (
-		gasUsed      *big.Int
-		transactions []common.Hash
-	)
+	blockHash := common.BytesToHash(header.Hash())
 
+	var transactions interface{}
 	if fullTx {
 		// Populate full transaction data
-		transactions, gasUsed, err = convertTransactionsToRPC(
-			e.cliCtx, block.Block.Txs, common.BytesToHash(header.Hash()), uint64(header.Height),
-		)
+		transactions, err = convertTransactionsToRPC(e.cliCtx, block.Block.Txs, blockHash, uint64(header.Height))
 		if err != nil {
 			return nil, err
 		}
 	} else {
-		// TODO: Gas used not saved and cannot be calculated by hashes
 		// Return slice of transaction hashes
-		transactions = make([]common.Hash, len(block.Block.Txs))
-		for i, tx := range block.Block.Txs {
-			transactions[i] = common.BytesToHash(tx.Hash())
-		}
+		transactions = transactionHashes(e.cliCtx, block.Block.Txs)
+	}
+
+	results, err := e.cliCtx.Client.BlockResults(&block.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	gasUsed := new(big.Int)
+	for _, txResult := range results.Results.DeliverTx {
+		gasUsed.Add(gasUsed, big.NewInt(txResult.GasUsed))
 	}
 
 	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryLogsBloom, strconv.FormatInt(block.Block.Height, 10)))
@@ -126,7 +147,15 @@ func (e *EthermintBackend) getEthBlockByNumber(height int64, fullTx bool) (map[s
 	var out types.QueryBloomFilter
 	e.cliCtx.Codec.MustUnmarshalJSON(res, &out)
 
-	return formatBlock(header, block.Block.Size(), gasLimit, gasUsed, transactions, out.Bloom), nil
+	res, _, err = e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryStateRoot, strconv.FormatInt(block.Block.Height, 10)))
+	if err != nil {
+		return nil, err
+	}
+
+	var root types.QueryResStateRoot
+	e.cliCtx.Codec.MustUnmarshalJSON(res, &root)
+
+	return formatBlock(header, block.Block.Size(), gasLimit, gasUsed, transactions, out.Bloom, common.HexToHash(root.Root)), nil
 }
 
 // getGasLimit returns the gas limit per block set in genesis
@@ -154,6 +183,22 @@ func (e *EthermintBackend) getGasLimit() (int64, error) {
 	return gasLimit, nil
 }
 
+// getBaseFee returns the base fee of the block following the given height, the
+// latest height being queried if it is zero.
+func (e *EthermintBackend) getBaseFee(height int64) (*big.Int, error) {
+	res, _, err := e.cliCtx.WithHeight(height).QueryWithData(fmt.Sprintf("custom/%s/%s", types.ModuleName, evm.QueryBaseFee), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryResBaseFee
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return utils.UnmarshalBigInt(out.BaseFee)
+}
+
 // GetTxLogs returns the logs given a transaction hash.
 func (e *EthermintBackend) GetTxLogs(txHash common.Hash) ([]*ethtypes.Log, error) {
 	// do we need to use the block height somewhere?
@@ -173,22 +218,46 @@ func (e *EthermintBackend) GetTxLogs(txHash common.Hash) ([]*ethtypes.Log, error
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
-// and have a from address that is one of the accounts this node manages.
+// and have a from address that is one of the accounts this node manages. The
+// transactions are returned in the order of the mempool, unless the
+// price-nonce ordering is configured, in which case they are ordered by nonce
+// for each sender and by gas price across the senders.
 func (e *EthermintBackend) PendingTransactions() ([]*Transaction, error) {
-	pendingTxs, err := e.cliCtx.Client.UnconfirmedTxs(100)
+	pendingTxs, err := e.cliCtx.Client.UnconfirmedTxs(maxUnconfirmedTxs)
 	if err != nil {
 		return nil, err
 	}
 
-	transactions := make([]*Transaction, 0, 100)
+	ethTxs := make([]types.MsgEthereumTx, 0, len(pendingTxs.Txs))
 	for _, tx := range pendingTxs.Txs {
 		ethTx, err := bytesToEthTx(e.cliCtx, tx)
 		if err != nil {
 			return nil, err
 		}
+		ethTxs = append(ethTxs, *ethTx)
+	}
+
+	if e.txOrdering == types.TxOrderingPriceNonce {
+		chainID, err := chainIDFromFlags()
+		if err != nil {
+			return nil, err
+		}
+
+		baseFee, err := e.getBaseFee(0)
+		if err != nil {
+			return nil, err
+		}
+
+		ethTxs, err = types.SortTxsByPriceAndNonce(chainID, baseFee, ethTxs)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	transactions := make([]*Transaction, 0, len(ethTxs))
+	for _, ethTx := range ethTxs {
 		// * Should check signer and reference against accounts the node manages in future
-		rpcTx, err := newRPCTransaction(*ethTx, common.BytesToHash(tx.Hash()), common.Hash{}, nil, 0)
+		rpcTx, err := newRPCTransaction(ethTx, common.Hash{}, nil, 0)
 		if err != nil {
 			return nil, err
 		}
@@ -3,7 +3,11 @@ package rpc
 import (
 	"bufio"
 	"fmt"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -14,6 +18,9 @@ import (
 	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	"github.com/cosmos/ethermint/app"
 	emintcrypto "github.com/cosmos/ethermint/crypto"
+	emint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/spf13/cobra"
@@ -21,7 +28,8 @@ import (
 )
 
 const (
-	flagUnlockKey = "unlock-key"
+	flagUnlockKey     = "unlock-key"
+	flagWebsocketPort = "wsport"
 )
 
 // Config contains configuration fields that determine the behavior of the RPC HTTP server.
@@ -45,6 +53,13 @@ func EmintServeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := lcd.ServeCommand(cdc, registerRoutes)
 	cmd.Flags().String(flagUnlockKey, "", "Select a key to unlock on the RPC server")
 	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block)")
+	cmd.Flags().String(flagWebsocketPort, "8546", "The port of the web3 websocket server serving the subscriptions, disabled if empty")
+	cmd.Flags().Int64(flagGasPriceBlocks, defaultGasPriceBlocks, "Number of recent blocks sampled by the eth_gasPrice oracle")
+	cmd.Flags().Int(flagGasPricePercentile, defaultGasPricePercentile, "Percentile of the sampled gas prices suggested by the eth_gasPrice oracle")
+	cmd.Flags().String(flagGasPriceFallback, strconv.Itoa(emint.DefaultGasPrice), "Gas price in wei suggested by the eth_gasPrice oracle if the sampled blocks have no Ethereum transaction")
+	cmd.Flags().Uint64(flagRPCGasCap, emint.DefaultRPCGasLimit, "Gas cap of eth_call and eth_estimateGas, which is the gas of the calls without gas")
+	cmd.Flags().Duration(flagFilterTimeout, defaultFilterTimeout, "Timeout after which the filters not polled by eth_getFilterChanges are uninstalled")
+	cmd.Flags().String(flagTxOrdering, evmtypes.TxOrderingFIFO, "Ordering of the pending transactions (fifo|price-nonce), price-nonce ordering the transactions of each sender by nonce and the senders by gas price")
 	return cmd
 }
 
@@ -93,12 +108,32 @@ func registerRoutes(rs *lcd.RestServer) {
 	// Web3 RPC API route
 	rs.Mux.HandleFunc("/", s.ServeHTTP).Methods("POST", "OPTIONS")
 
+	// Web3 websocket API, served apart from the REST server as the subscriptions
+	// outlive its read and write timeouts
+	if wsPort := viper.GetString(flagWebsocketPort); len(wsPort) > 0 {
+		go startWebsocketServer(s, viper.GetString(flags.FlagListenAddr), wsPort)
+	}
+
 	// Register all other Cosmos routes
 	client.RegisterRoutes(rs.CliCtx, rs.Mux)
 	authrest.RegisterTxRoutes(rs.CliCtx, rs.Mux)
 	app.ModuleBasics.RegisterRESTRoutes(rs.CliCtx, rs.Mux)
 }
 
+// startWebsocketServer serves the web3 RPC API over websocket connections on
+// the given port of the REST server host.
+func startWebsocketServer(s *rpc.Server, listenAddr, port string) {
+	host := "localhost"
+	if u, err := url.Parse(listenAddr); err == nil && len(u.Hostname()) > 0 {
+		host = u.Hostname()
+	}
+
+	addr := net.JoinHostPort(host, port)
+	if err := http.ListenAndServe(addr, s.WebsocketHandler([]string{"*"})); err != nil {
+		log.Error("web3 websocket server failed", "address", addr, "error", err)
+	}
+}
+
 func unlockKeyFromNameAndPassphrase(accountName, passphrase string) (emintKey emintcrypto.PrivKeySecp256k1, err error) {
 	keybase, err := emintkeys.NewKeyringFromHomeFlag(os.Stdin)
 	if err != nil {
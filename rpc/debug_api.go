@@ -0,0 +1,81 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/ethermint/x/evm"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PublicDebugAPI is the debug_ prefixed set of APIs in the Web3 JSON-RPC spec.
+type PublicDebugAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicDebugAPI creates an instance of the public debug Web3 API.
+func NewPublicDebugAPI(cliCtx context.CLIContext) *PublicDebugAPI {
+	return &PublicDebugAPI{
+		cliCtx: cliCtx,
+	}
+}
+
+// TraceTransaction re-executes the transaction identified by hash against the
+// state of its block and returns the opcode level steps of the execution, or
+// its call tree if the callTracer is selected by the config. The transactions
+// preceding it in the block are replayed first. The replay is run on the
+// queried state and never modifies the committed state.
+func (api *PublicDebugAPI) TraceTransaction(hash common.Hash, config *types.TraceConfig) (interface{}, error) {
+	tx, err := getTx(api.cliCtx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s not found: %w", hash.Hex(), err)
+	}
+
+	block, err := api.cliCtx.Client.Block(&tx.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	req := types.TraceTxRequest{
+		Tx:          tx.Tx,
+		BlockHeight: tx.Height,
+		BlockTime:   block.Block.Time,
+	}
+
+	for _, bz := range block.Block.Txs[:tx.Index] {
+		req.Predecessors = append(req.Predecessors, bz)
+	}
+
+	if config != nil {
+		req.Config = *config
+	}
+
+	bz, err := api.cliCtx.Codec.MarshalJSON(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// execute on top of the state of the previous block
+	ctx := api.cliCtx.WithHeight(tx.Height - 1)
+	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", types.ModuleName, evm.QueryTraceTx), bz)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Config.Tracer == types.CallTracerName {
+		var frame types.CallFrame
+		if err := api.cliCtx.Codec.UnmarshalJSON(res, &frame); err != nil {
+			return nil, err
+		}
+		return &frame, nil
+	}
+
+	var result types.ExecutionResult
+	if err := api.cliCtx.Codec.UnmarshalJSON(res, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
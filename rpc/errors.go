@@ -0,0 +1,46 @@
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// JSON-RPC error codes returned by the Web3 API. The server defaults to
+// -32000 for errors that don't define a code.
+const (
+	errCodeInvalidParams     = -32602
+	errCodeTxRejected        = -32003
+	errCodeExecutionReverted = 3
+)
+
+// jsonRPCError is an error that defines the code of the JSON-RPC error object
+// returned to the client.
+type jsonRPCError struct {
+	code    int
+	message string
+}
+
+func newInvalidParamsError(format string, args ...interface{}) *jsonRPCError {
+	return &jsonRPCError{code: errCodeInvalidParams, message: fmt.Sprintf(format, args...)}
+}
+
+func newTxRejectedError(format string, args ...interface{}) *jsonRPCError {
+	return &jsonRPCError{code: errCodeTxRejected, message: fmt.Sprintf(format, args...)}
+}
+
+// newRevertError returns the error of an execution reverted by the REVERT
+// opcode, with the reason of the ABI encoded revert payload if any.
+func newRevertError(ret []byte) *jsonRPCError {
+	reason, err := types.UnpackRevertReason(ret)
+	if err != nil {
+		return &jsonRPCError{code: errCodeExecutionReverted, message: "execution reverted"}
+	}
+	return &jsonRPCError{code: errCodeExecutionReverted, message: fmt.Sprintf("execution reverted: %s", reason)}
+}
+
+// Error implements the error interface.
+func (e *jsonRPCError) Error() string { return e.message }
+
+// ErrorCode implements the go-ethereum rpc.Error interface.
+func (e *jsonRPCError) ErrorCode() int { return e.code }
@@ -2,10 +2,11 @@ package rpc
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"math/big"
+	"strconv"
 	"sync"
 
 	"github.com/spf13/viper"
@@ -18,24 +19,30 @@ import (
 	"github.com/cosmos/ethermint/x/evm"
 	"github.com/cosmos/ethermint/x/evm/types"
 
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/rlp"
+	ethparams "github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/keys"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	authutils "github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 )
 
+// flagRPCGasCap sets the gas cap of eth_call and eth_estimateGas
+const flagRPCGasCap = "rpc-gas-cap"
+
 // PublicEthAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
 type PublicEthAPI struct {
 	cliCtx      context.CLIContext
@@ -43,17 +50,30 @@ type PublicEthAPI struct {
 	key         emintcrypto.PrivKeySecp256k1
 	nonceLock   *AddrLocker
 	keybaseLock sync.Mutex
+	gasPrice    *gasPriceOracle
+	gasCap      uint64
+	// syncStartingBlock is the latest block when the current sync was first
+	// reported, zero if the node is caught up
+	syncStartingBlock int64
+	syncLock          sync.Mutex
 }
 
 // NewPublicEthAPI creates an instance of the public ETH Web3 API.
 func NewPublicEthAPI(cliCtx context.CLIContext, backend Backend, nonceLock *AddrLocker,
 	key emintcrypto.PrivKeySecp256k1) *PublicEthAPI {
 
+	gasCap := viper.GetUint64(flagRPCGasCap)
+	if gasCap == 0 {
+		gasCap = emint.DefaultRPCGasLimit
+	}
+
 	return &PublicEthAPI{
 		cliCtx:    cliCtx,
 		backend:   backend,
 		key:       key,
 		nonceLock: nonceLock,
+		gasPrice:  newGasPriceOracle(cliCtx, backend),
+		gasCap:    gasCap,
 	}
 }
 
@@ -62,25 +82,90 @@ func (e *PublicEthAPI) ProtocolVersion() hexutil.Uint {
 	return hexutil.Uint(version.ProtocolVersion)
 }
 
-// Syncing returns whether or not the current node is syncing with other peers. Returns false if not, or a struct
-// outlining the state of the sync if it is.
+// ChainId returns the EIP-155 chain ID parsed from the configured chain-id, which
+// is the one used to sign and verify the transactions.
+func (e *PublicEthAPI) ChainId() (*hexutil.Big, error) { // nolint
+	chainID, err := chainIDFromFlags()
+	if err != nil {
+		return nil, err
+	}
+
+	return (*hexutil.Big)(chainID), nil
+}
+
+// Syncing returns false if the node is caught up, otherwise the starting,
+// current and highest blocks of the sync. The starting block is the latest
+// block when the sync was first reported and the highest block is the highest
+// block committed by the peers known to the consensus reactor, if any.
 func (e *PublicEthAPI) Syncing() (interface{}, error) {
 	status, err := e.cliCtx.Client.Status()
 	if err != nil {
 		return false, err
 	}
 
+	e.syncLock.Lock()
+	defer e.syncLock.Unlock()
+
 	if !status.SyncInfo.CatchingUp {
+		e.syncStartingBlock = 0
 		return false, nil
 	}
 
+	if e.syncStartingBlock == 0 {
+		e.syncStartingBlock = status.SyncInfo.LatestBlockHeight
+	}
+
+	// the peer heights are only advisory, the sync progress being reported
+	// without them if the consensus state can't be dumped
+	var peers []ctypes.PeerStateInfo
+	if consensus, err := e.cliCtx.Client.DumpConsensusState(); err == nil {
+		peers = consensus.Peers
+	}
+
+	return syncProgress(status.SyncInfo, e.syncStartingBlock, highestPeerBlock(peers)), nil
+}
+
+// syncProgress returns the eth_syncing result of a node from its sync info:
+// false if it is caught up, otherwise the starting, current and highest blocks
+// of the sync, the highest block being at least the current one.
+func syncProgress(syncInfo ctypes.SyncInfo, startingBlock, highestBlock int64) interface{} {
+	if !syncInfo.CatchingUp {
+		return false
+	}
+
+	if highestBlock < syncInfo.LatestBlockHeight {
+		highestBlock = syncInfo.LatestBlockHeight
+	}
+
 	return map[string]interface{}{
-		// "startingBlock": nil, // NA
-		"currentBlock": hexutil.Uint64(status.SyncInfo.LatestBlockHeight),
-		// "highestBlock":  nil, // NA
-		// "pulledStates":  nil, // NA
-		// "knownStates":   nil, // NA
-	}, nil
+		"startingBlock": hexutil.Uint64(startingBlock),
+		"currentBlock":  hexutil.Uint64(syncInfo.LatestBlockHeight),
+		"highestBlock":  hexutil.Uint64(highestBlock),
+	}
+}
+
+// highestPeerBlock returns the highest block committed by the peers from their
+// consensus states, which hold the height they are working on. The peers
+// without a consensus state are skipped.
+func highestPeerBlock(peers []ctypes.PeerStateInfo) int64 {
+	var highest int64
+	for _, peer := range peers {
+		var state struct {
+			RoundState struct {
+				Height string `json:"height"`
+			} `json:"round_state"`
+		}
+		if err := json.Unmarshal(peer.PeerState, &state); err != nil {
+			continue
+		}
+
+		height, err := strconv.ParseInt(state.RoundState.Height, 10, 64)
+		if err == nil && height-1 > highest {
+			highest = height - 1
+		}
+	}
+
+	return highest
 }
 
 // Coinbase returns this node's coinbase address. Not used in Ethermint.
@@ -98,33 +183,61 @@ func (e *PublicEthAPI) Hashrate() hexutil.Uint64 {
 	return 0
 }
 
-// GasPrice returns the current gas price based on Ethermint's gas price oracle.
-func (e *PublicEthAPI) GasPrice() *hexutil.Big {
-	out := big.NewInt(0)
-	return (*hexutil.Big)(out)
+// GasPrice returns the gas price in wei suggested by Ethermint's gas price
+// oracle from the gas prices of the recent blocks transactions.
+func (e *PublicEthAPI) GasPrice() (*hexutil.Big, error) {
+	price, err := e.gasPrice.SuggestPrice()
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(price), nil
+}
+
+// FeeHistory returns the base fees, the gas used ratios and the reward
+// percentiles of the blockCount blocks ending at the newest block, for the
+// EIP-1559 fee estimations. The base fees are the ones stored by the EVM module
+// at the end of the previous blocks.
+func (e *PublicEthAPI) FeeHistory(blockCount hexutil.Uint64, newestBlock BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	return e.gasPrice.FeeHistory(uint64(blockCount), newestBlock, rewardPercentiles)
 }
 
-// Accounts returns the list of accounts available to this node.
+// MaxPriorityFeePerGas returns the priority fee per gas in wei suggested by
+// Ethermint's gas price oracle for the EIP-1559 transactions, from the rewards
+// of the recent blocks returned by eth_feeHistory.
+func (e *PublicEthAPI) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	tip, err := e.gasPrice.SuggestTipCap()
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(tip), nil
+}
+
+// Accounts returns the Ethereum addresses of the keys of the node keyring. The
+// addresses are derived from the public keys, the private keys are never loaded.
+// The keys of other algorithms than the Ethermint secp256k1 are skipped, as their
+// addresses aren't Ethereum addresses.
 func (e *PublicEthAPI) Accounts() ([]common.Address, error) {
 	e.keybaseLock.Lock()
+	defer e.keybaseLock.Unlock()
 
 	addresses := make([]common.Address, 0) // return [] instead of nil if empty
 	keybase, err := keys.NewKeyringFromHomeFlag(e.cliCtx.Input)
 	if err != nil {
 		return addresses, err
 	}
+	defer keybase.CloseDB()
 
 	infos, err := keybase.List()
 	if err != nil {
 		return addresses, err
 	}
 
-	keybase.CloseDB()
-	e.keybaseLock.Unlock()
-
 	for _, info := range infos {
-		addressBytes := info.GetPubKey().Address().Bytes()
-		addresses = append(addresses, common.BytesToAddress(addressBytes))
+		pubKey, ok := info.GetPubKey().(emintcrypto.PubKeySecp256k1)
+		if !ok {
+			continue
+		}
+		addresses = append(addresses, common.BytesToAddress(pubKey.Address().Bytes()))
 	}
 
 	return addresses, nil
@@ -135,16 +248,44 @@ func (e *PublicEthAPI) BlockNumber() (hexutil.Uint64, error) {
 	return e.backend.BlockNumber()
 }
 
-// GetBalance returns the provided account's balance up to the provided block number.
+// heightContext returns the CLI context querying the state at the given block
+// number. It returns an invalid params error if the block number is beyond the
+// current chain tip.
+func (e *PublicEthAPI) heightContext(blockNum BlockNumber) (context.CLIContext, error) {
+	if blockNum != LatestBlockNumber {
+		latest, err := e.backend.BlockNumber()
+		if err != nil {
+			return e.cliCtx, err
+		}
+
+		if blockNum.Int64() > int64(latest) {
+			return e.cliCtx, newInvalidParamsError(
+				"block number %d is beyond the current chain tip %d", blockNum.Int64(), latest,
+			)
+		}
+	}
+
+	return e.cliCtx.WithHeight(blockNum.Int64()), nil
+}
+
+// GetBalance returns the provided account's balance in wei up to the provided
+// block number.
 func (e *PublicEthAPI) GetBalance(address common.Address, blockNum BlockNumber) (*hexutil.Big, error) {
-	ctx := e.cliCtx.WithHeight(blockNum.Int64())
+	ctx, err := e.heightContext(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
 	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/balance/%s", types.ModuleName, address.Hex()), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var out types.QueryResBalance
-	e.cliCtx.Codec.MustUnmarshalJSON(res, &out)
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
 	val, err := utils.UnmarshalBigInt(out.Balance)
 	if err != nil {
 		return nil, err
@@ -154,32 +295,94 @@ func (e *PublicEthAPI) GetBalance(address common.Address, blockNum BlockNumber)
 }
 
 // GetStorageAt returns the contract storage at the given address, block number, and key.
+// The key is the hex encoded storage slot and the value of unset slots is the
+// zero hash.
 func (e *PublicEthAPI) GetStorageAt(address common.Address, key string, blockNum BlockNumber) (hexutil.Bytes, error) {
-	ctx := e.cliCtx.WithHeight(blockNum.Int64())
-	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/storage/%s/%s", types.ModuleName, address.Hex(), key), nil)
+	ctx, err := e.heightContext(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	slot := common.HexToHash(key)
+	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s/%s", types.ModuleName, evm.QueryStorage, address.Hex(), slot.Hex()), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var out types.QueryResStorage
-	e.cliCtx.Codec.MustUnmarshalJSON(res, &out)
-	return out.Value, nil
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	return common.BytesToHash(out.Value).Bytes(), nil
 }
 
 // GetTransactionCount returns the number of transactions at the given address up to the given block number.
+// For the pending block number, the transactions of the address in the transaction pool are counted as well.
 func (e *PublicEthAPI) GetTransactionCount(address common.Address, blockNum BlockNumber) (*hexutil.Uint64, error) {
-	ctx := e.cliCtx.WithHeight(blockNum.Int64())
-	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/nonce/%s", types.ModuleName, address.Hex()), nil)
+	if blockNum == PendingBlockNumber {
+		return e.getPendingTransactionCount(address)
+	}
+
+	ctx, err := e.heightContext(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryNonce, address.Hex()), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var out types.QueryResNonce
-	e.cliCtx.Codec.MustUnmarshalJSON(res, &out)
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
 	return (*hexutil.Uint64)(&out.Nonce), nil
 }
 
-// GetBlockTransactionCountByHash returns the number of transactions in the block identified by hash.
+// getPendingTransactionCount returns the pending nonce of the address, which
+// follows both the transactions applied to the current block and the ones of
+// the address waiting in the transaction pool.
+func (e *PublicEthAPI) getPendingTransactionCount(address common.Address) (*hexutil.Uint64, error) {
+	res, _, err := e.cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryPendingNonce, address.Hex()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out types.QueryResNonce
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	pendingTxs, err := e.cliCtx.Client.UnconfirmedTxs(maxUnconfirmedTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := out.Nonce
+	for _, bz := range pendingTxs.Txs {
+		// skip the non Ethereum transactions
+		ethTx, err := bytesToEthTx(e.cliCtx, bz)
+		if err != nil {
+			continue
+		}
+
+		from, err := ethTx.VerifySig(ethTx.ChainID())
+		if err != nil || from != address {
+			continue
+		}
+
+		if ethTx.Data.AccountNonce >= nonce {
+			nonce = ethTx.Data.AccountNonce + 1
+		}
+	}
+
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// GetBlockTransactionCountByHash returns the number of Ethereum transactions in
+// the block identified by hash, or nil if the block doesn't exist.
 func (e *PublicEthAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint {
 	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryHashToHeight, hash.Hex()))
 	if err != nil {
@@ -192,20 +395,28 @@ func (e *PublicEthAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil
 	return e.getBlockTransactionCountByNumber(out.Number)
 }
 
-// GetBlockTransactionCountByNumber returns the number of transactions in the block identified by number.
+// GetBlockTransactionCountByNumber returns the number of Ethereum transactions
+// in the block identified by number, or nil if the block doesn't exist.
 func (e *PublicEthAPI) GetBlockTransactionCountByNumber(blockNum BlockNumber) *hexutil.Uint {
 	height := blockNum.Int64()
 	return e.getBlockTransactionCountByNumber(height)
 }
 
 func (e *PublicEthAPI) getBlockTransactionCountByNumber(number int64) *hexutil.Uint {
-	block, err := e.cliCtx.Client.Block(&number)
+	// the latest block is queried without height, see getEthBlockByNumber
+	var blkNumPtr *int64
+	if number > 0 {
+		blkNumPtr = &number
+	}
+
+	block, err := e.cliCtx.Client.Block(blkNumPtr)
 	if err != nil {
 		// Return nil if block doesn't exist
 		return nil
 	}
 
-	n := hexutil.Uint(len(block.Block.Txs))
+	// the Cosmos transactions of the block aren't counted
+	n := hexutil.Uint(len(ethereumTxs(e.cliCtx, block.Block.Txs)))
 	return &n
 }
 
@@ -219,16 +430,27 @@ func (e *PublicEthAPI) GetUncleCountByBlockNumber(blockNum BlockNumber) hexutil.
 	return 0
 }
 
-// GetCode returns the contract code at the given address and block number.
+// GetCode returns the contract code at the given address and block number. The
+// code of externally-owned and non-existent accounts is empty.
 func (e *PublicEthAPI) GetCode(address common.Address, blockNumber BlockNumber) (hexutil.Bytes, error) {
-	ctx := e.cliCtx.WithHeight(blockNumber.Int64())
+	ctx, err := e.heightContext(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
 	res, _, err := ctx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryCode, address.Hex()), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var out types.QueryResCode
-	e.cliCtx.Codec.MustUnmarshalJSON(res, &out)
+	if err := e.cliCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	if out.Code == nil {
+		return hexutil.Bytes{}, nil
+	}
 	return out.Code, nil
 }
 
@@ -237,20 +459,17 @@ func (e *PublicEthAPI) GetTxLogs(txHash common.Hash) ([]*ethtypes.Log, error) {
 	return e.backend.GetTxLogs(txHash)
 }
 
-// Sign signs the provided data using the private key of address via Geth's signature standard.
+// Sign signs the personal message of the provided data using the private key
+// of address via Geth's signature standard, ie. the signature is computed over
+// keccak256("\x19Ethereum Signed Message:\n" + len(data) + data) and its V value
+// is 27 or 28.
 func (e *PublicEthAPI) Sign(address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
 	// TODO: Change this functionality to find an unlocked account by address
 	if e.key == nil || !bytes.Equal(e.key.PubKey().Address().Bytes(), address.Bytes()) {
 		return nil, keystore.ErrLocked
 	}
 
-	// Sign the requested hash with the wallet
-	signature, err := e.key.Sign(data)
-	if err == nil {
-		signature[64] += 27 // Transform V from 0/1 to 27/28 according to the yellow paper
-	}
-
-	return signature, err
+	return e.key.SignPersonalMessage(data)
 }
 
 // SendTransaction sends an Ethereum transaction.
@@ -272,12 +491,9 @@ func (e *PublicEthAPI) SendTransaction(args params.SendTxArgs) (common.Hash, err
 		return common.Hash{}, err
 	}
 
-	// ChainID must be set as flag to send transaction
-	chainID := viper.GetString(flags.FlagChainID)
-	// parse the chainID from a string to a base-10 integer
-	intChainID, ok := new(big.Int).SetString(chainID, 10)
-	if !ok {
-		return common.Hash{}, fmt.Errorf("invalid chainID: %s, must be integer format", chainID)
+	intChainID, err := chainIDFromFlags()
+	if err != nil {
+		return common.Hash{}, err
 	}
 
 	// Sign transaction
@@ -302,13 +518,23 @@ func (e *PublicEthAPI) SendTransaction(args params.SendTxArgs) (common.Hash, err
 }
 
 // SendRawTransaction send a raw Ethereum transaction.
+// The transaction is decoded from its canonical Ethereum encoding and its
+// signature is verified against the configured chain ID before broadcasting it.
 func (e *PublicEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
-	tx := new(types.MsgEthereumTx)
+	// decode the legacy RLP or typed transaction bytes, rejecting the oversized
+	// or malformed inputs
+	tx, err := types.DecodeTxBytes(data)
+	if err != nil {
+		return common.Hash{}, newInvalidParamsError("failed to decode transaction: %s", err)
+	}
+
+	intChainID, err := chainIDFromFlags()
+	if err != nil {
+		return common.Hash{}, err
+	}
 
-	// RLP decode raw transaction bytes
-	if err := rlp.DecodeBytes(data, tx); err != nil {
-		// Return nil is for when gasLimit overflows uint64
-		return common.Hash{}, nil
+	if _, err := tx.VerifySig(intChainID); err != nil {
+		return common.Hash{}, newInvalidParamsError("invalid transaction signature: %s", err)
 	}
 
 	// Encode transaction by default Tx encoder
@@ -320,15 +546,23 @@ func (e *PublicEthAPI) SendRawTransaction(data hexutil.Bytes) (common.Hash, erro
 
 	// TODO: Possibly log the contract creation address (if recipient address is nil) or tx data
 	res, err := e.cliCtx.BroadcastTx(txBytes)
-	// If error is encountered on the node, the broadcast will not return an error
-	// TODO: Remove res log
-	fmt.Println(res.RawLog)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	// Return transaction hash
-	return common.HexToHash(res.TxHash), nil
+	// If error is encountered on the node, the broadcast will not return an error
+	if res.Code != abci.CodeTypeOK {
+		return common.Hash{}, newTxRejectedError("transaction rejected: %s", res.RawLog)
+	}
+
+	// Return the Ethereum transaction hash
+	return tx.Hash(), nil
+}
+
+// chainIDFromFlags parses the EIP-155 chain ID from the chain-id set as flag.
+// The chain-id must be set to send and verify transactions.
+func chainIDFromFlags() (*big.Int, error) {
+	return emint.ParseChainID(viper.GetString(flags.FlagChainID))
 }
 
 // CallArgs represents the arguments for a call.
@@ -341,13 +575,21 @@ type CallArgs struct {
 	Data     *hexutil.Bytes  `json:"data"`
 }
 
-// Call performs a raw contract call.
+// Call performs a raw contract call on the state of the given block height.
+// All the state changes of the call are discarded. The ABI encoded revert
+// payload (eg. Error(string)) is returned if the execution is reverted. The gas
+// of the call is capped to the RPC gas cap, which is the default gas.
 func (e *PublicEthAPI) Call(args CallArgs, blockNr rpc.BlockNumber, overrides *map[common.Address]account) (hexutil.Bytes, error) {
-	result, err := e.doCall(args, blockNr, big.NewInt(emint.DefaultRPCGasLimit))
+	result, err := e.doCall(args, blockNr, e.gasCap)
 	if err != nil {
 		return []byte{}, err
 	}
 
+	// failed executions without result data don't have a revert payload
+	if !result.IsOK() && len(result.Data) == 0 {
+		return []byte{}, errors.New(result.Log)
+	}
+
 	data, err := types.DecodeResultData(result.Data)
 	if err != nil {
 		return []byte{}, err
@@ -372,10 +614,11 @@ type account struct {
 
 // DoCall performs a simulated call operation through the evm. It returns the
 // estimated gas used on the operation or an error if fails.
-func (e *PublicEthAPI) doCall(args CallArgs, blockNr rpc.BlockNumber, globalGasCap *big.Int) (*sdk.Result, error) {
-	// Set height for historical queries
+func (e *PublicEthAPI) doCall(args CallArgs, blockNr rpc.BlockNumber, gasCap uint64) (*sdk.Result, error) {
+	// Set height for historical queries, the latest and pending block tags are
+	// queried on the latest state
 	ctx := e.cliCtx
-	if blockNr.Int64() != 0 {
+	if blockNr.Int64() > 0 {
 		ctx = e.cliCtx.WithHeight(blockNr.Int64())
 	}
 
@@ -390,16 +633,8 @@ func (e *PublicEthAPI) doCall(args CallArgs, blockNr rpc.BlockNumber, globalGasC
 		addr = *args.From
 	}
 
-	// Set default gas & gas price if none were set
-	// Change this to uint64(math.MaxUint64 / 2) if gas cap can be configured
-	gas := uint64(emint.DefaultRPCGasLimit)
-	if args.Gas != nil {
-		gas = uint64(*args.Gas)
-	}
-	if globalGasCap != nil && globalGasCap.Uint64() < gas {
-		log.Println("Caller gas above allowance, capping", "requested", gas, "cap", globalGasCap)
-		gas = globalGasCap.Uint64()
-	}
+	// Set the gas to the cap if none was set
+	gas := callGas(args.Gas, gasCap)
 
 	// Set gas price using default or parameter if passed in
 	gasPrice := new(big.Int).SetUint64(emint.DefaultGasPrice)
@@ -419,14 +654,15 @@ func (e *PublicEthAPI) doCall(args CallArgs, blockNr rpc.BlockNumber, globalGasC
 		data = []byte(*args.Data)
 	}
 
-	// Set destination address for call
-	var toAddr sdk.AccAddress
+	// Set destination address for call, a nil address defines a contract creation
+	var toAddr *sdk.AccAddress
 	if args.To != nil {
-		toAddr = sdk.AccAddress(args.To.Bytes())
+		to := sdk.AccAddress(args.To.Bytes())
+		toAddr = &to
 	}
 
 	// Create new call message
-	msg := types.NewMsgEthermint(0, &toAddr, sdk.NewIntFromBigInt(value), gas,
+	msg := types.NewMsgEthermint(0, toAddr, sdk.NewIntFromBigInt(value), gas,
 		sdk.NewIntFromBigInt(gasPrice), data, sdk.AccAddress(addr.Bytes()))
 
 	// Generate tx to be used to simulate (signature isn't needed)
@@ -455,16 +691,131 @@ func (e *PublicEthAPI) doCall(args CallArgs, blockNr rpc.BlockNumber, globalGasC
 }
 
 // EstimateGas returns an estimate of gas usage for the given smart contract call.
-// It adds 1,000 gas to the returned value instead of using the gas adjustment
-// param from the SDK.
+// It performs a binary search over the gas limit, bounded by the RPC gas cap and
+// the block gas limit, simulating the call on each iteration, and returns the lowest gas
+// limit that doesn't fail plus a buffer of 1,000 gas instead of using the gas
+// adjustment param from the SDK. The simulations are executed on a copy of the
+// state so no state is committed.
 func (e *PublicEthAPI) EstimateGas(args CallArgs) (hexutil.Uint64, error) {
-	result, err := e.doCall(args, 0, big.NewInt(emint.DefaultRPCGasLimit))
+	blockGasLimit, err := e.backend.getGasLimit()
+	if err != nil {
+		return 0, err
+	}
+
+	// Determine the highest gas limit can be used during the estimation
+	hi := estimateGasCap(args.Gas, blockGasLimit, e.gasCap)
+	gasCap := hi
+
+	// The lowest gas limit is the intrinsic gas of the call, as no execution can
+	// succeed below it
+	var data []byte
+	if args.Data != nil {
+		data = []byte(*args.Data)
+	}
+
+	// the calls are executed as messages without access list
+	intrinsic, err := types.IntrinsicGas(data, nil, args.To == nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if intrinsic > hi {
+		return 0, fmt.Errorf("intrinsic gas too high: %d > %d", intrinsic, hi)
+	}
+	lo := intrinsic - 1
+
+	// executable returns the simulation result for the given gas limit. Failed
+	// simulations are returned as a result with a non-OK code.
+	executable := func(gas uint64) (*sdk.Result, error) {
+		callArgs := args
+		callArgs.Gas = (*hexutil.Uint64)(&gas)
+		return e.doCall(callArgs, 0, gasCap)
+	}
+
+	// Reject the call if it fails with the highest allowance, as it can't
+	// succeed at any gas limit
+	result, err := executable(hi)
 	if err != nil {
 		return 0, err
 	}
+	if !result.IsOK() {
+		return 0, estimationError(result, gasCap)
+	}
+
+	// Execute the binary search and hone in on an executable gas limit
+	for lo+1 < hi {
+		mid := (hi + lo) / 2
+		result, err := executable(mid)
+		if err != nil {
+			return 0, err
+		}
+
+		if result.IsOK() {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
 
 	// TODO: change 1000 buffer for more accurate buffer (must be at least 1 to not run OOG)
-	return hexutil.Uint64(result.GasUsed + 1000), nil
+	estimate := hi + 1000
+	if estimate > gasCap {
+		estimate = gasCap
+	}
+
+	return hexutil.Uint64(estimate), nil
+}
+
+// callGas returns the gas of a call, which is the gas cap if unset and is capped
+// to it otherwise.
+func callGas(gas *hexutil.Uint64, gasCap uint64) uint64 {
+	if gas == nil {
+		return gasCap
+	}
+
+	if uint64(*gas) > gasCap {
+		return gasCap
+	}
+
+	return uint64(*gas)
+}
+
+// estimateGasCap returns the highest gas limit of the estimation, which is the
+// gas of the call if valid, bounded by the block gas limit and the gas cap.
+func estimateGasCap(gas *hexutil.Uint64, blockGasLimit int64, gasCap uint64) uint64 {
+	hi := gasCap
+	if gas != nil && uint64(*gas) >= ethparams.TxGas && uint64(*gas) < hi {
+		hi = uint64(*gas)
+	}
+
+	if blockGasLimit > 0 && uint64(blockGasLimit) < hi {
+		hi = uint64(blockGasLimit)
+	}
+
+	return hi
+}
+
+// estimationError returns the error of a call failing with the highest gas
+// limit of the estimation. The reverted executions return the revert reason,
+// while the out of gas failures report the gas allowance.
+func estimationError(result *sdk.Result, gasCap uint64) error {
+	switch {
+	case isResultError(result, emint.ErrExecutionReverted):
+		var ret []byte
+		if data, err := types.DecodeResultData(result.Data); err == nil {
+			ret = data.Ret
+		}
+		return newRevertError(ret)
+	case isResultError(result, sdkerrors.ErrOutOfGas):
+		return fmt.Errorf("gas required exceeds allowance (%d)", gasCap)
+	default:
+		return fmt.Errorf("always failing transaction: %s", result.Log)
+	}
+}
+
+// isResultError returns whether a failed result was returned for the error.
+func isResultError(result *sdk.Result, err *sdkerrors.Error) bool {
+	return string(result.Codespace) == err.Codespace() && uint32(result.Code) == err.ABCICode()
 }
 
 // GetBlockByHash returns the block identified by hash.
@@ -477,51 +828,92 @@ func (e *PublicEthAPI) GetBlockByNumber(blockNum BlockNumber, fullTx bool) (map[
 	return e.backend.GetBlockByNumber(blockNum, fullTx)
 }
 
+// formatBlock returns the Ethereum compatible block of a Tendermint block
+// header. The state root is the root of the EVM state committed by the block.
+// The fields without Tendermint analogue are set to stable zero values: the PoW
+// nonce, mix hash and difficulties are zero, the block has no uncles and no
+// extra data.
 func formatBlock(
 	header tmtypes.Header, size int, gasLimit int64,
-	gasUsed *big.Int, transactions interface{}, bloom ethtypes.Bloom,
+	gasUsed *big.Int, transactions interface{}, bloom ethtypes.Bloom, stateRoot common.Hash,
 ) map[string]interface{} {
 	return map[string]interface{}{
 		"number":           hexutil.Uint64(header.Height),
 		"hash":             hexutil.Bytes(header.Hash()),
 		"parentHash":       hexutil.Bytes(header.LastBlockID.Hash),
-		"nonce":            nil, // PoW specific
-		"sha3Uncles":       nil, // No uncles in Tendermint
+		"nonce":            ethtypes.BlockNonce{},   // PoW specific
+		"mixHash":          common.Hash{},           // PoW specific
+		"sha3Uncles":       ethtypes.EmptyUncleHash, // No uncles in Tendermint
 		"logsBloom":        bloom,
 		"transactionsRoot": hexutil.Bytes(header.DataHash),
-		"stateRoot":        hexutil.Bytes(header.AppHash),
-		"miner":            common.Address{},
-		"difficulty":       nil,
-		"totalDifficulty":  nil,
-		"extraData":        nil,
+		"stateRoot":        stateRoot,
+		"miner":            common.BytesToAddress(header.ProposerAddress),
+		"difficulty":       (*hexutil.Big)(big.NewInt(0)),
+		"totalDifficulty":  (*hexutil.Big)(big.NewInt(0)),
+		"extraData":        hexutil.Bytes{},
 		"size":             hexutil.Uint64(size),
 		"gasLimit":         hexutil.Uint64(gasLimit), // Static gas limit
 		"gasUsed":          (*hexutil.Big)(gasUsed),
 		"timestamp":        hexutil.Uint64(header.Time.Unix()),
-		"transactions":     transactions.([]common.Hash),
-		"uncles":           nil,
+		"transactions":     transactions,
+		"uncles":           []common.Hash{},
 	}
 }
 
-func convertTransactionsToRPC(cliCtx context.CLIContext, txs []tmtypes.Tx, blockHash common.Hash, height uint64) ([]common.Hash, *big.Int, error) {
-	transactions := make([]common.Hash, len(txs))
-	gasUsed := big.NewInt(0)
-
+// transactionHashes returns the hashes of the transactions of a block, which
+// are the Ethereum transaction hashes for the Ethereum transactions and the
+// Tendermint ones otherwise.
+func transactionHashes(cliCtx context.CLIContext, txs []tmtypes.Tx) []common.Hash {
+	hashes := make([]common.Hash, len(txs))
 	for i, tx := range txs {
 		ethTx, err := bytesToEthTx(cliCtx, tx)
 		if err != nil {
-			return nil, nil, err
+			hashes[i] = common.BytesToHash(tx.Hash())
+			continue
 		}
-		// TODO: Remove gas usage calculation if saving gasUsed per block
-		gasUsed.Add(gasUsed, ethTx.Fee())
-		tx, err := newRPCTransaction(*ethTx, common.BytesToHash(tx.Hash()), blockHash, &height, uint64(i))
+		hashes[i] = ethTx.Hash()
+	}
+
+	return hashes
+}
+
+// ethereumTxs returns the Ethereum transactions of a block, skipping the other
+// transactions.
+func ethereumTxs(cliCtx context.CLIContext, txs []tmtypes.Tx) []*types.MsgEthereumTx {
+	ethTxs := make([]*types.MsgEthereumTx, 0, len(txs))
+	for _, tx := range txs {
+		ethTx, err := bytesToEthTx(cliCtx, tx)
 		if err != nil {
-			return nil, nil, err
+			continue
 		}
-		transactions[i] = tx.Hash
+		ethTxs = append(ethTxs, ethTx)
 	}
 
-	return transactions, gasUsed, nil
+	return ethTxs
+}
+
+// convertTransactionsToRPC returns the RPC representation of the Ethereum
+// transactions of a block. The other transactions are skipped as they have no
+// Ethereum representation.
+func convertTransactionsToRPC(cliCtx context.CLIContext, txs []tmtypes.Tx, blockHash common.Hash, height uint64) ([]*Transaction, error) {
+	transactions := make([]*Transaction, 0, len(txs))
+
+	for _, ethTx := range ethereumTxs(cliCtx, txs) {
+		rpcTx, err := newRPCTransaction(*ethTx, blockHash, &height, uint64(len(transactions)))
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, rpcTx)
+	}
+
+	return transactions, nil
+}
+
+// ethTxIndex returns the index of the Ethereum transaction at the given
+// position of the block, which is its position among the Ethereum transactions
+// of the block, as set by the EVM module on its logs and receipt.
+func ethTxIndex(cliCtx context.CLIContext, txs []tmtypes.Tx, position uint32) uint64 {
+	return uint64(len(ethereumTxs(cliCtx, txs[:position])))
 }
 
 // Transaction represents a transaction returned to RPC clients.
@@ -543,22 +935,22 @@ type Transaction struct {
 }
 
 func bytesToEthTx(cliCtx context.CLIContext, bz []byte) (*types.MsgEthereumTx, error) {
-	var stdTx sdk.Tx
-	err := cliCtx.Codec.UnmarshalBinaryLengthPrefixed(bz, &stdTx)
+	stdTx, err := types.TxDecoder(cliCtx.Codec)(bz)
 	if err != nil {
 		return nil, err
 	}
 
 	ethTx, ok := stdTx.(types.MsgEthereumTx)
 	if !ok {
-		return nil, fmt.Errorf("invalid transaction type, must be an amino encoded Ethereum transaction")
+		return nil, fmt.Errorf("invalid transaction type, must be an Ethereum transaction")
 	}
 	return &ethTx, nil
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
-// representation, with the given location metadata set (if available).
-func newRPCTransaction(tx types.MsgEthereumTx, txHash, blockHash common.Hash, blockNumber *uint64, index uint64) (*Transaction, error) {
+// representation, with the given location metadata set (if available). The
+// transaction hash is the Ethereum transaction hash.
+func newRPCTransaction(tx types.MsgEthereumTx, blockHash common.Hash, blockNumber *uint64, index uint64) (*Transaction, error) {
 	// Verify signature and retrieve sender address
 	from, err := tx.VerifySig(tx.ChainID())
 	if err != nil {
@@ -569,7 +961,7 @@ func newRPCTransaction(tx types.MsgEthereumTx, txHash, blockHash common.Hash, bl
 		From:     from,
 		Gas:      hexutil.Uint64(tx.Data.GasLimit),
 		GasPrice: (*hexutil.Big)(tx.Data.Price),
-		Hash:     txHash,
+		Hash:     tx.Hash(),
 		Input:    hexutil.Bytes(tx.Data.Payload),
 		Nonce:    hexutil.Uint64(tx.Data.AccountNonce),
 		To:       tx.To(),
@@ -588,9 +980,26 @@ func newRPCTransaction(tx types.MsgEthereumTx, txHash, blockHash common.Hash, bl
 	return &result, nil
 }
 
-// GetTransactionByHash returns the transaction identified by hash.
+// getTx returns the Tendermint transaction identified by the Ethereum
+// transaction hash. The hash falls back to the Tendermint transaction hash if
+// no Ethereum transaction is mapped to it.
+func getTx(cliCtx context.CLIContext, hash common.Hash) (*ctypes.ResultTx, error) {
+	txHash := hash.Bytes()
+
+	res, _, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryTxHash, hash.Hex()))
+	if err == nil {
+		var out types.QueryResTxHash
+		cliCtx.Codec.MustUnmarshalJSON(res, &out)
+		txHash = out.Hash
+	}
+
+	return cliCtx.Client.Tx(txHash, false)
+}
+
+// GetTransactionByHash returns the transaction identified by hash. It returns
+// nil if the transaction is not found.
 func (e *PublicEthAPI) GetTransactionByHash(hash common.Hash) (*Transaction, error) {
-	tx, err := e.cliCtx.Client.Tx(hash.Bytes(), false)
+	tx, err := getTx(e.cliCtx, hash)
 	if err != nil {
 		// Return nil for transaction when not found
 		return nil, nil
@@ -609,14 +1018,16 @@ func (e *PublicEthAPI) GetTransactionByHash(hash common.Hash) (*Transaction, err
 	}
 
 	height := uint64(tx.Height)
-	return newRPCTransaction(*ethTx, common.BytesToHash(tx.Tx.Hash()), blockHash, &height, uint64(tx.Index))
+	return newRPCTransaction(*ethTx, blockHash, &height, ethTxIndex(e.cliCtx, block.Block.Txs, tx.Index))
 }
 
-// GetTransactionByBlockHashAndIndex returns the transaction identified by hash and index.
+// GetTransactionByBlockHashAndIndex returns the Ethereum transaction identified
+// by block hash and index, or nil if the block or index doesn't exist.
 func (e *PublicEthAPI) GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*Transaction, error) {
 	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryHashToHeight, hash.Hex()))
 	if err != nil {
-		return nil, err
+		// Return nil if block does not exist
+		return nil, nil
 	}
 
 	var out types.QueryResBlockNumber
@@ -624,40 +1035,55 @@ func (e *PublicEthAPI) GetTransactionByBlockHashAndIndex(hash common.Hash, idx h
 	return e.getTransactionByBlockNumberAndIndex(out.Number, idx)
 }
 
-// GetTransactionByBlockNumberAndIndex returns the transaction identified by number and index.
+// GetTransactionByBlockNumberAndIndex returns the Ethereum transaction
+// identified by block number and index, or nil if the block or index doesn't
+// exist.
 func (e *PublicEthAPI) GetTransactionByBlockNumberAndIndex(blockNum BlockNumber, idx hexutil.Uint) (*Transaction, error) {
 	value := blockNum.Int64()
 	return e.getTransactionByBlockNumberAndIndex(value, idx)
 }
 
+// getTransactionByBlockNumberAndIndex returns the idx-th Ethereum transaction
+// of the block, skipping the other transactions, whose transaction index is idx.
 func (e *PublicEthAPI) getTransactionByBlockNumberAndIndex(number int64, idx hexutil.Uint) (*Transaction, error) {
-	block, err := e.cliCtx.Client.Block(&number)
+	// the latest block is queried without height, see getEthBlockByNumber
+	var blkNumPtr *int64
+	if number > 0 {
+		blkNumPtr = &number
+	}
+
+	block, err := e.cliCtx.Client.Block(blkNumPtr)
 	if err != nil {
-		return nil, err
+		// Return nil if block doesn't exist
+		return nil, nil
 	}
 	header := block.Block.Header
 
-	txs := block.Block.Txs
-	if uint64(idx) >= uint64(len(txs)) {
+	ethTxs := ethereumTxs(e.cliCtx, block.Block.Txs)
+	if uint64(idx) >= uint64(len(ethTxs)) {
+		// Return nil if the index is out of range
 		return nil, nil
 	}
-	ethTx, err := bytesToEthTx(e.cliCtx, txs[idx])
-	if err != nil {
-		return nil, err
-	}
 
 	height := uint64(header.Height)
-	return newRPCTransaction(*ethTx, common.BytesToHash(txs[idx].Hash()), common.BytesToHash(header.Hash()), &height, uint64(idx))
+	return newRPCTransaction(*ethTxs[idx], common.BytesToHash(header.Hash()), &height, uint64(idx))
 }
 
-// GetTransactionReceipt returns the transaction receipt identified by hash.
+// GetTransactionReceipt returns the transaction receipt identified by hash, or
+// nil if there is no such Ethereum transaction.
 func (e *PublicEthAPI) GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
-	tx, err := e.cliCtx.Client.Tx(hash.Bytes(), false)
+	tx, err := getTx(e.cliCtx, hash)
 	if err != nil {
 		// Return nil for transaction when not found
 		return nil, nil
 	}
 
+	// only the Ethereum transactions have a receipt
+	ethTx, err := bytesToEthTx(e.cliCtx, tx.Tx)
+	if err != nil {
+		return nil, nil
+	}
+
 	// Query block for consensus hash
 	block, err := e.cliCtx.Client.Block(&tx.Height)
 	if err != nil {
@@ -665,56 +1091,90 @@ func (e *PublicEthAPI) GetTransactionReceipt(hash common.Hash) (map[string]inter
 	}
 	blockHash := common.BytesToHash(block.Block.Header.Hash())
 
-	// Convert tx bytes to eth transaction
-	ethTx, err := bytesToEthTx(e.cliCtx, tx.Tx)
+	txIndex := ethTxIndex(e.cliCtx, block.Block.Txs, tx.Index)
+
+	var receipt *types.TxReceipt
+	if tx.TxResult.IsOK() {
+		receipt, err = e.getTxReceipt(ethTx.Hash())
+	} else {
+		receipt, err = e.failedTxReceipt(tx, ethTx, txIndex)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	from, _ := ethTx.VerifySig(ethTx.ChainID())
+	logs := receipt.Logs
+	if logs == nil {
+		logs = []*ethtypes.Log{}
+	}
 
-	// Set status codes based on tx result
-	var status hexutil.Uint
-	if tx.TxResult.IsOK() {
-		status = hexutil.Uint(1)
-	} else {
-		status = hexutil.Uint(0)
+	return map[string]interface{}{
+		"blockHash":         blockHash,
+		"blockNumber":       hexutil.Uint64(tx.Height),
+		"transactionHash":   ethTx.Hash(),
+		"transactionIndex":  hexutil.Uint64(txIndex),
+		"from":              receipt.From,
+		"to":                receipt.To,
+		"gasUsed":           hexutil.Uint64(receipt.GasUsed),
+		"cumulativeGasUsed": hexutil.Uint64(receipt.CumulativeGasUsed),
+		"contractAddress":   receipt.ContractAddress,
+		"logs":              logs,
+		"logsBloom":         receipt.Bloom,
+		"status":            hexutil.Uint64(receipt.Status),
+	}, nil
+}
+
+// getTxReceipt returns the receipt stored for the Ethereum transaction hash.
+func (e *PublicEthAPI) getTxReceipt(hash common.Hash) (*types.TxReceipt, error) {
+	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryTxReceipt, hash.Hex()))
+	if err != nil {
+		return nil, err
 	}
 
-	res, _, err := e.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryTxLogs, hash.Hex()))
+	receipt, err := types.DecodeTxReceipt(res)
 	if err != nil {
 		return nil, err
 	}
+	return &receipt, nil
+}
 
-	var logs types.QueryETHLogs
-	e.cliCtx.Codec.MustUnmarshalJSON(res, &logs)
+// failedTxReceipt builds the receipt of a transaction failed without completing
+// its execution, eg. out of gas, the reverted executions having a stored
+// receipt. The state changes of the failed transactions are discarded, so their
+// receipt isn't stored and has neither logs nor bloom.
+func (e *PublicEthAPI) failedTxReceipt(tx *ctypes.ResultTx, ethTx *types.MsgEthereumTx, txIndex uint64) (*types.TxReceipt, error) {
+	from, err := ethTx.VerifySig(ethTx.ChainID())
+	if err != nil {
+		return nil, err
+	}
 
-	txData := tx.TxResult.GetData()
-	data, err := types.DecodeResultData(txData)
+	results, err := e.cliCtx.Client.BlockResults(&tx.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	fields := map[string]interface{}{
-		"blockHash":         blockHash,
-		"blockNumber":       hexutil.Uint64(tx.Height),
-		"transactionHash":   hash,
-		"transactionIndex":  hexutil.Uint64(tx.Index),
-		"from":              from,
-		"to":                ethTx.To(),
-		"gasUsed":           hexutil.Uint64(tx.TxResult.GasUsed),
-		"cumulativeGasUsed": nil, // ignore until needed
-		"contractAddress":   nil,
-		"logs":              logs.Logs,
-		"logsBloom":         data.Bloom,
-		"status":            status,
+	var cumulativeGasUsed uint64
+	for _, txResult := range results.Results.DeliverTx[:tx.Index+1] {
+		cumulativeGasUsed += uint64(txResult.GasUsed)
+	}
+
+	receipt := &types.TxReceipt{
+		Status:            types.ReceiptStatusFailed,
+		CumulativeGasUsed: cumulativeGasUsed,
+		GasUsed:           uint64(tx.TxResult.GasUsed),
+		From:              from,
+		To:                ethTx.To(),
+		BlockHeight:       uint64(tx.Height),
+		TxIndex:           txIndex,
 	}
 
-	if data.Address != (common.Address{}) {
-		fields["contractAddress"] = data.Address
+	// the contract address is only set for contract creation transactions
+	if ethTx.To() == nil {
+		contractAddress := types.GetContractAddress(from, ethTx.Data.AccountNonce)
+		receipt.ContractAddress = &contractAddress
 	}
 
-	return fields, nil
+	return receipt, nil
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
@@ -800,7 +1260,6 @@ func (e *PublicEthAPI) generateFromArgs(args params.SendTxArgs) (*types.MsgEther
 	var (
 		nonce    uint64
 		gasLimit uint64
-		err      error
 	)
 
 	amount := (*big.Int)(args.Value)
@@ -814,12 +1273,12 @@ func (e *PublicEthAPI) generateFromArgs(args params.SendTxArgs) (*types.MsgEther
 	}
 
 	if args.Nonce == nil {
-		// Get nonce (sequence) from account
-		from := sdk.AccAddress(args.From.Bytes())
-		_, nonce, err = authtypes.NewAccountRetriever(e.cliCtx).GetAccountNumberSequence(from)
+		// Get the pending nonce so that the transactions in the pool are accounted for
+		pendingNonce, err := e.getPendingTransactionCount(args.From)
 		if err != nil {
 			return nil, err
 		}
+		nonce = uint64(*pendingNonce)
 	} else {
 		nonce = (uint64)(*args.Nonce)
 	}
@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestCallGas(t *testing.T) {
+	gas := func(g uint64) *hexutil.Uint64 { return (*hexutil.Uint64)(&g) }
+
+	// the calls without gas default to the cap
+	require.Equal(t, uint64(50000), callGas(nil, 50000))
+	require.Equal(t, uint64(21000), callGas(gas(21000), 50000))
+	require.Equal(t, uint64(50000), callGas(gas(50000), 50000))
+
+	// the calls above the cap are executed with the capped gas
+	require.Equal(t, uint64(50000), callGas(gas(1<<40), 50000))
+}
+
+func TestEstimateGasCap(t *testing.T) {
+	gas := func(g uint64) *hexutil.Uint64 { return (*hexutil.Uint64)(&g) }
+
+	testCases := []struct {
+		name          string
+		gas           *hexutil.Uint64
+		blockGasLimit int64
+		expected      uint64
+	}{
+		{"no gas", nil, 0, 50000},
+		{"gas below cap", gas(30000), 0, 30000},
+		{"gas above cap", gas(1 << 40), 0, 50000},
+		{"gas below intrinsic", gas(100), 0, 50000},
+		{"block gas limit below cap", nil, 40000, 40000},
+		{"block gas limit above cap", gas(1 << 40), 1 << 40, 50000},
+	}
+
+	// the estimation never searches beyond the cap
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, estimateGasCap(tc.gas, tc.blockGasLimit, 50000), tc.name)
+	}
+}
+
+func TestSyncProgress(t *testing.T) {
+	// a caught up node reports a literal false
+	res, err := json.Marshal(syncProgress(ctypes.SyncInfo{LatestBlockHeight: 10}, 0, 20))
+	require.NoError(t, err)
+	require.Equal(t, "false", string(res))
+
+	// a catching up node reports the progress of the sync
+	syncInfo := ctypes.SyncInfo{LatestBlockHeight: 10, CatchingUp: true}
+	res, err = json.Marshal(syncProgress(syncInfo, 5, 20))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"startingBlock":"0x5","currentBlock":"0xa","highestBlock":"0x14"}`, string(res))
+
+	// the highest block is at least the current one
+	res, err = json.Marshal(syncProgress(syncInfo, 5, 0))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"startingBlock":"0x5","currentBlock":"0xa","highestBlock":"0xa"}`, string(res))
+}
+
+func TestHighestPeerBlock(t *testing.T) {
+	peers := []ctypes.PeerStateInfo{
+		{PeerState: json.RawMessage(`{"round_state":{"height":"12"}}`)},
+		{PeerState: json.RawMessage(`{"round_state":{"height":"21"}}`)},
+		{PeerState: nil},
+	}
+
+	// the peers are working on the block after their last committed one
+	require.Equal(t, int64(20), highestPeerBlock(peers))
+	require.Equal(t, int64(0), highestPeerBlock(nil))
+}
+
+func TestEthTxIndex(t *testing.T) {
+	cdc := app.MakeCodec()
+	cliCtx := context.CLIContext{Codec: cdc}
+
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	ethTx := func(nonce uint64) tmtypes.Tx {
+		msg := types.NewMsgEthereumTx(nonce, nil, big.NewInt(0), 100000, big.NewInt(1), nil)
+		msg.Sign(big.NewInt(3), priv)
+
+		bz, err := cdc.MarshalBinaryLengthPrefixed(msg)
+		require.NoError(t, err)
+		return bz
+	}
+
+	// the Cosmos transactions are skipped by the Ethereum transaction indexes
+	txs := []tmtypes.Tx{[]byte("cosmos tx"), ethTx(0), []byte("cosmos tx"), ethTx(1)}
+	require.Equal(t, uint64(0), ethTxIndex(cliCtx, txs, 1))
+	require.Equal(t, uint64(1), ethTxIndex(cliCtx, txs, 3))
+
+	height := uint64(1)
+	transactions, err := convertTransactionsToRPC(cliCtx, txs, common.BytesToHash([]byte("block")), height)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	for i, tx := range transactions {
+		require.Equal(t, hexutil.Uint64(i), *tx.TransactionIndex)
+		require.Equal(t, hexutil.Uint64(i), tx.Nonce)
+	}
+}
@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// maxFeeHistory is the maximum number of blocks returned by eth_feeHistory
+const maxFeeHistory = 1024
+
+// minSuggestedTip is the minimum priority fee per gas, in wei, suggested by
+// eth_maxPriorityFeePerGas
+var minSuggestedTip = big.NewInt(1)
+
+// FeeHistoryResult is the fee market history returned by eth_feeHistory. The
+// base fees hold an additional entry for the block following the newest one.
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// FeeHistory returns the fee market history of the blockCount blocks ending at
+// the newest block. The base fee of a block is the one stored by the previous
+// block and the rewards are the effective gas tips paid by the Ethereum
+// transactions above the base fee, at the given percentiles of the gas they
+// used.
+func (gpo *gasPriceOracle) FeeHistory(blockCount uint64, newest BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, newInvalidParamsError("invalid reward percentile %f, must be within [0, 100]", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, newInvalidParamsError("invalid reward percentile %f, must be greater than %f", p, rewardPercentiles[i-1])
+		}
+	}
+
+	result, _, err := gpo.feeHistory(blockCount, newest, rewardPercentiles)
+	return result, err
+}
+
+// SuggestTipCap returns the priority fee per gas suggested for the EIP-1559
+// transactions: the percentile of the rewards paid in the recent blocks with
+// Ethereum transactions, at the same percentile of their gas used, as returned
+// by eth_feeHistory. The fallback price is suggested if these blocks have no
+// Ethereum transaction, and the suggestion is never below minSuggestedTip.
+func (gpo *gasPriceOracle) SuggestTipCap() (*big.Int, error) {
+	percentile := float64(gpo.percentile)
+	history, sampled, err := gpo.feeHistory(uint64(gpo.blocks), LatestBlockNumber, []float64{percentile})
+	if err != nil {
+		return nil, err
+	}
+
+	var tips []*big.Int
+	for i, rewards := range history.Reward {
+		if sampled[i] {
+			tips = append(tips, rewards[0].ToInt())
+		}
+	}
+
+	tip := new(big.Int).Set(gpo.fallback)
+	if len(tips) > 0 {
+		sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+		tip.Set(tips[(len(tips)-1)*gpo.percentile/100])
+	}
+
+	if tip.Cmp(minSuggestedTip) < 0 {
+		tip.Set(minSuggestedTip)
+	}
+
+	return tip, nil
+}
+
+// feeHistory returns the fee history of FeeHistory, along with whether each
+// block has Ethereum transactions sampled for the rewards.
+func (gpo *gasPriceOracle) feeHistory(blockCount uint64, newest BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, []bool, error) {
+
+	if blockCount > maxFeeHistory {
+		blockCount = maxFeeHistory
+	}
+
+	latest, err := gpo.backend.BlockNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the latest and pending block tags resolve to the latest block
+	last := int64(latest)
+	if newest > 0 {
+		if int64(newest) > last {
+			return nil, nil, fmt.Errorf("block %d not found, the latest block is %d", newest, last)
+		}
+		last = int64(newest)
+	}
+
+	oldest := last - int64(blockCount) + 1
+	if oldest < 1 {
+		oldest = 1
+	}
+
+	result := &FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(big.NewInt(oldest)),
+		GasUsedRatio: []float64{},
+	}
+	if blockCount == 0 || last < 1 {
+		return result, nil, nil
+	}
+
+	var sampled []bool
+
+	gasLimit, err := gpo.backend.getGasLimit()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for height := oldest; height <= last; height++ {
+		h := height
+		block, err := gpo.cliCtx.Client.Block(&h)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results, err := gpo.cliCtx.Client.BlockResults(&h)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var gasUsed int64
+		for _, txResult := range results.Results.DeliverTx {
+			gasUsed += txResult.GasUsed
+		}
+
+		ratio := float64(0)
+		if gasLimit > 0 {
+			ratio = float64(gasUsed) / float64(gasLimit)
+		}
+
+		// the base fee of the first block is zero
+		baseFee := new(big.Int)
+		if height > 1 {
+			baseFee, err = gpo.backend.getBaseFee(height - 1)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(baseFee))
+		result.GasUsedRatio = append(result.GasUsedRatio, ratio)
+
+		if len(rewardPercentiles) > 0 {
+			rewards, ok := gpo.blockRewards(block.Block.Txs, results.Results.DeliverTx, baseFee, rewardPercentiles)
+			result.Reward = append(result.Reward, rewards)
+			sampled = append(sampled, ok)
+		}
+	}
+
+	// base fee of the block following the newest one
+	nextBaseFee, err := gpo.backend.getBaseFee(last)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.BaseFee = append(result.BaseFee, (*hexutil.Big)(nextBaseFee))
+
+	return result, sampled, nil
+}
+
+// txReward is the effective gas tip paid by a transaction for the gas it used
+type txReward struct {
+	reward  *big.Int
+	gasUsed uint64
+}
+
+// blockRewards returns the effective gas tips paid above the base fee by the
+// Ethereum transactions of a block at the given percentiles of the gas used by
+// these transactions. The rewards are zero for the blocks without Ethereum
+// transactions, for which false is returned.
+func (gpo *gasPriceOracle) blockRewards(txs tmtypes.Txs, results []*abci.ResponseDeliverTx, baseFee *big.Int, percentiles []float64) ([]*hexutil.Big, bool) {
+	var (
+		sorted []txReward
+		total  uint64
+	)
+
+	for i, tx := range txs {
+		if i >= len(results) {
+			break
+		}
+
+		// only the Ethereum transactions are sampled
+		ethTx, err := bytesToEthTx(gpo.cliCtx, tx)
+		if err != nil {
+			continue
+		}
+
+		gasUsed := uint64(results[i].GasUsed)
+		sorted = append(sorted, txReward{reward: ethTx.EffectiveGasTip(baseFee), gasUsed: gasUsed})
+		total += gasUsed
+	}
+
+	rewards := make([]*hexutil.Big, len(percentiles))
+	if len(sorted) == 0 {
+		for i := range rewards {
+			rewards[i] = (*hexutil.Big)(new(big.Int))
+		}
+		return rewards, false
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].reward.Cmp(sorted[j].reward) < 0 })
+
+	// the reward of a percentile is the one of the transaction reaching that
+	// percentile of the gas used, the transactions being ordered by reward
+	txIndex, sumGas := 0, sorted[0].gasUsed
+	for i, p := range percentiles {
+		threshold := uint64(float64(total) * p / 100)
+		for sumGas < threshold && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGas += sorted[txIndex].gasUsed
+		}
+		rewards[i] = (*hexutil.Big)(new(big.Int).Set(sorted[txIndex].reward))
+	}
+
+	return rewards, true
+}
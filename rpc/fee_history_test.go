@@ -0,0 +1,117 @@
+package rpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// mockClient is a Tendermint client serving blocks with the given transactions,
+// each transaction using 21000 gas.
+type mockClient struct {
+	rpcclient.Client
+	txs []tmtypes.Txs
+}
+
+func (c *mockClient) Block(height *int64) (*ctypes.ResultBlock, error) {
+	return &ctypes.ResultBlock{Block: &tmtypes.Block{Data: tmtypes.Data{Txs: c.txs[*height-1]}}}, nil
+}
+
+func (c *mockClient) BlockResults(height *int64) (*ctypes.ResultBlockResults, error) {
+	results := make([]*abci.ResponseDeliverTx, len(c.txs[*height-1]))
+	for i := range results {
+		results[i] = &abci.ResponseDeliverTx{GasUsed: 21000}
+	}
+
+	return &ctypes.ResultBlockResults{Height: *height, Results: &state.ABCIResponses{DeliverTx: results}}, nil
+}
+
+// newTestGasPriceOracle returns an oracle over blocks with the given Ethereum
+// transactions.
+func newTestGasPriceOracle(t *testing.T, baseFee int64, blocks ...[]types.MsgEthereumTx) *gasPriceOracle {
+	cdc := app.MakeCodec()
+	backend := &mockBackend{baseFee: baseFee}
+	client := &mockClient{}
+
+	for _, msgs := range blocks {
+		var txs tmtypes.Txs
+		for _, msg := range msgs {
+			bz, err := cdc.MarshalBinaryLengthPrefixed(msg)
+			require.NoError(t, err)
+			txs = append(txs, bz)
+		}
+
+		backend.addBlock()
+		client.txs = append(client.txs, txs)
+	}
+
+	return &gasPriceOracle{
+		cliCtx:     context.CLIContext{Client: client, Codec: cdc},
+		backend:    backend,
+		blocks:     defaultGasPriceBlocks,
+		percentile: defaultGasPricePercentile,
+		fallback:   big.NewInt(20),
+	}
+}
+
+func TestSuggestTipCap(t *testing.T) {
+	legacyTx := func(gasPrice int64) types.MsgEthereumTx {
+		return types.NewMsgEthereumTx(0, nil, nil, 21000, big.NewInt(gasPrice), nil)
+	}
+	dynamicFeeTx := func(tip, feeCap int64) types.MsgEthereumTx {
+		return types.NewMsgEthereumTxDynamicFee(0, nil, nil, 21000, big.NewInt(tip), big.NewInt(feeCap), nil)
+	}
+
+	gpo := newTestGasPriceOracle(t, 10,
+		nil,
+		[]types.MsgEthereumTx{legacyTx(30), legacyTx(50)},
+		[]types.MsgEthereumTx{dynamicFeeTx(5, 100)},
+		[]types.MsgEthereumTx{dynamicFeeTx(8, 100), legacyTx(12)},
+		nil,
+	)
+
+	// the rewards of the blocks with Ethereum transactions are the effective
+	// tips above the base fee, at the oracle percentile of the gas used
+	history, err := gpo.FeeHistory(5, LatestBlockNumber, []float64{defaultGasPricePercentile})
+	require.NoError(t, err)
+	require.Equal(t, [][]*hexutil.Big{
+		{(*hexutil.Big)(big.NewInt(0))},
+		{(*hexutil.Big)(big.NewInt(40))},
+		{(*hexutil.Big)(big.NewInt(5))},
+		{(*hexutil.Big)(big.NewInt(8))},
+		{(*hexutil.Big)(big.NewInt(0))},
+	}, history.Reward)
+
+	// the suggestion is the percentile of these rewards, skipping the blocks
+	// without Ethereum transactions
+	tip, err := gpo.SuggestTipCap()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(8), tip)
+
+	// the fallback price is suggested on an empty chain, never suggesting zero
+	gpo = newTestGasPriceOracle(t, 0, nil, nil)
+
+	tip, err = gpo.SuggestTipCap()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(20), tip)
+
+	gpo.fallback = new(big.Int)
+
+	tip, err = gpo.SuggestTipCap()
+	require.NoError(t, err)
+	require.Equal(t, minSuggestedTip, tip)
+}
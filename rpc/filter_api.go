@@ -2,54 +2,130 @@ package rpc
 
 import (
 	"errors"
+	"sync"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// flagFilterTimeout sets the timeout of the polling filters
+	flagFilterTimeout = "filter-timeout"
+
+	defaultFilterTimeout = 5 * time.Minute
 )
 
 // PublicFilterAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
 type PublicFilterAPI struct {
-	cliCtx  context.CLIContext
-	backend Backend
-	filters map[rpc.ID]*Filter // ID to filter; TODO: change to sync.Map in case of concurrent writes
+	cliCtx    context.CLIContext
+	backend   Backend
+	timeout   time.Duration      // filters not polled within the timeout are uninstalled
+	filtersMu sync.Mutex         // guards filters
+	filters   map[rpc.ID]*Filter // ID to filter
 }
 
 // NewPublicEthAPI creates an instance of the public ETH Web3 API.
 func NewPublicFilterAPI(cliCtx context.CLIContext, backend Backend) *PublicFilterAPI {
-	return &PublicFilterAPI{
+	timeout := viper.GetDuration(flagFilterTimeout)
+	if timeout <= 0 {
+		timeout = defaultFilterTimeout
+	}
+
+	return newPublicFilterAPI(cliCtx, backend, timeout)
+}
+
+func newPublicFilterAPI(cliCtx context.CLIContext, backend Backend, timeout time.Duration) *PublicFilterAPI {
+	api := &PublicFilterAPI{
 		cliCtx:  cliCtx,
 		backend: backend,
+		timeout: timeout,
 		filters: make(map[rpc.ID]*Filter),
 	}
+
+	go api.timeoutLoop()
+
+	return api
 }
 
-// NewFilter instantiates a new filter.
-func (e *PublicFilterAPI) NewFilter(criteria filters.FilterCriteria) rpc.ID {
-	id := rpc.NewID()
-	e.filters[id] = NewFilter(e.backend, &criteria)
-	return id
+// timeoutLoop uninstalls the filters which haven't been polled within the
+// timeout. The filters are checked every timeout, so a filter lives at most
+// twice the timeout after its last poll.
+func (e *PublicFilterAPI) timeoutLoop() {
+	ticker := time.NewTicker(e.timeout)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		e.filtersMu.Lock()
+		for id, f := range e.filters {
+			select {
+			case <-f.deadline.C:
+				f.uninstallFilter()
+				delete(e.filters, id)
+			default:
+			}
+		}
+		e.filtersMu.Unlock()
+	}
 }
 
-// NewBlockFilter instantiates a new block filter.
-func (e *PublicFilterAPI) NewBlockFilter() rpc.ID {
+// install registers a filter under a new ID, starting its deadline.
+func (e *PublicFilterAPI) install(filter *Filter) rpc.ID {
 	id := rpc.NewID()
-	e.filters[id] = NewBlockFilter(e.backend)
+	filter.deadline = time.NewTimer(e.timeout)
+
+	e.filtersMu.Lock()
+	e.filters[id] = filter
+	e.filtersMu.Unlock()
+
 	return id
 }
 
+// NewFilter instantiates a new log filter returning on each poll the logs of
+// the blocks committed since the previous poll.
+func (e *PublicFilterAPI) NewFilter(criteria filters.FilterCriteria) (rpc.ID, error) {
+	filter, err := NewPollingFilter(e.backend, &criteria)
+	if err != nil {
+		return "", err
+	}
+
+	return e.install(filter), nil
+}
+
+// NewBlockFilter instantiates a new block filter returning on each poll the
+// hashes of the blocks committed since the previous poll.
+func (e *PublicFilterAPI) NewBlockFilter() (rpc.ID, error) {
+	filter, err := NewBlockFilter(e.backend)
+	if err != nil {
+		return "", err
+	}
+
+	return e.install(filter), nil
+}
+
 // NewPendingTransactionFilter instantiates a new pending transaction filter.
 func (e *PublicFilterAPI) NewPendingTransactionFilter() rpc.ID {
-	id := rpc.NewID()
-	e.filters[id] = NewPendingTransactionFilter(e.backend)
-	return id
+	return e.install(NewPendingTransactionFilter(e.backend))
 }
 
-// UninstallFilter uninstalls a filter with the given ID.
+// UninstallFilter uninstalls a filter with the given ID. It returns false if
+// there is no such filter.
 func (e *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
-	e.filters[id].uninstallFilter()
+	e.filtersMu.Lock()
+	defer e.filtersMu.Unlock()
+
+	f, ok := e.filters[id]
+	if !ok {
+		return false
+	}
+
+	f.uninstallFilter()
+	f.deadline.Stop()
 	delete(e.filters, id)
 	return true
 }
@@ -59,15 +135,34 @@ func (e *PublicFilterAPI) UninstallFilter(id rpc.ID) bool {
 // If the filter is a block filter, it returns an array of block hashes.
 // If the filter is a pending transaction filter, it returns an array of transaction hashes.
 func (e *PublicFilterAPI) GetFilterChanges(id rpc.ID) (interface{}, error) {
-	if e.filters[id] == nil {
+	e.filtersMu.Lock()
+	f, ok := e.filters[id]
+	if ok {
+		// the timer expired but the filter isn't yet removed by the timeout
+		// loop, the expiration is drained to reset the timer
+		if !f.deadline.Stop() {
+			<-f.deadline.C
+		}
+		f.deadline.Reset(e.timeout)
+	}
+	e.filtersMu.Unlock()
+
+	if !ok {
 		return nil, errors.New("invalid filter ID")
 	}
-	return e.filters[id].getFilterChanges()
+	return f.getFilterChanges()
 }
 
 // GetFilterLogs returns an array of all logs matching filter with given id.
 func (e *PublicFilterAPI) GetFilterLogs(id rpc.ID) ([]*ethtypes.Log, error) {
-	return e.filters[id].getFilterLogs()
+	e.filtersMu.Lock()
+	f, ok := e.filters[id]
+	e.filtersMu.Unlock()
+
+	if !ok || f.typ != logFilter {
+		return nil, errors.New("invalid filter ID")
+	}
+	return f.getFilterLogs()
 }
 
 // GetLogs returns logs matching the given argument that are stored within the state.
@@ -29,10 +29,12 @@ type Filter struct {
 	topics             [][]common.Hash  // log topics to watch for
 	blockHash          *common.Hash     // Block hash if filtering a single block
 
-	typ     string
-	hashes  []common.Hash   // filtered block or transaction hashes
-	logs    []*ethtypes.Log //nolint // filtered logs
-	stopped bool            // set to true once filter in uninstalled
+	typ       string
+	hashes    []common.Hash   // filtered block or transaction hashes
+	logs      []*ethtypes.Log //nolint // filtered logs
+	stopped   bool            // set to true once filter in uninstalled
+	lastBlock int64           // last block polled for changes
+	deadline  *time.Timer     // filter is uninstalled if not polled before the deadline
 
 	err error
 }
@@ -65,55 +67,92 @@ func NewFilterWithBlockHash(backend Backend, criteria *filters.FilterCriteria) *
 	}
 }
 
-// NewBlockFilter creates a new filter that notifies when a block arrives.
-func NewBlockFilter(backend Backend) *Filter {
+// NewBlockFilter creates a new filter that notifies when a block arrives. The
+// blocks are polled from the block following the latest one.
+func NewBlockFilter(backend Backend) (*Filter, error) {
+	num, err := backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
 	filter := NewFilter(backend, &filters.FilterCriteria{})
 	filter.typ = blockFilter
+	filter.lastBlock = int64(num)
 
-	go func() {
-		err := filter.pollForBlocks()
-		if err != nil {
-			filter.err = err
-		}
-	}()
-
-	return filter
+	return filter, nil
 }
 
-func (f *Filter) pollForBlocks() error {
-	prev := hexutil.Uint64(0)
+// NewPollingFilter creates a new log filter returning on each poll the logs of
+// the blocks committed since the previous poll. The logs are polled from the
+// block following the latest one.
+func NewPollingFilter(backend Backend, criteria *filters.FilterCriteria) (*Filter, error) {
+	num, err := backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
 
-	for {
-		if f.stopped {
-			return nil
-		}
+	filter := NewFilter(backend, criteria)
+	filter.lastBlock = int64(num)
 
-		num, err := f.backend.BlockNumber()
-		if err != nil {
-			return err
-		}
+	return filter, nil
+}
 
-		if num == prev {
-			continue
-		}
+// getBlockChanges returns the hashes of the blocks committed since the last
+// poll.
+func (f *Filter) getBlockChanges() ([]common.Hash, error) {
+	num, err := f.backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
 
-		block, err := f.backend.GetBlockByNumber(BlockNumber(num), false)
+	hashes := []common.Hash{}
+	for height := f.lastBlock + 1; height <= int64(num); height++ {
+		block, err := f.backend.GetBlockByNumber(BlockNumber(height), false)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		hashBytes, ok := block["hash"].(hexutil.Bytes)
 		if !ok {
-			return errors.New("could not convert block hash to hexutil.Bytes")
+			return nil, errors.New("could not convert block hash to hexutil.Bytes")
 		}
 
-		hash := common.BytesToHash(hashBytes)
-		f.hashes = append(f.hashes, hash)
+		hashes = append(hashes, common.BytesToHash(hashBytes))
+	}
 
-		prev = num
+	if int64(num) > f.lastBlock {
+		f.lastBlock = int64(num)
+	}
 
-		// TODO: should we add a delay?
+	return hashes, nil
+}
+
+// getLogChanges returns the logs matching the filter criteria of the blocks
+// committed since the last poll.
+func (f *Filter) getLogChanges() ([]*ethtypes.Log, error) {
+	num, err := f.backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := f.lastBlock+1, int64(num)
+	if f.fromBlock != nil && f.fromBlock.Sign() > 0 && f.fromBlock.Int64() > from {
+		from = f.fromBlock.Int64()
+	}
+	if f.toBlock != nil && f.toBlock.Sign() > 0 && f.toBlock.Int64() < to {
+		to = f.toBlock.Int64()
 	}
+
+	logs, err := f.getLogsInRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(num) > f.lastBlock {
+		f.lastBlock = int64(num)
+	}
+
+	return logs, nil
 }
 
 func (f *Filter) pollForTransactions() error {
@@ -170,15 +209,7 @@ func (f *Filter) uninstallFilter() {
 func (f *Filter) getFilterChanges() (interface{}, error) {
 	switch f.typ {
 	case blockFilter:
-		if f.err != nil {
-			return nil, f.err
-		}
-
-		blocks := make([]common.Hash, len(f.hashes))
-		copy(blocks, f.hashes)
-		f.hashes = []common.Hash{}
-
-		return blocks, nil
+		return f.getBlockChanges()
 	case pendingTxFilter:
 		if f.err != nil {
 			return nil, f.err
@@ -189,7 +220,7 @@ func (f *Filter) getFilterChanges() (interface{}, error) {
 		f.hashes = []common.Hash{}
 		return txs, nil
 	case logFilter:
-		return f.getFilterLogs()
+		return f.getLogChanges()
 	}
 
 	return nil, errors.New("unsupported filter")
@@ -200,17 +231,17 @@ func (f *Filter) getFilterLogs() ([]*ethtypes.Log, error) {
 
 	// filter specific block only
 	if f.blockHash != nil {
-		block, err := f.backend.GetBlockByHash(*f.blockHash, true)
+		block, err := f.backend.GetBlockByHash(*f.blockHash, false)
 		if err != nil {
 			return nil, err
 		}
 
 		// if the logsBloom == 0, there are no logs in that block
-		if txs, ok := block["transactions"].([]common.Hash); !ok {
+		if txs, ok := block["transactions"].([]common.Hash); !ok || len(txs) == 0 {
 			return ret, nil
-		} else if len(txs) != 0 {
-			return f.checkMatches(block)
 		}
+
+		return f.checkMatches(block)
 	}
 
 	// filter range of blocks
@@ -219,28 +250,32 @@ func (f *Filter) getFilterLogs() ([]*ethtypes.Log, error) {
 		return nil, err
 	}
 
-	// if f.fromBlock is set to 0, set it to the latest block number
-	if f.fromBlock == nil || f.fromBlock.Cmp(big.NewInt(0)) == 0 {
-		f.fromBlock = big.NewInt(int64(num))
+	// if the range bounds aren't set to a block, set them to the latest block
+	// number
+	from, to := int64(num), int64(num)
+	if f.fromBlock != nil && f.fromBlock.Sign() > 0 {
+		from = f.fromBlock.Int64()
 	}
-
-	// if f.toBlock is set to 0, set it to the latest block number
-	if f.toBlock == nil || f.toBlock.Cmp(big.NewInt(0)) == 0 {
-		f.toBlock = big.NewInt(int64(num))
+	if f.toBlock != nil && f.toBlock.Sign() > 0 {
+		to = f.toBlock.Int64()
 	}
 
-	log.Debug("[ethAPI] Retrieving filter logs", "fromBlock", f.fromBlock, "toBlock", f.toBlock,
-		"topics", f.topics, "addresses", f.addresses)
+	return f.getLogsInRange(from, to)
+}
 
-	from := f.fromBlock.Int64()
-	to := f.toBlock.Int64()
+// getLogsInRange returns the logs matching the filter criteria of the blocks
+// within the given range.
+func (f *Filter) getLogsInRange(from, to int64) ([]*ethtypes.Log, error) {
+	ret := []*ethtypes.Log{}
+
+	log.Debug("[ethAPI] Retrieving filter logs", "fromBlock", from, "toBlock", to,
+		"topics", f.topics, "addresses", f.addresses)
 
 	for i := from; i <= to; i++ {
-		block, err := f.backend.GetBlockByNumber(NewBlockNumber(big.NewInt(i)), true)
+		block, err := f.backend.GetBlockByNumber(NewBlockNumber(big.NewInt(i)), false)
 		if err != nil {
-			f.err = err
-			log.Debug("[ethAPI] Cannot get block", "block", block["number"], "error", err)
-			break
+			log.Debug("[ethAPI] Cannot get block", "block", i, "error", err)
+			return nil, err
 		}
 
 		log.Debug("[ethAPI] filtering", "block", block)
@@ -253,8 +288,7 @@ func (f *Filter) getFilterLogs() ([]*ethtypes.Log, error) {
 		} else if len(txs) != 0 {
 			logs, err := f.checkMatches(block)
 			if err != nil {
-				f.err = err
-				break
+				return nil, err
 			}
 
 			ret = append(ret, logs...)
@@ -0,0 +1,169 @@
+package rpc
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+)
+
+var errNotImplemented = errors.New("not implemented")
+
+// mockBackend is a Backend serving a chain of blocks, each holding a single
+// transaction with the given logs, and the given base fee.
+type mockBackend struct {
+	blocks  [][]*ethtypes.Log
+	baseFee int64
+}
+
+var _ Backend = &mockBackend{}
+
+// addBlock commits a block holding a transaction with the given logs.
+func (b *mockBackend) addBlock(logs ...*ethtypes.Log) {
+	height := uint64(len(b.blocks) + 1)
+	for _, log := range logs {
+		log.BlockNumber = height
+		log.TxHash = mockTxHash(int64(height))
+	}
+	b.blocks = append(b.blocks, logs)
+}
+
+func mockTxHash(height int64) common.Hash {
+	return common.BigToHash(big.NewInt(height))
+}
+
+func mockBlockHash(height int64) common.Hash {
+	return common.BigToHash(big.NewInt(height + 1000))
+}
+
+func (b *mockBackend) BlockNumber() (hexutil.Uint64, error) {
+	return hexutil.Uint64(len(b.blocks)), nil
+}
+
+func (b *mockBackend) GetBlockByNumber(blockNum BlockNumber, _ bool) (map[string]interface{}, error) {
+	return b.getEthBlockByNumber(blockNum.Int64(), false)
+}
+
+func (b *mockBackend) GetBlockByHash(common.Hash, bool) (map[string]interface{}, error) {
+	return nil, errNotImplemented
+}
+
+func (b *mockBackend) getEthBlockByNumber(height int64, _ bool) (map[string]interface{}, error) {
+	if height < 1 || height > int64(len(b.blocks)) {
+		return nil, errors.New("block not found")
+	}
+
+	return map[string]interface{}{
+		"number":       hexutil.Uint64(height),
+		"hash":         hexutil.Bytes(mockBlockHash(height).Bytes()),
+		"transactions": []common.Hash{mockTxHash(height)},
+	}, nil
+}
+
+func (b *mockBackend) getGasLimit() (int64, error) { return 0, nil }
+
+func (b *mockBackend) getBaseFee(int64) (*big.Int, error) { return big.NewInt(b.baseFee), nil }
+
+func (b *mockBackend) PendingTransactions() ([]*Transaction, error) { return nil, nil }
+
+func (b *mockBackend) GetTxLogs(txHash common.Hash) ([]*ethtypes.Log, error) {
+	return b.blocks[txHash.Big().Int64()-1], nil
+}
+
+func TestLogFilterChanges(t *testing.T) {
+	addr := common.BytesToAddress([]byte("contract"))
+	other := common.BytesToAddress([]byte("other"))
+
+	backend := &mockBackend{}
+	backend.addBlock(&ethtypes.Log{Address: addr})
+
+	api := newPublicFilterAPI(context.CLIContext{}, backend, time.Minute)
+	id, err := api.NewFilter(filters.FilterCriteria{Addresses: []common.Address{addr}})
+	require.NoError(t, err)
+
+	// the first poll returns the matching logs of the blocks committed since
+	// the filter was installed
+	backend.addBlock(&ethtypes.Log{Address: addr, Data: []byte{2}}, &ethtypes.Log{Address: other})
+
+	changes, err := api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Equal(t, []*ethtypes.Log{backend.blocks[1][0]}, changes)
+
+	// the second poll only returns the logs committed since the first one
+	backend.addBlock(&ethtypes.Log{Address: other})
+	backend.addBlock(&ethtypes.Log{Address: addr, Data: []byte{4}})
+
+	changes, err = api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Equal(t, []*ethtypes.Log{backend.blocks[3][0]}, changes)
+
+	changes, err = api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	// the filter logs aren't affected by the polls
+	logs, err := api.GetFilterLogs(id)
+	require.NoError(t, err)
+	require.Equal(t, []*ethtypes.Log{backend.blocks[3][0]}, logs)
+
+	require.True(t, api.UninstallFilter(id))
+	require.False(t, api.UninstallFilter(id))
+
+	_, err = api.GetFilterChanges(id)
+	require.Error(t, err)
+}
+
+func TestBlockFilterChanges(t *testing.T) {
+	backend := &mockBackend{}
+	backend.addBlock()
+
+	api := newPublicFilterAPI(context.CLIContext{}, backend, time.Minute)
+	id, err := api.NewBlockFilter()
+	require.NoError(t, err)
+
+	backend.addBlock()
+	backend.addBlock()
+
+	changes, err := api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{mockBlockHash(2), mockBlockHash(3)}, changes)
+
+	backend.addBlock()
+
+	changes, err = api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Equal(t, []common.Hash{mockBlockHash(4)}, changes)
+
+	changes, err = api.GetFilterChanges(id)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
+
+func TestFilterTimeout(t *testing.T) {
+	backend := &mockBackend{}
+	api := newPublicFilterAPI(context.CLIContext{}, backend, 10*time.Millisecond)
+
+	id, err := api.NewBlockFilter()
+	require.NoError(t, err)
+
+	// the filter not polled within the timeout is uninstalled
+	require.Eventually(t, func() bool {
+		api.filtersMu.Lock()
+		defer api.filtersMu.Unlock()
+
+		_, ok := api.filters[id]
+		return !ok
+	}, time.Second, 5*time.Millisecond)
+
+	_, err = api.GetFilterChanges(id)
+	require.Error(t, err)
+}
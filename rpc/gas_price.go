@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	emint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm"
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+const (
+	flagGasPriceBlocks     = "gpo-blocks"
+	flagGasPricePercentile = "gpo-percentile"
+	flagGasPriceFallback   = "gpo-fallback"
+
+	defaultGasPriceBlocks     = 20
+	defaultGasPricePercentile = 60
+)
+
+// gasPriceOracle suggests gas prices from the prices paid by the Ethereum
+// transactions of the recent blocks.
+type gasPriceOracle struct {
+	cliCtx     context.CLIContext
+	backend    Backend
+	blocks     int64
+	percentile int
+	fallback   *big.Int
+}
+
+// newGasPriceOracle creates a gas price oracle configured by the gpo flags of
+// the rest-server command.
+func newGasPriceOracle(cliCtx context.CLIContext, backend Backend) *gasPriceOracle {
+	blocks := viper.GetInt64(flagGasPriceBlocks)
+	if blocks <= 0 {
+		blocks = defaultGasPriceBlocks
+	}
+
+	percentile := viper.GetInt(flagGasPricePercentile)
+	if percentile < 0 || percentile > 100 {
+		percentile = defaultGasPricePercentile
+	}
+
+	fallback, ok := new(big.Int).SetString(viper.GetString(flagGasPriceFallback), 0)
+	if !ok || fallback.Sign() < 0 {
+		fallback = big.NewInt(emint.DefaultGasPrice)
+	}
+
+	return &gasPriceOracle{
+		cliCtx:     cliCtx,
+		backend:    backend,
+		blocks:     blocks,
+		percentile: percentile,
+		fallback:   fallback,
+	}
+}
+
+// SuggestPrice returns the percentile of the gas prices of the Ethereum
+// transactions included in the recent blocks, or the fallback price if these
+// blocks have no Ethereum transaction. The suggested price is bounded by the
+// gas price params of the EVM module so that the transactions using it are not
+// rejected.
+func (gpo *gasPriceOracle) SuggestPrice() (*big.Int, error) {
+	latest, err := gpo.backend.BlockNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	var prices []*big.Int
+	for height := int64(latest); height > 0 && height > int64(latest)-gpo.blocks; height-- {
+		h := height
+		block, err := gpo.cliCtx.Client.Block(&h)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range block.Block.Txs {
+			// only the Ethereum transactions are sampled
+			ethTx, err := bytesToEthTx(gpo.cliCtx, tx)
+			if err != nil {
+				continue
+			}
+			prices = append(prices, ethTx.Data.Price)
+		}
+	}
+
+	price := gpo.fallback
+	if len(prices) > 0 {
+		sort.Slice(prices, func(i, j int) bool { return prices[i].Cmp(prices[j]) < 0 })
+		price = prices[(len(prices)-1)*gpo.percentile/100]
+	}
+
+	res, _, err := gpo.cliCtx.Query(fmt.Sprintf("custom/%s/%s", types.ModuleName, evm.QueryParams))
+	if err != nil {
+		return nil, err
+	}
+
+	var params types.Params
+	if err := gpo.cliCtx.Codec.UnmarshalJSON(res, &params); err != nil {
+		return nil, err
+	}
+
+	return boundGasPrice(price, params), nil
+}
+
+// boundGasPrice returns the gas price within the minimum and maximum gas prices
+// of the params.
+func boundGasPrice(price *big.Int, params types.Params) *big.Int {
+	gasPrice := sdk.NewIntFromBigInt(price)
+
+	if gasPrice.LT(params.MinGasPrice) {
+		return params.MinGasPrice.BigInt()
+	}
+
+	if !params.MaxGasPrice.IsZero() && gasPrice.GT(params.MaxGasPrice) {
+		return params.MaxGasPrice.BigInt()
+	}
+
+	return new(big.Int).Set(price)
+}
@@ -2,33 +2,30 @@ package rpc
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
-	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/spf13/viper"
 )
 
 // PublicNetAPI is the eth_ prefixed set of APIs in the Web3 JSON-RPC spec.
 type PublicNetAPI struct {
-	networkVersion uint64
+	networkVersion string
 }
 
 // NewPersonalEthAPI creates an instance of the public ETH Web3 API.
 func NewPublicNetAPI(cliCtx context.CLIContext) *PublicNetAPI {
-	chainID := viper.GetString(flags.FlagChainID)
-	// parse the chainID from a integer string
-	intChainID, err := strconv.ParseUint(chainID, 0, 64)
+	// parse the EIP-155 chain ID from the chain-id string, as the transactions do
+	chainID, err := chainIDFromFlags()
 	if err != nil {
-		panic(fmt.Sprintf("invalid chainID: %s, must be integer format", chainID))
+		panic(fmt.Sprintf("invalid chain-id: %s", err))
 	}
 
 	return &PublicNetAPI{
-		networkVersion: intChainID,
+		networkVersion: chainID.String(),
 	}
 }
 
-// Version returns the current ethereum protocol version.
+// Version returns the network ID, which is the EIP-155 chain ID as a decimal
+// string.
 func (s *PublicNetAPI) Version() string {
-	return fmt.Sprintf("%d", s.networkVersion)
+	return s.networkVersion
 }
@@ -1,9 +1,12 @@
 package rpc
 
 import (
+	"bytes"
 	"context"
 
 	sdkcontext "github.com/cosmos/cosmos-sdk/client/context"
+	emintcrypto "github.com/cosmos/ethermint/crypto"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
@@ -12,13 +15,15 @@ import (
 type PersonalEthAPI struct {
 	cliCtx    sdkcontext.CLIContext
 	nonceLock *AddrLocker
+	key       emintcrypto.PrivKeySecp256k1
 }
 
 // NewPersonalEthAPI creates an instance of the public ETH Web3 API.
-func NewPersonalEthAPI(cliCtx sdkcontext.CLIContext, nonceLock *AddrLocker) *PersonalEthAPI {
+func NewPersonalEthAPI(cliCtx sdkcontext.CLIContext, nonceLock *AddrLocker, key emintcrypto.PrivKeySecp256k1) *PersonalEthAPI {
 	return &PersonalEthAPI{
 		cliCtx:    cliCtx,
 		nonceLock: nonceLock,
+		key:       key,
 	}
 }
 
@@ -28,9 +33,29 @@ func NewPersonalEthAPI(cliCtx sdkcontext.CLIContext, nonceLock *AddrLocker) *Per
 // Note, the produced signature conforms to the secp256k1 curve R, S and V values,
 // where the V value will be 27 or 28 for legacy reasons.
 //
-// The key used to calculate the signature is decrypted with the given password.
+// The key used to calculate the signature is the one unlocked when starting the
+// rest-server, hence the given password is ignored.
 //
 // https://github.com/ethereum/go-ethereum/wiki/Management-APIs#personal_sign
 func (e *PersonalEthAPI) Sign(ctx context.Context, data hexutil.Bytes, addr common.Address, passwd string) (hexutil.Bytes, error) {
-	return nil, nil
+	// TODO: Change this functionality to find an unlocked account by address
+	if e.key == nil || !bytes.Equal(e.key.PubKey().Address().Bytes(), addr.Bytes()) {
+		return nil, keystore.ErrLocked
+	}
+
+	return e.key.SignPersonalMessage(data)
+}
+
+// EcRecover returns the address of the account that created the signature
+// with personal_sign or eth_sign over the provided data. The V value of the
+// signature may be 27/28, as produced by personal_sign, or 0/1.
+//
+// https://github.com/ethereum/go-ethereum/wiki/Management-APIs#personal_ecRecover
+func (e *PersonalEthAPI) EcRecover(ctx context.Context, data, sig hexutil.Bytes) (common.Address, error) {
+	addr, err := emintcrypto.RecoverPersonalMessageSigner(data, sig)
+	if err != nil {
+		return common.Address{}, newInvalidParamsError("%s", err.Error())
+	}
+
+	return addr, nil
 }
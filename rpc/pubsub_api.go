@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	sdkcontext "github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// tmWebsocketEndpoint is the endpoint of the Tendermint websocket event API
+	tmWebsocketEndpoint = "/websocket"
+	// eventsCapacity is the number of Tendermint events buffered per query
+	eventsCapacity = 100
+)
+
+// PubSubAPI is the eth_subscribe and eth_unsubscribe set of APIs in the Web3
+// JSON-RPC spec. The subscriptions are only supported on connections with
+// notifications, ie. websockets.
+type PubSubAPI struct {
+	backend Backend
+	events  *eventSystem
+}
+
+// NewPubSubAPI creates an instance of the public pub-sub Web3 API.
+func NewPubSubAPI(cliCtx sdkcontext.CLIContext, backend Backend) *PubSubAPI {
+	return &PubSubAPI{
+		backend: backend,
+		events:  newEventSystem(cliCtx.NodeURI),
+	}
+}
+
+// NewHeads sends a notification with the header of each block committed by
+// the node.
+func (api *PubSubAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	gasLimit, err := api.backend.getGasLimit()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	events, err := api.events.subscribe(rpcSub.ID, tmtypes.EventQueryNewBlockHeader.String())
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer api.events.unsubscribe(rpcSub.ID, tmtypes.EventQueryNewBlockHeader.String())
+
+		for {
+			select {
+			case event := <-events:
+				data, ok := event.Data.(tmtypes.EventDataNewBlockHeader)
+				if !ok {
+					continue
+				}
+
+				header := formatHeader(data.Header, gasLimit, blockBloomFromEvents(data.ResultEndBlock.Events))
+				if err := notifier.Notify(rpcSub.ID, header); err != nil {
+					log.Debug("failed to notify block header", "subscription", rpcSub.ID, "error", err)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// Logs sends a notification for each log emitted by a committed transaction
+// that matches the given criteria, which are the ones of eth_getLogs.
+func (api *PubSubAPI) Logs(ctx context.Context, criteria filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	events, err := api.events.subscribe(rpcSub.ID, tmtypes.EventQueryTx.String())
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		defer api.events.unsubscribe(rpcSub.ID, tmtypes.EventQueryTx.String())
+
+		for {
+			select {
+			case event := <-events:
+				data, ok := event.Data.(tmtypes.EventDataTx)
+				if !ok || !data.Result.IsOK() {
+					continue
+				}
+
+				// skip the results of non Ethereum transactions
+				resultData, err := types.DecodeResultData(data.Result.Data)
+				if err != nil {
+					continue
+				}
+
+				logs := filterLogs(resultData.Logs, criteria.FromBlock, criteria.ToBlock, criteria.Addresses, criteria.Topics)
+				for _, ethLog := range logs {
+					if err := notifier.Notify(rpcSub.ID, ethLog); err != nil {
+						break
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// eventSystem fans the Tendermint events out to the Web3 subscriptions. The
+// Tendermint websocket client keeps a single channel per query, hence the node
+// is subscribed once per query for as long as a subscription uses it.
+type eventSystem struct {
+	client *rpcclient.HTTP
+
+	mtx     sync.Mutex
+	started bool
+	streams map[string]*eventStream
+}
+
+// eventStream holds the subscriptions of a Tendermint query.
+type eventStream struct {
+	subs map[rpc.ID]chan coretypes.ResultEvent
+	quit chan struct{}
+}
+
+func newEventSystem(nodeURI string) *eventSystem {
+	return &eventSystem{
+		client:  rpcclient.NewHTTP(nodeURI, tmWebsocketEndpoint),
+		streams: make(map[string]*eventStream),
+	}
+}
+
+// subscribe returns the channel receiving the events of the query for the
+// given subscription. The websocket connection to the node is established on
+// the first subscription.
+func (es *eventSystem) subscribe(id rpc.ID, query string) (<-chan coretypes.ResultEvent, error) {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	if !es.started {
+		if err := es.client.Start(); err != nil {
+			return nil, fmt.Errorf("failed to connect to the node event API: %w", err)
+		}
+		es.started = true
+	}
+
+	stream, ok := es.streams[query]
+	if !ok {
+		events, err := es.client.Subscribe(context.Background(), string(id), query, eventsCapacity)
+		if err != nil {
+			return nil, err
+		}
+
+		stream = &eventStream{
+			subs: make(map[rpc.ID]chan coretypes.ResultEvent),
+			quit: make(chan struct{}),
+		}
+		es.streams[query] = stream
+
+		go es.broadcast(stream, events)
+	}
+
+	ch := make(chan coretypes.ResultEvent, eventsCapacity)
+	stream.subs[id] = ch
+	return ch, nil
+}
+
+// unsubscribe removes the subscription from the query. The node is
+// unsubscribed from the query once it has no subscriptions left.
+func (es *eventSystem) unsubscribe(id rpc.ID, query string) {
+	es.mtx.Lock()
+	defer es.mtx.Unlock()
+
+	stream, ok := es.streams[query]
+	if !ok {
+		return
+	}
+
+	delete(stream.subs, id)
+	if len(stream.subs) > 0 {
+		return
+	}
+
+	close(stream.quit)
+	delete(es.streams, query)
+
+	if err := es.client.Unsubscribe(context.Background(), string(id), query); err != nil {
+		log.Debug("failed to unsubscribe from the node events", "query", query, "error", err)
+	}
+}
+
+// broadcast forwards the events of a query to its subscriptions until the
+// query has no subscriptions left. The events are dropped for subscriptions
+// that don't keep up.
+func (es *eventSystem) broadcast(stream *eventStream, events <-chan coretypes.ResultEvent) {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			es.mtx.Lock()
+			for id, ch := range stream.subs {
+				select {
+				case ch <- event:
+				default:
+					log.Debug("dropped event of a slow subscription", "subscription", id)
+				}
+			}
+			es.mtx.Unlock()
+		case <-stream.quit:
+			return
+		}
+	}
+}
+
+// blockBloomFromEvents returns the block bloom emitted by the EVM module at the
+// end of the block, or an empty bloom if there is none.
+func blockBloomFromEvents(events []abci.Event) ethtypes.Bloom {
+	for _, event := range events {
+		if event.Type != types.EventTypeBlockBloom {
+			continue
+		}
+
+		for _, attr := range event.Attributes {
+			if string(attr.Key) != types.AttributeKeyEthereumBloom {
+				continue
+			}
+
+			bz, err := hexutil.Decode(string(attr.Value))
+			if err != nil {
+				return ethtypes.Bloom{}
+			}
+			return ethtypes.BytesToBloom(bz)
+		}
+	}
+
+	return ethtypes.Bloom{}
+}
+
+// formatHeader returns the Ethereum compatible header of a Tendermint block
+// header, as returned by the newHeads subscription. The fields without
+// Tendermint analogue are set to the same zero values as for blocks.
+func formatHeader(header tmtypes.Header, gasLimit int64, bloom ethtypes.Bloom) map[string]interface{} {
+	return map[string]interface{}{
+		"number":           hexutil.Uint64(header.Height),
+		"hash":             hexutil.Bytes(header.Hash()),
+		"parentHash":       hexutil.Bytes(header.LastBlockID.Hash),
+		"nonce":            ethtypes.BlockNonce{},   // PoW specific
+		"mixHash":          common.Hash{},           // PoW specific
+		"sha3Uncles":       ethtypes.EmptyUncleHash, // No uncles in Tendermint
+		"logsBloom":        bloom,
+		"transactionsRoot": hexutil.Bytes(header.DataHash),
+		"stateRoot":        hexutil.Bytes(header.AppHash),
+		"miner":            common.BytesToAddress(header.ProposerAddress),
+		"difficulty":       (*hexutil.Big)(big.NewInt(0)),
+		"extraData":        hexutil.Bytes{},
+		"gasLimit":         hexutil.Uint64(gasLimit), // Static gas limit
+		"timestamp":        hexutil.Uint64(header.Time.Unix()),
+	}
+}
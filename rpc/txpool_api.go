@@ -0,0 +1,166 @@
+package rpc
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/ethermint/x/evm"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PublicTxPoolAPI is the txpool_ prefixed set of APIs in the Web3 JSON-RPC
+// spec, inspecting the Ethereum transactions of the Tendermint mempool.
+type PublicTxPoolAPI struct {
+	cliCtx context.CLIContext
+}
+
+// NewPublicTxPoolAPI creates an instance of the public txpool Web3 API.
+func NewPublicTxPoolAPI(cliCtx context.CLIContext) *PublicTxPoolAPI {
+	return &PublicTxPoolAPI{
+		cliCtx: cliCtx,
+	}
+}
+
+// txPool holds the Ethereum transactions of the mempool by sender and nonce,
+// split between the pending transactions, executable from the nonce of their
+// sender account, and the queued transactions following a nonce gap.
+type txPool struct {
+	pending map[common.Address]map[uint64]*types.MsgEthereumTx
+	queued  map[common.Address]map[uint64]*types.MsgEthereumTx
+}
+
+// Content returns the pending and queued Ethereum transactions of the mempool,
+// grouped by sender and nonce.
+func (api *PublicTxPoolAPI) Content() (map[string]map[string]map[string]*Transaction, error) {
+	pool, err := api.txPool()
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := txPoolContent(pool.pending)
+	if err != nil {
+		return nil, err
+	}
+
+	queued, err := txPoolContent(pool.queued)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]map[string]map[string]*Transaction{
+		"pending": pending,
+		"queued":  queued,
+	}, nil
+}
+
+// Status returns the number of pending and queued Ethereum transactions of the
+// mempool.
+func (api *PublicTxPoolAPI) Status() (map[string]hexutil.Uint, error) {
+	pool, err := api.txPool()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending, queued int
+	for _, txs := range pool.pending {
+		pending += len(txs)
+	}
+	for _, txs := range pool.queued {
+		queued += len(txs)
+	}
+
+	return map[string]hexutil.Uint{
+		"pending": hexutil.Uint(pending),
+		"queued":  hexutil.Uint(queued),
+	}, nil
+}
+
+// txPool groups the Ethereum transactions of the mempool by recovered sender
+// and classifies them against the nonce of the sender account. The other
+// transactions, the ones with an invalid signature and the ones with a nonce
+// already used are skipped.
+func (api *PublicTxPoolAPI) txPool() (*txPool, error) {
+	unconfirmedTxs, err := api.cliCtx.Client.UnconfirmedTxs(maxUnconfirmedTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	senders := make(map[common.Address]map[uint64]*types.MsgEthereumTx)
+	for _, bz := range unconfirmedTxs.Txs {
+		ethTx, err := bytesToEthTx(api.cliCtx, bz)
+		if err != nil {
+			continue
+		}
+
+		from, err := ethTx.VerifySig(ethTx.ChainID())
+		if err != nil {
+			continue
+		}
+
+		if senders[from] == nil {
+			senders[from] = make(map[uint64]*types.MsgEthereumTx)
+		}
+		senders[from][ethTx.Data.AccountNonce] = ethTx
+	}
+
+	pool := &txPool{
+		pending: make(map[common.Address]map[uint64]*types.MsgEthereumTx),
+		queued:  make(map[common.Address]map[uint64]*types.MsgEthereumTx),
+	}
+
+	for from, txs := range senders {
+		res, _, err := api.cliCtx.Query(fmt.Sprintf("custom/%s/%s/%s", types.ModuleName, evm.QueryNonce, from.Hex()))
+		if err != nil {
+			return nil, err
+		}
+
+		var out types.QueryResNonce
+		api.cliCtx.Codec.MustUnmarshalJSON(res, &out)
+
+		// the transactions following the account nonce without gap are pending
+		nonce := out.Nonce
+		for ; txs[nonce] != nil; nonce++ {
+			if pool.pending[from] == nil {
+				pool.pending[from] = make(map[uint64]*types.MsgEthereumTx)
+			}
+			pool.pending[from][nonce] = txs[nonce]
+		}
+
+		// the transactions after a nonce gap are queued, the ones below the
+		// account nonce being already used
+		for txNonce, tx := range txs {
+			if txNonce < nonce {
+				continue
+			}
+
+			if pool.queued[from] == nil {
+				pool.queued[from] = make(map[uint64]*types.MsgEthereumTx)
+			}
+			pool.queued[from][txNonce] = tx
+		}
+	}
+
+	return pool, nil
+}
+
+// txPoolContent converts the transactions grouped by sender and nonce to their
+// RPC representation, keyed by sender address and decimal nonce.
+func txPoolContent(txs map[common.Address]map[uint64]*types.MsgEthereumTx) (map[string]map[string]*Transaction, error) {
+	content := make(map[string]map[string]*Transaction, len(txs))
+	for from, nonceTxs := range txs {
+		content[from.Hex()] = make(map[string]*Transaction, len(nonceTxs))
+		for nonce, tx := range nonceTxs {
+			rpcTx, err := newRPCTransaction(*tx, common.Hash{}, nil, 0)
+			if err != nil {
+				return nil, err
+			}
+			content[from.Hex()][strconv.FormatUint(nonce, 10)] = rpcTx
+		}
+	}
+
+	return content, nil
+}
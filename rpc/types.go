@@ -18,6 +18,11 @@ const (
 
 	// EarliestBlockNumber mapping from "earliest" to 1 for tm query (earliest query not supported)
 	EarliestBlockNumber = BlockNumber(1)
+
+	// PendingBlockNumber mapping from "pending" to -1. Apart from the account
+	// nonces the pending state isn't tracked, so it maps to 0 for tm query and
+	// the latest state is queried instead.
+	PendingBlockNumber = BlockNumber(-1)
 )
 
 func NewBlockNumber(n *big.Int) BlockNumber {
@@ -44,9 +49,8 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 		*bn = LatestBlockNumber
 		return nil
 	case "pending":
-		return fmt.Errorf("pending queries not implemented")
-		// *bn = PendingBlockNumber
-		// return nil
+		*bn = PendingBlockNumber
+		return nil
 	}
 
 	blckNum, err := hexutil.DecodeUint64(input)
@@ -61,7 +65,11 @@ func (bn *BlockNumber) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// Int64 converts block number to primitive type
+// Int64 converts block number to primitive type. The pending block number is
+// converted to the latest one.
 func (bn BlockNumber) Int64() int64 {
+	if bn == PendingBlockNumber {
+		return LatestBlockNumber.Int64()
+	}
 	return (int64)(bn)
 }
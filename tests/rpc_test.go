@@ -4,6 +4,7 @@
 // and have started the RPC service with `emintcli rest-server`.
 //
 // You can configure the desired ETHERMINT_NODE_HOST and ETHERMINT_INTEGRATION_TEST_MODE
+// (and ETHERMINT_WS_HOST for the websocket subscriptions)
 //
 // to have it running
 
@@ -11,18 +12,23 @@ package tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cosmos/ethermint/version"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -34,6 +40,7 @@ const (
 var (
 	ETHERMINT_INTEGRATION_TEST_MODE = os.Getenv("ETHERMINT_INTEGRATION_TEST_MODE")
 	ETHERMINT_NODE_HOST             = os.Getenv("ETHERMINT_NODE_HOST")
+	ETHERMINT_WS_HOST               = os.Getenv("ETHERMINT_WS_HOST")
 
 	zeroString = "0x0"
 )
@@ -134,6 +141,58 @@ func TestEth_protocolVersion(t *testing.T) {
 	require.Equal(t, expectedRes, res, "expected: %s got: %s\n", expectedRes.String(), rpcRes.Result)
 }
 
+func TestWeb3_clientVersion(t *testing.T) {
+	rpcRes, err := call(t, "web3_clientVersion", []string{})
+	require.NoError(t, err)
+
+	var res string
+	err = json.Unmarshal(rpcRes.Result, &res)
+	require.NoError(t, err)
+
+	require.Equal(t, version.ClientVersion(), res)
+}
+
+func TestWeb3_sha3(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"0x", "0xc5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		// "hello"
+		{"0x68656c6c6f", "0x1c8aff950685c2ed4bc3174f3472287b56d9517b9c948127319a09a7a36deac8"},
+	}
+
+	for _, tc := range testCases {
+		rpcRes, err := call(t, "web3_sha3", []string{tc.input})
+		require.NoError(t, err)
+
+		var res hexutil.Bytes
+		err = res.UnmarshalJSON(rpcRes.Result)
+		require.NoError(t, err)
+
+		require.Equal(t, tc.expected, res.String())
+		require.Equal(t, crypto.Keccak256(hexutil.MustDecode(tc.input)), []byte(res))
+	}
+}
+
+func TestEth_chainId(t *testing.T) {
+	rpcRes, err := call(t, "eth_chainId", []string{})
+	require.NoError(t, err)
+
+	var chainID hexutil.Big
+	err = chainID.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+
+	// require the network ID to be the decimal chain ID
+	rpcRes, err = call(t, "net_version", []string{})
+	require.NoError(t, err)
+
+	var netVersion string
+	err = json.Unmarshal(rpcRes.Result, &netVersion)
+	require.NoError(t, err)
+	require.Equal(t, chainID.ToInt().String(), netVersion)
+}
+
 func TestEth_blockNumber(t *testing.T) {
 	rpcRes, err := call(t, "eth_blockNumber", []string{})
 	require.NoError(t, err)
@@ -161,9 +220,24 @@ func TestEth_GetBalance(t *testing.T) {
 	}
 }
 
+func TestEth_GetBalance_BlockTags(t *testing.T) {
+	for _, tag := range []string{"latest", "pending", "0x1"} {
+		rpcRes, err := call(t, "eth_getBalance", []string{addrA, tag})
+		require.NoError(t, err, tag)
+
+		var res hexutil.Big
+		err = res.UnmarshalJSON(rpcRes.Result)
+		require.NoError(t, err, tag)
+	}
+
+	// require an error for a block number beyond the chain tip
+	_, err := call(t, "eth_getBalance", []string{addrA, "0x7fffffffffffffff"})
+	require.Error(t, err)
+}
+
 func TestEth_GetStorageAt(t *testing.T) {
 	expectedRes := hexutil.Bytes{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
-	rpcRes, err := call(t, "eth_getStorageAt", []string{addrA, string(addrAStoreKey), zeroString})
+	rpcRes, err := call(t, "eth_getStorageAt", []string{addrA, fmt.Sprint(addrAStoreKey), zeroString})
 	require.NoError(t, err)
 
 	var storage hexutil.Bytes
@@ -200,6 +274,22 @@ func getAddress(t *testing.T) []byte {
 	return res[0]
 }
 
+func TestEth_Accounts(t *testing.T) {
+	rpcRes, err := call(t, "eth_accounts", []string{})
+	require.NoError(t, err)
+
+	// require an array, even if empty, of lowercase hex addresses
+	var res []string
+	err = json.Unmarshal(rpcRes.Result, &res)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	for _, addr := range res {
+		require.True(t, common.IsHexAddress(addr), addr)
+		require.Equal(t, strings.ToLower(addr), addr)
+	}
+}
+
 func TestEth_SendTransaction(t *testing.T) {
 	from := getAddress(t)
 
@@ -216,6 +306,91 @@ func TestEth_SendTransaction(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestEth_Call(t *testing.T) {
+	from := getAddress(t)
+
+	for _, tag := range []string{"latest", "pending"} {
+		param := []interface{}{
+			map[string]string{
+				"from": "0x" + fmt.Sprintf("%x", from),
+				"to":   "0x1122334455667788990011223344556677889900",
+			},
+			tag,
+		}
+
+		rpcRes, err := call(t, "eth_call", param)
+		require.NoError(t, err)
+
+		var ret hexutil.Bytes
+		err = json.Unmarshal(rpcRes.Result, &ret)
+		require.NoError(t, err)
+		require.Empty(t, ret)
+	}
+}
+
+func TestEth_EstimateGas(t *testing.T) {
+	from := getAddress(t)
+
+	param := make([]map[string]string, 1)
+	param[0] = make(map[string]string)
+	param[0]["from"] = "0x" + fmt.Sprintf("%x", from)
+	param[0]["to"] = "0x1122334455667788990011223344556677889900"
+
+	rpcRes, err := call(t, "eth_estimateGas", param)
+	require.NoError(t, err)
+
+	var gas hexutil.Uint64
+	err = json.Unmarshal(rpcRes.Result, &gas)
+	require.NoError(t, err)
+	// a transfer only costs the intrinsic gas, plus the buffer of the estimation
+	require.True(t, uint64(gas) >= 21000 && uint64(gas) <= 22000, "gas %d", gas)
+}
+
+func TestEth_EstimateGas_Reverted(t *testing.T) {
+	from := getAddress(t)
+
+	// init code reverting with the ABI encoded Error("estimation reverted")
+	param := []map[string]string{{
+		"from": "0x" + fmt.Sprintf("%x", from),
+		"data": "0x6064600c60003960646000fd08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000013657374696d6174696f6e20726576657274656400000000000000000000000000",
+	}}
+
+	_, err := call(t, "eth_estimateGas", param)
+	require.EqualError(t, err, "execution reverted: estimation reverted")
+}
+
+func TestEth_EstimateGas_OutOfGas(t *testing.T) {
+	from := getAddress(t)
+
+	// init code looping forever (JUMPDEST PUSH1 0 JUMP), which can't succeed at any gas
+	param := []map[string]string{{
+		"from": "0x" + fmt.Sprintf("%x", from),
+		"data": "0x5b600056",
+	}}
+
+	_, err := call(t, "eth_estimateGas", param)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "gas required exceeds allowance")
+}
+
+func TestEth_EstimateGas_ContractDeployment(t *testing.T) {
+	from := getAddress(t)
+
+	param := make([]map[string]string, 1)
+	param[0] = make(map[string]string)
+	param[0]["from"] = "0x" + fmt.Sprintf("%x", from)
+	param[0]["data"] = "0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029"
+
+	rpcRes, err := call(t, "eth_estimateGas", param)
+	require.NoError(t, err)
+
+	var gas hexutil.Uint64
+	err = json.Unmarshal(rpcRes.Result, &gas)
+	require.NoError(t, err)
+	// contract creation costs at least 53000 of intrinsic gas
+	require.True(t, uint64(gas) > 53000)
+}
+
 func TestEth_NewFilter(t *testing.T) {
 	param := make([]map[string][]string, 1)
 	param[0] = make(map[string][]string)
@@ -289,8 +464,86 @@ func TestEth_GetTransactionReceipt(t *testing.T) {
 	rpcRes, err := call(t, "eth_getTransactionReceipt", param)
 	require.NoError(t, err)
 
-	t.Log(rpcRes.Result)
-	// TODO: why does this not return a receipt?
+	var receipt map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &receipt)
+	require.NoError(t, err)
+	require.Equal(t, "0x1", receipt["status"])
+	require.NotNil(t, receipt["contractAddress"])
+	require.Nil(t, receipt["to"])
+	require.Len(t, receipt["logs"], 1)
+
+	// require a null receipt for an unknown transaction
+	rpcRes, err = call(t, "eth_getTransactionReceipt", []string{common.Hash{}.Hex()})
+	require.NoError(t, err)
+
+	receipt = nil
+	err = json.Unmarshal(rpcRes.Result, &receipt)
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+}
+
+func TestEth_GetTransactionReceipt_Reverted(t *testing.T) {
+	from := getAddress(t)
+
+	// init code reverting with the ABI encoded Error("estimation reverted"), the
+	// gas being set as the estimation of a reverted execution fails
+	param := []map[string]string{{
+		"from": "0x" + fmt.Sprintf("%x", from),
+		"gas":  "0x186a0",
+		"data": "0x6064600c60003960646000fd08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000013657374696d6174696f6e20726576657274656400000000000000000000000000",
+	}}
+
+	rpcRes, err := call(t, "eth_sendTransaction", param)
+	require.NoError(t, err)
+
+	var hash hexutil.Bytes
+	err = json.Unmarshal(rpcRes.Result, &hash)
+	require.NoError(t, err)
+
+	time.Sleep(time.Second * 2)
+
+	// the Ethereum hash of the transaction is read from the transaction
+	rpcRes, err = call(t, "eth_getTransactionByHash", []string{hash.String()})
+	require.NoError(t, err)
+
+	var tx map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &tx)
+	require.NoError(t, err)
+
+	// require the reverted transaction to have a failed receipt
+	rpcRes, err = call(t, "eth_getTransactionReceipt", []interface{}{tx["hash"]})
+	require.NoError(t, err)
+
+	var receipt map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &receipt)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Equal(t, "0x0", receipt["status"])
+	require.Equal(t, tx["hash"], receipt["transactionHash"])
+	require.Len(t, receipt["logs"], 0)
+}
+
+func TestDebug_TraceTransaction(t *testing.T) {
+	hash := deployTestContract(t)
+
+	time.Sleep(time.Second * 2)
+
+	param := []interface{}{hash.String(), map[string]bool{"disableMemory": true}}
+	rpcRes, err := call(t, "debug_traceTransaction", param)
+	require.NoError(t, err)
+
+	var res map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &res)
+	require.NoError(t, err)
+
+	require.Equal(t, false, res["failed"])
+	structLogs, ok := res["structLogs"].([]interface{})
+	require.True(t, ok)
+	require.NotEmpty(t, structLogs)
+
+	step := structLogs[0].(map[string]interface{})
+	require.Equal(t, "PUSH1", step["op"])
+	require.Nil(t, step["memory"])
 }
 
 func TestEth_GetTxLogs(t *testing.T) {
@@ -547,3 +800,328 @@ func TestEth_NewPendingTransactionFilter(t *testing.T) {
 	require.True(t, len(txs) >= 2, "could not get any txs", "changesRes.Result", string(changesRes.Result))
 
 }
+
+func dialWebsocket(t *testing.T) *rpc.Client {
+	if ETHERMINT_WS_HOST == "" {
+		t.Skip("ETHERMINT_WS_HOST is not defined")
+	}
+
+	client, err := rpc.DialWebsocket(context.Background(), ETHERMINT_WS_HOST, "")
+	require.NoError(t, err)
+	return client
+}
+
+func TestEth_Subscribe_NewHeads(t *testing.T) {
+	client := dialWebsocket(t)
+	defer client.Close()
+
+	heads := make(chan map[string]interface{})
+	sub, err := client.EthSubscribe(context.Background(), heads, "newHeads")
+	require.NoError(t, err)
+
+	select {
+	case head := <-heads:
+		require.NotEmpty(t, head["hash"])
+		require.NotEmpty(t, head["number"])
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no header received")
+	}
+
+	// require no header to be received once unsubscribed
+	sub.Unsubscribe()
+	select {
+	case <-heads:
+		t.Fatal("header received after unsubscribing")
+	case <-time.After(3 * time.Second):
+	}
+}
+
+func TestEth_Subscribe_Logs(t *testing.T) {
+	client := dialWebsocket(t)
+	defer client.Close()
+
+	// topic of the event emitted by the test contract deployment
+	topic := common.HexToHash("0x775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd738898")
+	criteria := map[string]interface{}{"topics": [][]common.Hash{{topic}}}
+
+	logs := make(chan ethtypes.Log)
+	sub, err := client.EthSubscribe(context.Background(), logs, "logs", criteria)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	deployTestContract(t)
+
+	select {
+	case log := <-logs:
+		require.Equal(t, topic, log.Topics[0])
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("no log received")
+	}
+}
+
+func TestEth_gasPrice(t *testing.T) {
+	rpcRes, err := call(t, "eth_gasPrice", []string{})
+	require.NoError(t, err)
+
+	var gasPrice hexutil.Big
+	err = gasPrice.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+	require.True(t, gasPrice.ToInt().Sign() >= 0)
+}
+
+func TestEth_FeeHistory(t *testing.T) {
+	// send a transaction so that the history has a block with a reward
+	deployTestContract(t)
+
+	rpcRes, err := call(t, "eth_feeHistory", []interface{}{"0x4", "latest", []float64{25, 75}})
+	require.NoError(t, err)
+
+	var history struct {
+		OldestBlock  hexutil.Big     `json:"oldestBlock"`
+		Reward       [][]hexutil.Big `json:"reward"`
+		BaseFee      []hexutil.Big   `json:"baseFeePerGas"`
+		GasUsedRatio []float64       `json:"gasUsedRatio"`
+	}
+	err = json.Unmarshal(rpcRes.Result, &history)
+	require.NoError(t, err)
+
+	blocks := len(history.GasUsedRatio)
+	require.True(t, blocks > 0 && blocks <= 4)
+	require.True(t, history.OldestBlock.ToInt().Sign() > 0)
+	require.Len(t, history.Reward, blocks)
+	require.Len(t, history.BaseFee, blocks+1)
+
+	// the base fee doesn't change without a block gas limit
+	for _, baseFee := range history.BaseFee {
+		require.Equal(t, 0, baseFee.ToInt().Sign())
+	}
+
+	for i, rewards := range history.Reward {
+		require.Len(t, rewards, 2)
+		require.True(t, rewards[0].ToInt().Cmp(rewards[1].ToInt()) <= 0)
+		require.True(t, history.GasUsedRatio[i] >= 0)
+	}
+
+	// require an error for percentiles that are not in ascending order
+	_, err = call(t, "eth_feeHistory", []interface{}{"0x4", "latest", []float64{75, 25}})
+	require.Error(t, err)
+}
+
+func getTransactionCount(t *testing.T, addr hexutil.Bytes, tag string) uint64 {
+	rpcRes, err := call(t, "eth_getTransactionCount", []string{addr.String(), tag})
+	require.NoError(t, err)
+
+	var nonce hexutil.Uint64
+	err = nonce.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+	return uint64(nonce)
+}
+
+func TestEth_GetTransactionCount_Pending(t *testing.T) {
+	from := hexutil.Bytes(getAddress(t))
+	latest := getTransactionCount(t, from, "latest")
+
+	param := make([]map[string]string, 1)
+	param[0] = make(map[string]string)
+	param[0]["from"] = from.String()
+	param[0]["to"] = "0x1122334455667788990011223344556677889900"
+	param[0]["value"] = "0x1"
+
+	for i := 0; i < 2; i++ {
+		_, err := call(t, "eth_sendTransaction", param)
+		require.NoError(t, err)
+	}
+
+	// the pending count includes the transactions of the pool
+	require.Equal(t, latest+2, getTransactionCount(t, from, "pending"))
+
+	// the latest count follows once the transactions are committed
+	for i := 0; i < 10; i++ {
+		count := getTransactionCount(t, from, "latest")
+		require.True(t, count <= latest+2, "latest count %d beyond the pending one", count)
+		if count == latest+2 {
+			return
+		}
+	}
+	t.Fatal("transactions not committed")
+}
+
+func TestEth_GetBlockByNumber_BlockTags(t *testing.T) {
+	for _, tag := range []string{"latest", "pending", "earliest"} {
+		rpcRes, err := call(t, "eth_getBlockByNumber", []interface{}{tag, false})
+		require.NoError(t, err, tag)
+
+		var block map[string]interface{}
+		err = json.Unmarshal(rpcRes.Result, &block)
+		require.NoError(t, err, tag)
+
+		// stable values of the fields without Tendermint analogue
+		require.Equal(t, "0x0000000000000000", block["nonce"], tag)
+		require.Equal(t, "0x0", block["difficulty"], tag)
+		require.Equal(t, []interface{}{}, block["uncles"], tag)
+	}
+
+	rpcRes, err := call(t, "eth_getBlockByNumber", []interface{}{"earliest", false})
+	require.NoError(t, err)
+
+	var block map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &block)
+	require.NoError(t, err)
+	require.Equal(t, "0x1", block["number"])
+}
+
+func TestEth_GetBlock_Transactions(t *testing.T) {
+	hash := deployTestContract(t)
+
+	time.Sleep(time.Second * 2)
+
+	rpcRes, err := call(t, "eth_getTransactionByHash", []string{hash.String()})
+	require.NoError(t, err)
+
+	var tx map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &tx)
+	require.NoError(t, err)
+	require.NotNil(t, tx["blockHash"])
+
+	// full transaction objects by number
+	rpcRes, err = call(t, "eth_getBlockByNumber", []interface{}{tx["blockNumber"], true})
+	require.NoError(t, err)
+
+	var block map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &block)
+	require.NoError(t, err)
+	require.Equal(t, tx["blockHash"], block["hash"])
+
+	txs, ok := block["transactions"].([]interface{})
+	require.True(t, ok)
+	require.Contains(t, txs, tx)
+
+	// transaction hashes by hash
+	rpcRes, err = call(t, "eth_getBlockByHash", []interface{}{tx["blockHash"], false})
+	require.NoError(t, err)
+
+	block = make(map[string]interface{})
+	err = json.Unmarshal(rpcRes.Result, &block)
+	require.NoError(t, err)
+	require.Equal(t, tx["blockNumber"], block["number"])
+	require.Contains(t, block["transactions"], tx["hash"])
+}
+
+func TestEth_GetBlockTransactionCount(t *testing.T) {
+	hash := deployTestContract(t)
+
+	time.Sleep(time.Second * 2)
+
+	rpcRes, err := call(t, "eth_getTransactionByHash", []string{hash.String()})
+	require.NoError(t, err)
+
+	var tx map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &tx)
+	require.NoError(t, err)
+	require.NotNil(t, tx["blockHash"])
+
+	rpcRes, err = call(t, "eth_getBlockTransactionCountByNumber", []interface{}{tx["blockNumber"]})
+	require.NoError(t, err)
+
+	var count hexutil.Uint
+	err = count.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+	require.True(t, count >= 1)
+
+	rpcRes, err = call(t, "eth_getBlockTransactionCountByHash", []interface{}{tx["blockHash"]})
+	require.NoError(t, err)
+
+	var countByHash hexutil.Uint
+	err = countByHash.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+	require.Equal(t, count, countByHash)
+
+	// unknown block
+	rpcRes, err = call(t, "eth_getBlockTransactionCountByHash", []interface{}{common.Hash{}.Hex()})
+	require.NoError(t, err)
+	require.Equal(t, "null", string(rpcRes.Result))
+}
+
+func TestEth_GetTransactionByBlockAndIndex(t *testing.T) {
+	hash := deployTestContract(t)
+
+	time.Sleep(time.Second * 2)
+
+	rpcRes, err := call(t, "eth_getTransactionByHash", []string{hash.String()})
+	require.NoError(t, err)
+
+	var tx map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &tx)
+	require.NoError(t, err)
+	require.NotNil(t, tx["blockHash"])
+
+	rpcRes, err = call(t, "eth_getBlockTransactionCountByHash", []interface{}{tx["blockHash"]})
+	require.NoError(t, err)
+
+	var count hexutil.Uint
+	err = count.UnmarshalJSON(rpcRes.Result)
+	require.NoError(t, err)
+
+	// the deployment is one of the Ethereum transactions of the block
+	var found bool
+	for i := hexutil.Uint(0); i < count; i++ {
+		rpcRes, err = call(t, "eth_getTransactionByBlockHashAndIndex", []interface{}{tx["blockHash"], i})
+		require.NoError(t, err)
+
+		var byHash map[string]interface{}
+		err = json.Unmarshal(rpcRes.Result, &byHash)
+		require.NoError(t, err)
+
+		rpcRes, err = call(t, "eth_getTransactionByBlockNumberAndIndex", []interface{}{tx["blockNumber"], i})
+		require.NoError(t, err)
+
+		var byNumber map[string]interface{}
+		err = json.Unmarshal(rpcRes.Result, &byNumber)
+		require.NoError(t, err)
+		require.Equal(t, byHash, byNumber)
+
+		if byHash["hash"] == tx["hash"] {
+			require.Equal(t, tx, byHash)
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	// out of range index
+	rpcRes, err = call(t, "eth_getTransactionByBlockNumberAndIndex", []interface{}{tx["blockNumber"], count})
+	require.NoError(t, err)
+	require.Equal(t, "null", string(rpcRes.Result))
+}
+
+func TestTxPool_StatusContent(t *testing.T) {
+	rpcRes, err := call(t, "txpool_status", []string{})
+	require.NoError(t, err)
+
+	var status map[string]hexutil.Uint
+	err = json.Unmarshal(rpcRes.Result, &status)
+	require.NoError(t, err)
+	require.Contains(t, status, "pending")
+	require.Contains(t, status, "queued")
+
+	rpcRes, err = call(t, "txpool_content", []string{})
+	require.NoError(t, err)
+
+	var content map[string]map[string]map[string]map[string]interface{}
+	err = json.Unmarshal(rpcRes.Result, &content)
+	require.NoError(t, err)
+	require.Contains(t, content, "pending")
+	require.Contains(t, content, "queued")
+
+	// the content holds the transactions of the pool by sender and nonce
+	for _, txs := range content["pending"] {
+		for nonce, tx := range txs {
+			txNonce, err := hexutil.DecodeUint64(tx["nonce"].(string))
+			require.NoError(t, err)
+			require.Equal(t, nonce, fmt.Sprint(txNonce))
+		}
+	}
+}
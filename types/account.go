@@ -50,22 +50,22 @@ func ProtoBaseAccount() exported.Account {
 	return &Account{BaseAccount: &auth.BaseAccount{}}
 }
 
-// Balance returns the balance of an account.
-func (acc Account) Balance() sdk.Int {
-	return acc.GetCoins().AmountOf(DenomDefault)
+// Balance returns the balance of an account in the given denomination.
+func (acc Account) Balance(denom string) sdk.Int {
+	return acc.GetCoins().AmountOf(denom)
 }
 
-// SetBalance sets an account's balance of photons
-func (acc Account) SetBalance(amt sdk.Int) {
+// SetBalance sets an account's balance in the given denomination
+func (acc Account) SetBalance(denom string, amt sdk.Int) {
 	coins := acc.GetCoins()
-	diff := amt.Sub(coins.AmountOf(DenomDefault))
+	diff := amt.Sub(coins.AmountOf(denom))
 	switch {
 	case diff.IsPositive():
 		// Increase coins to amount
-		coins = coins.Add(sdk.NewCoins(sdk.NewCoin(DenomDefault, diff)))
+		coins = coins.Add(sdk.NewCoins(sdk.NewCoin(denom, diff)))
 	case diff.IsNegative():
 		// Decrease coins to amount
-		coins = coins.Sub(sdk.NewCoins(sdk.NewCoin(DenomDefault, diff.Neg())))
+		coins = coins.Sub(sdk.NewCoins(sdk.NewCoin(denom, diff.Neg())))
 	default:
 		return
 	}
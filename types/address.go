@@ -0,0 +1,20 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// EthAddressFromCosmos returns the Ethereum address of a Cosmos account
+// address. Both encode the same 20 bytes, derived from the secp256k1 public key
+// as in Ethereum.
+func EthAddressFromCosmos(addr sdk.AccAddress) ethcmn.Address {
+	return ethcmn.BytesToAddress(addr.Bytes())
+}
+
+// CosmosFromEthAddress returns the Cosmos account address of an Ethereum
+// address, which is bech32 encoded with the account address prefix.
+func CosmosFromEthAddress(addr ethcmn.Address) sdk.AccAddress {
+	return sdk.AccAddress(addr.Bytes())
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	emintcrypto "github.com/cosmos/ethermint/crypto"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAddressConversion(t *testing.T) {
+	privkey, err := emintcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	cosmosAddr := sdk.AccAddress(privkey.PubKey().Address())
+	ethAddr := ethcrypto.PubkeyToAddress(privkey.ToECDSA().PublicKey)
+
+	// require the same key to yield matching addresses in both encodings
+	require.Equal(t, ethAddr, EthAddressFromCosmos(cosmosAddr))
+	require.Equal(t, cosmosAddr, CosmosFromEthAddress(ethAddr))
+
+	// require the conversions to round trip, including through bech32
+	require.Equal(t, cosmosAddr, CosmosFromEthAddress(EthAddressFromCosmos(cosmosAddr)))
+	require.Equal(t, ethAddr, EthAddressFromCosmos(CosmosFromEthAddress(ethAddr)))
+
+	bech32Addr, err := sdk.AccAddressFromBech32(cosmosAddr.String())
+	require.NoError(t, err)
+	require.Equal(t, ethAddr, EthAddressFromCosmos(bech32Addr))
+}
@@ -0,0 +1,35 @@
+package types
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// regexChainID matches a chain-id that is either a base-10 integer or an
+// identifier followed by a '-' or '_' separator and a trailing base-10 integer,
+// eg. "3" or "ethermint-7".
+var regexChainID = regexp.MustCompile(`^(?:[a-zA-Z0-9_.-]*[-_])?([0-9]+)$`)
+
+// ParseChainID parses the EIP-155 chain ID from the trailing integer component
+// of a Cosmos chain-id string (eg. "ethermint-7" -> 7). It returns an error
+// wrapping ErrInvalidChainID if the chain-id doesn't have a valid positive
+// trailing integer.
+func ParseChainID(chainID string) (*big.Int, error) {
+	chainID = strings.TrimSpace(chainID)
+
+	matches := regexChainID.FindStringSubmatch(chainID)
+	if matches == nil {
+		return nil, sdkerrors.Wrapf(ErrInvalidChainID, "%s: must have a trailing base-10 integer", chainID)
+	}
+
+	// a regular expression match always contains the integer group
+	chainIDInt, ok := new(big.Int).SetString(matches[1], 10)
+	if !ok || chainIDInt.Sign() <= 0 {
+		return nil, sdkerrors.Wrapf(ErrInvalidChainID, "%s: must be a positive integer", chainID)
+	}
+
+	return chainIDInt, nil
+}
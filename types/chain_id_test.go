@@ -0,0 +1,43 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseChainID(t *testing.T) {
+	testCases := []struct {
+		name     string
+		chainID  string
+		expError bool
+		expInt   *big.Int
+	}{
+		{"integer", "3", false, big.NewInt(3)},
+		{"dash separator", "ethermint-7", false, big.NewInt(7)},
+		{"underscore separator", "ethermint_8", false, big.NewInt(8)},
+		{"multiple separators", "my-test-chain-42", false, big.NewInt(42)},
+		{"large integer", "ethermint-1000000", false, big.NewInt(1000000)},
+		{"surrounding spaces", " ethermint-9 ", false, big.NewInt(9)},
+		{"empty", "", true, nil},
+		{"no integer", "ethermint", true, nil},
+		{"trailing separator", "ethermint-", true, nil},
+		{"no separator", "ethermint7", true, nil},
+		{"trailing letters", "ethermint-7a", true, nil},
+		{"zero", "ethermint-0", true, nil},
+		{"invalid characters", "ether mint-7", true, nil},
+	}
+
+	for _, tc := range testCases {
+		chainID, err := ParseChainID(tc.chainID)
+		if tc.expError {
+			require.Error(t, err, tc.name)
+			require.Nil(t, chainID, tc.name)
+			require.True(t, ErrInvalidChainID.Is(err), tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+			require.Equal(t, tc.expInt, chainID, tc.name)
+		}
+	}
+}
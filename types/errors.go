@@ -18,4 +18,60 @@ var (
 
 	// ErrVMExecution returns an error resulting from an error in EVM execution.
 	ErrVMExecution = sdkerrors.Register(RootCodespace, 3, "error while executing evm transaction")
+
+	// ErrInvalidSignature returns an error resulting from a malformed transaction
+	// signature.
+	ErrInvalidSignature = sdkerrors.Register(RootCodespace, 4, "invalid signature")
+
+	// ErrRecoveryFailed returns an error resulting from a failed public key
+	// recovery of a transaction signature.
+	ErrRecoveryFailed = sdkerrors.Register(RootCodespace, 5, "failed to recover signer")
+
+	// ErrExecutionReverted returns an error resulting from an EVM execution
+	// reverted by the REVERT opcode.
+	ErrExecutionReverted = sdkerrors.Register(RootCodespace, 6, "execution reverted")
+
+	// ErrBlockGasLimitExceeded returns an error resulting from a transaction
+	// which gas limit exceeds the gas left in the block.
+	ErrBlockGasLimitExceeded = sdkerrors.Register(RootCodespace, 7, "block gas limit exceeded")
+
+	// ErrNonceTooLow returns an error resulting from a transaction which nonce is
+	// lower than the nonce of the sender account.
+	ErrNonceTooLow = sdkerrors.Register(RootCodespace, 8, "nonce too low")
+
+	// ErrNonceTooHigh returns an error resulting from a transaction which nonce is
+	// higher than the nonce of the sender account.
+	ErrNonceTooHigh = sdkerrors.Register(RootCodespace, 9, "nonce too high")
+
+	// ErrContractAddressCollision returns an error resulting from a contract
+	// creation at an address which already has a nonce or code.
+	ErrContractAddressCollision = sdkerrors.Register(RootCodespace, 10, "contract address collision")
+
+	// ErrTxSizeExceeded returns an error resulting from a transaction which
+	// payload is larger than the MaxTxSize parameter.
+	ErrTxSizeExceeded = sdkerrors.Register(RootCodespace, 11, "max tx size exceeded")
+
+	// ErrCodeSizeExceeded returns an error resulting from a contract creation
+	// which deployed code is larger than the MaxCodeSize parameter.
+	ErrCodeSizeExceeded = sdkerrors.Register(RootCodespace, 12, "max code size exceeded")
+
+	// ErrStatePruned returns an error resulting from a query on the state of a
+	// block height which has been pruned.
+	ErrStatePruned = sdkerrors.Register(RootCodespace, 13, "state pruned")
+
+	// ErrCreateDisabled returns an error resulting from a contract creation while
+	// the EnableCreate parameter is disabled.
+	ErrCreateDisabled = sdkerrors.Register(RootCodespace, 14, "contract creation disabled")
+
+	// ErrCallDisabled returns an error resulting from a contract call while the
+	// EnableCall parameter is disabled.
+	ErrCallDisabled = sdkerrors.Register(RootCodespace, 15, "contract call disabled")
+
+	// ErrReplacementUnderpriced returns an error resulting from a transaction
+	// replacing a pending transaction without a sufficient gas price increase.
+	ErrReplacementUnderpriced = sdkerrors.Register(RootCodespace, 16, "replacement transaction underpriced")
+
+	// ErrTxReplaced returns an error resulting from the recheck of a pending
+	// transaction which has been replaced by a transaction with the same nonce.
+	ErrTxReplaced = sdkerrors.Register(RootCodespace, 17, "transaction replaced")
 )
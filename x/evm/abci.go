@@ -1,34 +1,57 @@
 package evm
 
 import (
-	"math/big"
-
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/ethermint/x/evm/types"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// BeginBlock sets the Bloom and Hash mappings and resets the Bloom filter and
-// the transaction count to 0.
+// BeginBlock sets the Hash mapping, records the hash of the previous block for
+// the BLOCKHASH opcode and resets the Bloom filter, the
+// transaction count and log index to 0, the pending nonces and the block gas
+// used. The block hash is set on the logs emitted in the block.
 func BeginBlock(k Keeper, ctx sdk.Context, req abci.RequestBeginBlock) {
 	// Consider removing this when using evm as module without web3 API
-	bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
-	err := k.SetBlockBloomMapping(ctx, bloom, req.Header.GetHeight()-1)
-	if err != nil {
-		panic(err)
-	}
 	k.SetBlockHashMapping(ctx, req.Header.LastBlockId.GetHash(), req.Header.GetHeight()-1)
-	k.Bloom = big.NewInt(0)
-	k.TxCount = 0
+	k.SetHeightHash(ctx, req.Header.GetHeight()-1, req.Header.LastBlockId.GetHash())
+	// the bloom is reset in place as it is shared by the keeper copies
+	k.Bloom.SetInt64(0)
+	k.ResetBlock(common.BytesToHash(req.Hash))
+	k.ResetPendingNonces()
+	k.ResetBlockGasUsed()
 }
 
-// EndBlock updates the accounts and commits states objects to the KV Store
+// EndBlock stores the block bloom and the base fee of the next block, updates
+// the accounts, commits states objects to the KV Store and stores the root of
+// the committed EVM state
 func EndBlock(k Keeper, ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	ctx = ctx.WithBlockGasMeter(sdk.NewInfiniteGasMeter())
 
+	// Store the bloom aggregated from the logs of the block transactions
+	// Consider removing this when using evm as module without web3 API
+	bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
+	if err := k.SetBlockBloomMapping(ctx, bloom, ctx.BlockHeight()); err != nil {
+		panic(err)
+	}
+
+	// Emit the bloom so that it can be included in the headers notified to the
+	// Web3 subscriptions
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeBlockBloom,
+			sdk.NewAttribute(types.AttributeKeyEthereumBloom, hexutil.Encode(bloom.Bytes())),
+		),
+	)
+
+	// Update the base fee of the next block from the gas used by the block
+	k.UpdateBaseFee(ctx)
+
 	// Update account balances before committing other parts of state
 	k.CommitStateDB.UpdateAccounts()
 
@@ -41,5 +64,9 @@ func EndBlock(k Keeper, ctx sdk.Context, _ abci.RequestEndBlock) []abci.Validato
 	// Clear accounts cache after account data has been committed
 	k.CommitStateDB.ClearStateObjects()
 
+	// Store the root of the committed EVM state for the Web3 blocks
+	stateRoot := k.CommitStateDB.WithContext(ctx).WithEvmDenom(k.GetEvmDenom(ctx)).StateRoot()
+	k.SetStateRoot(ctx, ctx.BlockHeight(), stateRoot)
+
 	return []abci.ValidatorUpdate{}
 }
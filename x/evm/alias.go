@@ -12,17 +12,32 @@ const (
 	CodeKey              = types.StoreKey
 	BlockKey             = types.BlockKey
 	RouterKey            = types.RouterKey
+	DefaultParamspace    = types.DefaultParamspace
 	QueryProtocolVersion = types.QueryProtocolVersion
 	QueryBalance         = types.QueryBalance
 	QueryBlockNumber     = types.QueryBlockNumber
 	QueryStorage         = types.QueryStorage
 	QueryCode            = types.QueryCode
 	QueryNonce           = types.QueryNonce
+	QueryPendingNonce    = types.QueryPendingNonce
 	QueryHashToHeight    = types.QueryHashToHeight
 	QueryTxLogs          = types.QueryTxLogs
 	QueryLogsBloom       = types.QueryLogsBloom
 	QueryLogs            = types.QueryLogs
 	QueryAccount         = types.QueryAccount
+	QueryFilterLogs      = types.QueryFilterLogs
+	QueryTxHash          = types.QueryTxHash
+	QueryTraceTx         = types.QueryTraceTx
+	QueryParams          = types.QueryParams
+	QueryTxReceipt       = types.QueryTxReceipt
+	QueryBaseFee         = types.QueryBaseFee
+	QueryStorageDump     = types.QueryStorageDump
+	QueryStateRoot       = types.QueryStateRoot
+	QueryTxsByRecipient  = types.QueryTxsByRecipient
+
+	DefaultMaxRecipientTxs = keeper.DefaultMaxRecipientTxs
+	DefaultMaxFilterBlocks = keeper.DefaultMaxFilterBlocks
+	DefaultMaxFilterLogs   = keeper.DefaultMaxFilterLogs
 )
 
 // nolint
@@ -36,4 +51,5 @@ type (
 	Keeper          = keeper.Keeper
 	QueryResAccount = types.QueryResAccount
 	GenesisState    = types.GenesisState
+	Params          = types.Params
 )
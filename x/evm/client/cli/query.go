@@ -11,6 +11,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
 
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
@@ -26,6 +27,9 @@ func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 	evmQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdGetStorageAt(moduleName, cdc),
 		GetCmdGetCode(moduleName, cdc),
+		GetCmdGetStorageDump(moduleName, cdc),
+		GetCmdGetAccount(moduleName, cdc),
+		GetCmdQueryParams(moduleName, cdc),
 	)...)
 	return evmQueryCmd
 }
@@ -59,6 +63,65 @@ func GetCmdGetStorageAt(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdGetStorageDump queries all the non-zero storage slots of an account
+func GetCmdGetStorageDump(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "storage-dump [account]",
+		Short: "Gets all the non-zero storage slots of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			account, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse account address")
+			}
+
+			res, _, err := cliCtx.Query(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryStorageDump, account))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryResStorageDump
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdGetAccount queries the EVM state summary of an account
+func GetCmdGetAccount(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "account [address]",
+		Short: "Gets the balance, nonce, code size and storage slot count of an account",
+		Long: `Gets the balance in the evm denomination, nonce, code size and storage slot count of an account,
+which is a contract if it has code and an EOA otherwise. The address is either a hex Ethereum address
+or a bech32 Cosmos address.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := cosmosAddressFromArg(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse account address")
+			}
+
+			res, _, err := cliCtx.Query(
+				fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryAccountSummary, emint.EthAddressFromCosmos(addr).Hex()))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryResAccountSummary
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
+
 // GetCmdGetCode queries the code field of a given address
 func GetCmdGetCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -86,3 +149,26 @@ func GetCmdGetCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdQueryParams queries the current parameters of the evm module
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Gets the current evm module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.Query(
+				fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParams))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return cliCtx.PrintOutput(out)
+		},
+	}
+}
@@ -2,28 +2,45 @@ package cli
 
 import (
 	"bufio"
+	"crypto/ecdsa"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	clientkeys "github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	emintcrypto "github.com/cosmos/ethermint/crypto"
 	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
+const (
+	flagNonce    = "nonce"
+	flagTo       = "to"
+	flagValue    = "value"
+	flagGasLimit = "gas-limit"
+	flagGasPrice = "gas-price"
+	flagData     = "data"
+)
+
 // GetTxCmd defines the CLI commands regarding evm module transactions
 func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 	evmTxCmd := &cobra.Command{
@@ -38,6 +55,10 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdGenTx(cdc),
 		GetCmdGenCreateTx(cdc),
 	)...)
+	evmTxCmd.AddCommand(
+		GetCmdDecodeRawTx(),
+		GetCmdSignRawTx(),
+	)
 
 	return evmTxCmd
 }
@@ -150,7 +171,7 @@ func GetCmdGenCreateTx(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			contractAddr := ethcrypto.CreateAddress(common.BytesToAddress(from.Bytes()), seq)
+			contractAddr := types.GetContractAddress(common.BytesToAddress(from.Bytes()), seq)
 			fmt.Printf(
 				"Contract will be deployed to: \nHex: %s\nCosmos Address: %s\n",
 				contractAddr.Hex(),
@@ -160,3 +181,262 @@ func GetCmdGenCreateTx(cdc *codec.Codec) *cobra.Command {
 		},
 	}
 }
+
+// GetCmdDecodeRawTx decodes a hex encoded raw Ethereum transaction offline
+func GetCmdDecodeRawTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decode-raw [raw transaction hex]",
+		Short: "decode a raw RLP encoded Ethereum transaction without querying a node",
+		Long: `Decode a raw RLP encoded Ethereum transaction and print its fields. The sender
+is recovered from the signature if the chain-id flag is set and the transaction is signed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			payload := args[0]
+			if !strings.HasPrefix(payload, "0x") {
+				payload = "0x" + payload
+			}
+
+			bz, err := hexutil.Decode(payload)
+			if err != nil {
+				return errors.Wrap(err, "invalid raw transaction hex")
+			}
+
+			// fallback to the chain-id of the client config
+			chainIDStr, _ := cmd.Flags().GetString(flags.FlagChainID)
+			if len(chainIDStr) == 0 {
+				chainIDStr = viper.GetString(flags.FlagChainID)
+			}
+
+			var chainID *big.Int
+			if len(chainIDStr) > 0 {
+				chainID, err = emint.ParseChainID(chainIDStr)
+				if err != nil {
+					return err
+				}
+			}
+
+			tx, err := decodeRawTx(bz, chainID)
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(tx, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flags.FlagChainID, "", "The chain ID used to recover the transaction sender")
+	return cmd
+}
+
+// decodedTx defines the fields of a decoded raw Ethereum transaction
+type decodedTx struct {
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Data     hexutil.Bytes   `json:"data"`
+	From     *common.Address `json:"from,omitempty"`
+}
+
+// decodeRawTx decodes a raw RLP encoded Ethereum transaction. The sender is
+// only recovered if the chain ID is not nil and the transaction is signed.
+func decodeRawTx(bz []byte, chainID *big.Int) (*decodedTx, error) {
+	var msg types.MsgEthereumTx
+	if err := rlp.DecodeBytes(bz, &msg); err != nil {
+		return nil, errors.Wrap(err, "failed to decode raw transaction")
+	}
+
+	tx := &decodedTx{
+		Nonce:    hexutil.Uint64(msg.Data.AccountNonce),
+		To:       msg.Data.Recipient,
+		Value:    (*hexutil.Big)(msg.Data.Amount),
+		Gas:      hexutil.Uint64(msg.Data.GasLimit),
+		GasPrice: (*hexutil.Big)(msg.Data.Price),
+		Data:     msg.Data.Payload,
+	}
+
+	unsigned := (msg.Data.R == nil || msg.Data.R.Sign() == 0) && (msg.Data.S == nil || msg.Data.S.Sign() == 0)
+	if chainID != nil && !unsigned {
+		from, err := msg.VerifySig(chainID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to recover the transaction sender")
+		}
+		tx.From = &from
+	}
+
+	return tx, nil
+}
+
+// GetCmdSignRawTx signs an Ethereum transaction offline and prints its raw RLP
+// encoding, which can be broadcasted through eth_sendRawTransaction
+func GetCmdSignRawTx() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign-raw",
+		Short: "sign an Ethereum transaction with a local key without querying a node",
+		Long: `Sign an Ethereum transaction with a key of the local keybase and print the raw RLP
+encoded transaction and its hash. The transaction creates a contract if the to flag is empty.`,
+		Args: cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return viper.BindPFlag(flags.FlagKeyringBackend, cmd.Flags().Lookup(flags.FlagKeyringBackend))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// fallback to the chain-id of the client config
+			chainIDStr, _ := cmd.Flags().GetString(flags.FlagChainID)
+			if len(chainIDStr) == 0 {
+				chainIDStr = viper.GetString(flags.FlagChainID)
+			}
+
+			chainID, err := emint.ParseChainID(chainIDStr)
+			if err != nil {
+				return err
+			}
+
+			msg, err := msgEthereumTxFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			keyName, _ := cmd.Flags().GetString(flags.FlagFrom)
+			if len(keyName) == 0 {
+				return fmt.Errorf("the %s flag must be set to the name of the signing key", flags.FlagFrom)
+			}
+
+			priv, err := exportEthPrivKey(cmd, keyName)
+			if err != nil {
+				return err
+			}
+
+			raw, err := signRawTx(&msg, chainID, priv)
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(signedTx{Raw: raw, Hash: msg.Hash()}, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint64(flagNonce, 0, "The nonce of the transaction")
+	cmd.Flags().String(flagTo, "", "The hex address of the recipient, empty for a contract creation")
+	cmd.Flags().String(flagValue, "0", "The amount of wei transferred to the recipient")
+	cmd.Flags().Uint64(flagGasLimit, flags.DefaultGasLimit, "The gas limit of the transaction")
+	cmd.Flags().String(flagGasPrice, strconv.Itoa(emint.DefaultGasPrice), "The gas price of the transaction in wei")
+	cmd.Flags().String(flagData, "", "The hex encoded payload of the transaction")
+	cmd.Flags().String(flags.FlagFrom, "", "Name of the key of the local keybase signing the transaction")
+	cmd.Flags().String(flags.FlagChainID, "", "The chain ID of the EIP-155 signature")
+	cmd.Flags().String(flags.FlagKeyringBackend, flags.DefaultKeyringBackend, "Select keyring's backend (os|file|test)")
+	return cmd
+}
+
+// signedTx defines the output of an offline signed Ethereum transaction
+type signedTx struct {
+	Raw  hexutil.Bytes `json:"raw"`
+	Hash common.Hash   `json:"hash"`
+}
+
+// msgEthereumTxFromFlags builds an unsigned Ethereum transaction from the
+// flags of the sign-raw command.
+func msgEthereumTxFromFlags(cmd *cobra.Command) (types.MsgEthereumTx, error) {
+	nonce, _ := cmd.Flags().GetUint64(flagNonce)
+	gasLimit, _ := cmd.Flags().GetUint64(flagGasLimit)
+	toStr, _ := cmd.Flags().GetString(flagTo)
+	valueStr, _ := cmd.Flags().GetString(flagValue)
+	gasPriceStr, _ := cmd.Flags().GetString(flagGasPrice)
+	dataStr, _ := cmd.Flags().GetString(flagData)
+
+	var to *common.Address
+	if len(toStr) > 0 {
+		if !common.IsHexAddress(toStr) {
+			return types.MsgEthereumTx{}, fmt.Errorf("invalid recipient hex address %s", toStr)
+		}
+		addr := common.HexToAddress(toStr)
+		to = &addr
+	}
+
+	// Ambiguously decode the amounts from any base
+	value, ok := new(big.Int).SetString(valueStr, 0)
+	if !ok || value.Sign() < 0 {
+		return types.MsgEthereumTx{}, fmt.Errorf("invalid value %s", valueStr)
+	}
+
+	gasPrice, ok := new(big.Int).SetString(gasPriceStr, 0)
+	if !ok || gasPrice.Sign() < 0 {
+		return types.MsgEthereumTx{}, fmt.Errorf("invalid gas price %s", gasPriceStr)
+	}
+
+	var data []byte
+	if len(dataStr) > 0 {
+		if !strings.HasPrefix(dataStr, "0x") {
+			dataStr = "0x" + dataStr
+		}
+
+		var err error
+		data, err = hexutil.Decode(dataStr)
+		if err != nil {
+			return types.MsgEthereumTx{}, errors.Wrap(err, "invalid data hex")
+		}
+	}
+
+	return types.NewMsgEthereumTx(nonce, to, value, gasLimit, gasPrice, data), nil
+}
+
+// exportEthPrivKey exports the Ethereum private key of the given key of the
+// local keybase. The key password is prompted for the file keyring backend.
+func exportEthPrivKey(cmd *cobra.Command, name string) (*ecdsa.PrivateKey, error) {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+
+	kb, err := clientkeys.NewKeyringFromHomeFlag(inBuf)
+	if err != nil {
+		return nil, err
+	}
+
+	passphrase := ""
+	if viper.GetString(flags.FlagKeyringBackend) == flags.KeyringBackendFile {
+		passphrase, err = input.GetPassword("Enter key password:", inBuf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	privKey, err := kb.ExportPrivateKeyObject(name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	emintKey, ok := privKey.(emintcrypto.PrivKeySecp256k1)
+	if !ok {
+		return nil, fmt.Errorf("invalid private key type, must be Ethereum key: %T", privKey)
+	}
+
+	return emintKey.ToECDSA(), nil
+}
+
+// signRawTx signs the transaction for the given chain ID and returns its raw
+// RLP encoding. The signature is verified to recover the address of the key
+// before the transaction is encoded.
+func signRawTx(msg *types.MsgEthereumTx, chainID *big.Int, priv *ecdsa.PrivateKey) ([]byte, error) {
+	msg.Sign(chainID, priv)
+
+	sender, err := msg.VerifySig(chainID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to verify the transaction signature")
+	}
+
+	if signer := ethcrypto.PubkeyToAddress(priv.PublicKey); sender != signer {
+		return nil, fmt.Errorf("recovered sender %s does not match the signer %s", sender.Hex(), signer.Hex())
+	}
+
+	return rlp.EncodeToBytes(msg)
+}
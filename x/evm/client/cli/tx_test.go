@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+func TestDecodeRawTx(t *testing.T) {
+	// unsigned transaction of TestMsgEthereumTxRLPDecode
+	raw := common.FromHex("E48080830186A0940000000000000000746573745F61646472657373808474657374808080")
+	addr := common.BytesToAddress([]byte("test_address"))
+
+	tx, err := decodeRawTx(raw, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), uint64(tx.Nonce))
+	require.Equal(t, &addr, tx.To)
+	require.Equal(t, uint64(100000), uint64(tx.Gas))
+	require.Equal(t, []byte("test"), []byte(tx.Data))
+	require.Nil(t, tx.From)
+
+	// require the sender of a signed transaction to be recovered
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	msg := types.NewMsgEthereumTx(5, &addr, big.NewInt(10), 21000, big.NewInt(2), nil)
+	msg.Sign(big.NewInt(3), priv)
+	raw, err = rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+
+	tx, err = decodeRawTx(raw, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), uint64(tx.Nonce))
+	require.Equal(t, big.NewInt(10), tx.Value.ToInt())
+	require.Equal(t, big.NewInt(2), tx.GasPrice.ToInt())
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), *tx.From)
+
+	// require the sender to not be recovered without a chain ID
+	tx, err = decodeRawTx(raw, nil)
+	require.NoError(t, err)
+	require.Nil(t, tx.From)
+
+	// require an error for a chain ID other than the signature one
+	_, err = decodeRawTx(raw, big.NewInt(4))
+	require.Error(t, err)
+
+	// require an error for malformed input
+	_, err = decodeRawTx([]byte{0xe4, 0x80}, big.NewInt(3))
+	require.Error(t, err)
+	_, err = decodeRawTx(nil, big.NewInt(3))
+	require.Error(t, err)
+}
+
+func TestSignRawTx(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	addr := common.BytesToAddress([]byte("test_address"))
+	msg := types.NewMsgEthereumTx(7, &addr, big.NewInt(10), 21000, big.NewInt(2), []byte("test"))
+
+	raw, err := signRawTx(&msg, big.NewInt(3), priv)
+	require.NoError(t, err)
+
+	// require the hash to be the one of the raw transaction
+	require.Equal(t, crypto.Keccak256Hash(raw), msg.Hash())
+
+	tx, err := decodeRawTx(raw, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), uint64(tx.Nonce))
+	require.Equal(t, &addr, tx.To)
+	require.Equal(t, big.NewInt(10), tx.Value.ToInt())
+	require.Equal(t, []byte("test"), []byte(tx.Data))
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), *tx.From)
+
+	// require the transactions signed for a zero chain ID to not be replay
+	// protected, hence to be valid for any chain ID
+	msg = types.NewMsgEthereumTxContract(0, big.NewInt(0), 21000, big.NewInt(2), nil)
+	raw, err = signRawTx(&msg, big.NewInt(0), priv)
+	require.NoError(t, err)
+
+	tx, err = decodeRawTx(raw, big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(priv.PublicKey), *tx.From)
+}
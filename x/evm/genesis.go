@@ -2,19 +2,73 @@ package evm
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	emint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // InitGenesis initializes genesis state based on exported genesis
 func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorUpdate {
+	k.SetParams(ctx, data.Params)
+
 	for _, record := range data.Accounts {
-		k.SetCode(ctx, record.Address, record.Code)
 		k.CreateGenesisAccount(ctx, record)
 	}
+
+	// persist the genesis accounts code and storage, which would otherwise only
+	// be committed at the end of the first block
+	if _, err := k.Commit(ctx, false); err != nil {
+		panic(err)
+	}
+	k.CommitStateDB.ClearStateObjects()
+
+	for _, bloom := range data.Blooms {
+		if err := k.SetBlockBloomMapping(ctx, bloom.Bloom, bloom.Height); err != nil {
+			panic(err)
+		}
+	}
 	return []abci.ValidatorUpdate{}
 }
 
-// ExportGenesis exports genesis state
-func ExportGenesis(ctx sdk.Context, _ Keeper) GenesisState {
-	return GenesisState{Accounts: nil}
+// ExportGenesis exports genesis state. Only the accounts with code or storage
+// are exported, the other accounts being exported by the auth module.
+func ExportGenesis(ctx sdk.Context, k Keeper, ak types.AccountKeeper) GenesisState {
+	var accounts []types.GenesisAccount
+	ak.IterateAccounts(ctx, func(account authexported.Account) bool {
+		emintAccount, ok := account.(*emint.Account)
+		if !ok {
+			return false
+		}
+
+		addr := ethcmn.BytesToAddress(emintAccount.GetAddress().Bytes())
+
+		storage, err := k.GetAccountStorage(ctx, addr)
+		if err != nil {
+			panic(err)
+		}
+
+		code := k.GetCode(ctx, addr)
+		if len(code) == 0 && len(storage) == 0 {
+			return false
+		}
+
+		accounts = append(accounts, types.GenesisAccount{
+			Address: addr.Hex(),
+			Balance: k.GetBalance(ctx, addr),
+			Code:    code,
+			Storage: storage,
+		})
+		return false
+	})
+
+	var blooms []types.BlockBloom
+	k.IterateBlockBlooms(ctx, func(height int64, bloom ethtypes.Bloom) bool {
+		blooms = append(blooms, types.BlockBloom{Height: height, Bloom: bloom})
+		return false
+	})
+
+	return GenesisState{Params: k.GetParams(ctx), Accounts: accounts, Blooms: blooms}
 }
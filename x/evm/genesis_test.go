@@ -0,0 +1,57 @@
+package evm_test
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/x/evm"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func (suite *EvmTestSuite) TestExportImportGenesis() {
+	contract := common.BytesToAddress([]byte("contract"))
+	code := common.FromHex("602a60005260206000f3")
+	key1, value1 := common.BytesToHash([]byte("key1")), common.BytesToHash([]byte("value1"))
+	key2, value2 := common.BytesToHash([]byte("key2")), common.BytesToHash([]byte("value2"))
+
+	k := suite.app.EvmKeeper
+	k.SetBalance(suite.ctx, contract, big.NewInt(100))
+	k.SetCode(suite.ctx, contract, code)
+	k.SetState(suite.ctx, contract, key1, value1)
+	k.SetState(suite.ctx, contract, key2, value2)
+
+	// account without code nor storage, which isn't exported
+	k.SetBalance(suite.ctx, common.BytesToAddress([]byte("account")), big.NewInt(5))
+
+	_, err := k.Commit(suite.ctx, false)
+	suite.Require().NoError(err)
+	k.CommitStateDB.ClearStateObjects()
+
+	genesis := evm.ExportGenesis(suite.ctx, k, suite.app.AccountKeeper)
+	suite.Require().NoError(types.ValidateGenesis(genesis))
+	suite.Require().Len(genesis.Accounts, 1)
+	suite.Require().Equal(contract.Hex(), genesis.Accounts[0].Address)
+	suite.Require().Len(genesis.Accounts[0].Storage, 2)
+
+	// require the genesis state to round trip through its JSON encoding
+	bz := types.ModuleCdc.MustMarshalJSON(genesis)
+	var decoded types.GenesisState
+	types.ModuleCdc.MustUnmarshalJSON(bz, &decoded)
+
+	newApp := app.Setup(false)
+	newCtx := newApp.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "3"})
+	evm.InitGenesis(newCtx, newApp.EvmKeeper, decoded)
+
+	newKeeper := newApp.EvmKeeper
+	suite.Require().Equal(code, newKeeper.GetCode(newCtx, contract))
+	suite.Require().Equal(value1, newKeeper.GetState(newCtx, contract, key1))
+	suite.Require().Equal(value2, newKeeper.GetState(newCtx, contract, key2))
+	suite.Require().Equal(big.NewInt(100), newKeeper.GetBalance(newCtx, contract))
+
+	// require the imported state to be exported identically
+	suite.Require().Equal(genesis.Accounts, evm.ExportGenesis(newCtx, newKeeper, newApp.AccountKeeper).Accounts)
+}
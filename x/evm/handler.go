@@ -1,9 +1,10 @@
 package evm
 
 import (
-	"math/big"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -32,58 +33,133 @@ func NewHandler(k Keeper) sdk.Handler {
 
 // HandleMsgEthereumTx handles an Ethereum specific tx
 func HandleMsgEthereumTx(ctx sdk.Context, k Keeper, msg types.MsgEthereumTx) sdk.Result {
-	// parse the chainID from a string to a base-10 integer
-	intChainID, ok := new(big.Int).SetString(ctx.ChainID(), 10)
+	// parse the EIP-155 chain ID from the chain-id string
+	intChainID, err := emint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	// retrieve the sender address recovered by the ante handler, or verify the
+	// signature if the transaction didn't go through it
+	sender, ok := types.SenderFromContext(ctx)
 	if !ok {
-		return sdk.ResultFromError(sdkerrors.Wrap(emint.ErrInvalidChainID, ctx.ChainID()))
+		sender, err = msg.VerifySig(intChainID)
+		if err != nil {
+			return sdk.ResultFromError(err)
+		}
 	}
 
-	// Verify signature and retrieve sender address
-	sender, err := msg.VerifySig(intChainID)
-	if err != nil {
+	if err := checkBlockGasLimit(ctx, k, msg.Data.GasLimit); err != nil {
 		return sdk.ResultFromError(err)
 	}
 
-	txHash := tmtypes.Tx(ctx.TxBytes()).Hash()
-	ethHash := common.BytesToHash(txHash)
+	ethHash := msg.Hash()
+	params := k.GetParams(ctx)
+
+	if err := params.ValidateTxSize(msg.Data.Payload); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	if err := params.ValidateRecipient(msg.Data.Recipient); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	if err := checkCreateCall(ctx, k, params, msg.Data.Recipient); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	if err := params.ValidateDeployer(sender, msg.Data.Recipient); err != nil {
+		return sdk.ResultFromError(err)
+	}
 
 	st := types.StateTransition{
-		Sender:       sender,
-		AccountNonce: msg.Data.AccountNonce,
-		Price:        msg.Data.Price,
-		GasLimit:     msg.Data.GasLimit,
-		Recipient:    msg.Data.Recipient,
-		Amount:       msg.Data.Amount,
-		Payload:      msg.Data.Payload,
-		Csdb:         k.CommitStateDB.WithContext(ctx),
-		ChainID:      intChainID,
-		THash:        &ethHash,
-		Simulate:     ctx.IsCheckTx(),
+		Sender:         sender,
+		AccountNonce:   msg.Data.AccountNonce,
+		Price:          msg.GasPrice(),
+		GasLimit:       msg.Data.GasLimit,
+		Recipient:      msg.Data.Recipient,
+		Amount:         msg.Data.Amount,
+		Payload:        msg.Data.Payload,
+		AccessList:     msg.Data.Accesses,
+		Csdb:           k.CommitStateDB.WithContext(ctx).WithCoinDecimals(params.CoinDecimals).WithEvmDenom(params.EvmDenom),
+		ChainID:        intChainID,
+		THash:          &ethHash,
+		Hardfork:       params.Hardfork,
+		ChainConfig:    &params.ChainConfig,
+		MaxCodeSize:    params.MaxCodeSize,
+		ExtraEIPs:      params.ExtraEIPs,
+		GetHashFn:      k.GetHashFn(ctx),
+		Simulate:       ctx.IsCheckTx(),
 	}
 
 	// Prepare db for logs
-	// TODO: block hash
-	k.CommitStateDB.Prepare(ethHash, common.Hash{}, k.TxCount)
-	k.TxCount++
+	txIndex := prepareTx(k, ethHash)
 
 	// TODO: move to keeper
 	returnData, err := st.TransitionCSDB(ctx)
+	addBlockGasUsed(ctx, k)
 	if err != nil {
-		return sdk.ResultFromError(err)
+		return transitionErrorResult(err, returnData)
+	}
+
+	// a reverted execution fails the simulations, eg. of eth_call, while it is
+	// delivered as a completed transaction with a failed receipt
+	if returnData.Reverted && st.Simulate {
+		return transitionErrorResult(returnData.Err, returnData)
 	}
 
 	// update block bloom filter
 	k.Bloom.Or(k.Bloom, returnData.Bloom)
 
+	// track the next nonce of the sender for the remaining of the block
+	k.SetPendingNonce(sender, msg.Data.AccountNonce+1)
+
+	// the receipt and the indexes of the transaction are stored without consuming
+	// gas, so that the gas meter reflects the gas used by the receipt
+	receipt := newTxReceipt(ctx, msg, sender, ethHash, returnData, uint64(txIndex))
+	infCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
 	// update transaction logs in KVStore
-	err = k.SetTransactionLogs(ctx, returnData.Logs, txHash[:])
+	err = k.SetTransactionLogs(infCtx, returnData.Logs, ethHash.Bytes())
 	if err != nil {
 		return sdk.ResultFromError(err)
 	}
 
+	// map the Ethereum transaction hash to the Tendermint one for the web3 API
+	k.SetTxHashMapping(infCtx, ethHash, tmtypes.Tx(ctx.TxBytes()).Hash())
+
+	// store the Ethereum compatible receipt of the transaction
+	if err = k.SetTxReceipt(infCtx, receipt, ethHash.Bytes()); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	// index the transaction by recipient, the contract creations by the address
+	// of the created contract
+	recipient := receipt.To
+	if recipient == nil {
+		recipient = receipt.ContractAddress
+	}
+	k.SetRecipientTx(infCtx, *recipient, ctx.BlockHeight(), receipt.TxIndex, ethHash.Bytes())
+
+	// refund the fee of the gas left at the end of the state transition and burn
+	// the base fee of the gas used unless disabled by the params, the tip
+	// remaining in the fee collector. The settlement doesn't consume gas either.
+	baseFee := k.GetBaseFee(infCtx)
+	if receipt.GasUsed < msg.Data.GasLimit {
+		leftoverGas := msg.Data.GasLimit - receipt.GasUsed
+		if err := k.RefundGas(infCtx, sender, leftoverGas, msg.EffectiveGasPrice(baseFee)); err != nil {
+			return sdk.ResultFromError(err)
+		}
+	}
+
+	if err := k.BurnBaseFee(infCtx, receipt.GasUsed, baseFee); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeEthereumTx,
+			sdk.NewAttribute(types.AttributeKeyTxHash, msg.Hash().Hex()),
 			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Data.Amount.String()),
 		),
 		sdk.NewEvent(
@@ -102,33 +178,94 @@ func HandleMsgEthereumTx(ctx sdk.Context, k Keeper, msg types.MsgEthereumTx) sdk
 		)
 	}
 
-	// set the events to the result
+	// surface the EVM logs as SDK events for the Cosmos indexers
+	ctx.EventManager().EmitEvents(txLogEvents(returnData.Logs))
+
+	// set the events to the result, and the revert reason to its log
 	returnData.Result.Events = ctx.EventManager().Events()
+	if returnData.Reverted {
+		returnData.Result.Log = returnData.Err.Error()
+	}
 	return *returnData.Result
 }
 
+// newTxReceipt builds the Ethereum compatible receipt of an executed
+// MsgEthereumTx. The cumulative gas used is computed from the gas consumed by
+// the previous transactions in the block and the gas used by this transaction.
+func newTxReceipt(
+	ctx sdk.Context, msg types.MsgEthereumTx, sender common.Address, hash common.Hash,
+	returnData *types.ReturnData, txIndex uint64,
+) types.TxReceipt {
+	gasUsed := ctx.GasMeter().GasConsumed()
+
+	cumulativeGasUsed := gasUsed
+	if ctx.BlockGasMeter() != nil {
+		cumulativeGasUsed += ctx.BlockGasMeter().GasConsumed()
+	}
+
+	status := types.ReceiptStatusSuccessful
+	if returnData.Reverted {
+		status = types.ReceiptStatusFailed
+	}
+
+	receipt := types.TxReceipt{
+		Status:            status,
+		CumulativeGasUsed: cumulativeGasUsed,
+		GasUsed:           gasUsed,
+		Bloom:             ethtypes.BytesToBloom(returnData.Bloom.Bytes()),
+		Logs:              returnData.Logs,
+		TxHash:            hash,
+		From:              sender,
+		To:                msg.To(),
+		BlockHeight:       uint64(ctx.BlockHeight()),
+		TxIndex:           txIndex,
+	}
+
+	// the contract address is only set for contract creation transactions
+	if msg.To() == nil {
+		contractAddress := types.GetContractAddress(sender, msg.Data.AccountNonce)
+		receipt.ContractAddress = &contractAddress
+	}
+
+	return receipt
+}
+
 // HandleMsgEthermint handles a MsgEthermint
 func HandleMsgEthermint(ctx sdk.Context, k Keeper, msg types.MsgEthermint) sdk.Result {
-	// parse the chainID from a string to a base-10 integer
-	intChainID, ok := new(big.Int).SetString(ctx.ChainID(), 10)
-	if !ok {
-		return sdk.ResultFromError(sdkerrors.Wrap(emint.ErrInvalidChainID, ctx.ChainID()))
+	// parse the EIP-155 chain ID from the chain-id string
+	intChainID, err := emint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	if err := checkBlockGasLimit(ctx, k, msg.GasLimit); err != nil {
+		return sdk.ResultFromError(err)
 	}
 
 	txHash := tmtypes.Tx(ctx.TxBytes()).Hash()
 	ethHash := common.BytesToHash(txHash)
+	params := k.GetParams(ctx)
+
+	if err := params.ValidateTxSize(msg.Payload); err != nil {
+		return sdk.ResultFromError(err)
+	}
 
 	st := types.StateTransition{
-		Sender:       common.BytesToAddress(msg.From.Bytes()),
-		AccountNonce: msg.AccountNonce,
-		Price:        msg.Price.BigInt(),
-		GasLimit:     msg.GasLimit,
-		Amount:       msg.Amount.BigInt(),
-		Payload:      msg.Payload,
-		Csdb:         k.CommitStateDB.WithContext(ctx),
-		ChainID:      intChainID,
-		THash:        &ethHash,
-		Simulate:     ctx.IsCheckTx(),
+		Sender:         common.BytesToAddress(msg.From.Bytes()),
+		AccountNonce:   msg.AccountNonce,
+		Price:          msg.Price.BigInt(),
+		GasLimit:       msg.GasLimit,
+		Amount:         msg.Amount.BigInt(),
+		Payload:        msg.Payload,
+		Csdb:           k.CommitStateDB.WithContext(ctx).WithCoinDecimals(params.CoinDecimals).WithEvmDenom(params.EvmDenom),
+		ChainID:        intChainID,
+		THash:          &ethHash,
+		Hardfork:       params.Hardfork,
+		ChainConfig:    &params.ChainConfig,
+		MaxCodeSize:    params.MaxCodeSize,
+		ExtraEIPs:      params.ExtraEIPs,
+		GetHashFn:      k.GetHashFn(ctx),
+		Simulate:       ctx.IsCheckTx(),
 	}
 
 	if msg.Recipient != nil {
@@ -136,13 +273,25 @@ func HandleMsgEthermint(ctx sdk.Context, k Keeper, msg types.MsgEthermint) sdk.R
 		st.Recipient = &to
 	}
 
+	if err := checkCreateCall(ctx, k, params, st.Recipient); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
+	if err := params.ValidateDeployer(st.Sender, st.Recipient); err != nil {
+		return sdk.ResultFromError(err)
+	}
+
 	// Prepare db for logs
-	k.CommitStateDB.Prepare(ethHash, common.Hash{}, k.TxCount)
-	k.TxCount++
+	prepareTx(k, ethHash)
 
 	returnData, err := st.TransitionCSDB(ctx)
+	addBlockGasUsed(ctx, k)
+	if err == nil && returnData.Reverted {
+		// the MsgEthermint have no receipt, so their reverted executions fail
+		err = returnData.Err
+	}
 	if err != nil {
-		return sdk.ResultFromError(err)
+		return transitionErrorResult(err, returnData)
 	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
@@ -170,3 +319,89 @@ func HandleMsgEthermint(ctx sdk.Context, k Keeper, msg types.MsgEthermint) sdk.R
 	returnData.Result.Events = ctx.EventManager().Events()
 	return *returnData.Result
 }
+
+// prepareTx sets the transaction hash, the block hash and the index of the
+// transaction in the block on the state DB, for the logs emitted by the
+// transaction, and returns the transaction index. The Ethereum transactions of
+// the block are counted by the app once delivered.
+func prepareTx(k Keeper, ethHash common.Hash) int {
+	txIndex := k.GetTxCount()
+	k.CommitStateDB.Prepare(ethHash, k.GetCurrentBlockHash(), txIndex)
+	return txIndex
+}
+
+// checkCreateCall returns an error if the EVM params disable the contract
+// creations, for a nil recipient, or the calls to the contracts, for a
+// recipient with code.
+func checkCreateCall(ctx sdk.Context, k Keeper, params types.Params, recipient *common.Address) error {
+	recipientIsContract := recipient != nil && k.GetCodeSize(ctx, *recipient) > 0
+	return params.ValidateCreateCall(recipient, recipientIsContract)
+}
+
+// checkBlockGasLimit returns an error if the gas limit of an EVM transaction
+// exceeds the gas left in the block. The gas used by the block is only tracked
+// for the delivered transactions, hence the transactions are only checked
+// against the block gas limit in CheckTx.
+func checkBlockGasLimit(ctx sdk.Context, k Keeper, gasLimit uint64) error {
+	blockGasLimit := k.GetBlockGasLimit(ctx)
+	if blockGasLimit == 0 {
+		return nil
+	}
+
+	var blockGasUsed uint64
+	if !ctx.IsCheckTx() {
+		blockGasUsed = k.GetBlockGasUsed()
+	}
+
+	var gasLeft uint64
+	if blockGasUsed < blockGasLimit {
+		gasLeft = blockGasLimit - blockGasUsed
+	}
+
+	if gasLimit > gasLeft {
+		return sdkerrors.Wrapf(
+			emint.ErrBlockGasLimitExceeded, "gas limit %d exceeds the gas left in the block %d (limit %d)",
+			gasLimit, gasLeft, blockGasLimit,
+		)
+	}
+
+	return nil
+}
+
+// addBlockGasUsed adds the gas consumed by a delivered EVM transaction, whether
+// it succeeded or not, to the gas used by the block.
+func addBlockGasUsed(ctx sdk.Context, k Keeper) {
+	if !ctx.IsCheckTx() {
+		k.AddBlockGasUsed(ctx.GasMeter().GasConsumed())
+	}
+}
+
+// transitionErrorResult returns the result of a failed state transition. The
+// result data of reverted executions is kept so that the revert payload is
+// returned to the caller.
+func transitionErrorResult(err error, returnData *types.ReturnData) sdk.Result {
+	res := sdk.ResultFromError(err)
+	if returnData != nil && returnData.Result != nil {
+		res.Data = returnData.Result.Data
+	}
+	return res
+}
+
+// txLogEvents returns a tx_log event for each log emitted by the EVM, with the
+// address of the emitting contract, the index of the log in the block and its
+// topics in order.
+func txLogEvents(logs []*ethtypes.Log) sdk.Events {
+	events := make(sdk.Events, len(logs))
+	for i, log := range logs {
+		attrs := []sdk.Attribute{
+			sdk.NewAttribute(types.AttributeKeyContractAddress, log.Address.Hex()),
+			sdk.NewAttribute(types.AttributeKeyLogIndex, strconv.FormatUint(uint64(log.Index), 10)),
+		}
+		for _, topic := range log.Topics {
+			attrs = append(attrs, sdk.NewAttribute(types.AttributeKeyTopic, topic.Hex()))
+		}
+
+		events[i] = sdk.NewEvent(types.EventTypeTxLog, attrs...)
+	}
+	return events
+}
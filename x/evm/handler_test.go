@@ -10,16 +10,22 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/cosmos/ethermint/app"
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm"
 	"github.com/cosmos/ethermint/x/evm/keeper"
 	"github.com/cosmos/ethermint/x/evm/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 type EvmTestSuite struct {
@@ -40,6 +46,12 @@ func (suite *EvmTestSuite) SetupTest() {
 	suite.handler = evm.NewHandler(suite.app.EvmKeeper)
 	suite.querier = keeper.NewQuerier(suite.app.EvmKeeper)
 	suite.codec = codec.New()
+
+	// fund the fee collector to refund the unused gas, as the fees are deducted
+	// by the ante handler
+	fees := sdk.NewCoins(sdk.NewCoin(emint.DenomDefault, sdk.NewInt(1000000000000000000)))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, fees))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToModule(suite.ctx, mint.ModuleName, auth.FeeCollectorName, fees))
 }
 
 func TestEvmTestSuite(t *testing.T) {
@@ -95,6 +107,141 @@ func (suite *EvmTestSuite) TestHandler_Logs() {
 	suite.Require().Equal(logs, resultData.Logs)
 }
 
+func (suite *EvmTestSuite) TestHandler_Events() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(1000000)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// attributes of the events of the given type, in emission order
+	attributes := func(events sdk.Events, eventType string) [][2]string {
+		var attrs [][2]string
+		for _, event := range events {
+			if event.Type != eventType {
+				continue
+			}
+			for _, attr := range event.Attributes {
+				attrs = append(attrs, [2]string{string(attr.Key), string(attr.Value)})
+			}
+		}
+		return attrs
+	}
+
+	// contract emitting the Hello(17) event in its constructor, see TestHandler_Logs
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+	tx := types.NewMsgEthereumTx(1, nil, big.NewInt(0), gasLimit, gasPrice, bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Len(resultData.Logs, 1)
+
+	suite.Require().Equal([][2]string{
+		{types.AttributeKeyTxHash, tx.Hash().Hex()},
+		{sdk.AttributeKeyAmount, "0"},
+	}, attributes(result.Events, types.EventTypeEthereumTx))
+
+	log := resultData.Logs[0]
+	suite.Require().Equal([][2]string{
+		{types.AttributeKeyContractAddress, resultData.Address.Hex()},
+		{types.AttributeKeyLogIndex, "0"},
+		{types.AttributeKeyTopic, log.Topics[0].Hex()},
+		{types.AttributeKeyTopic, common.BigToHash(big.NewInt(17)).Hex()},
+	}, attributes(result.Events, types.EventTypeTxLog))
+
+	// require a transfer to emit the recipient and no log event, the tx bytes
+	// setting a distinct transaction hash for the logs
+	recipient := common.BytesToAddress([]byte("recipient"))
+	tx = types.NewMsgEthereumTx(2, &recipient, big.NewInt(0), gasLimit, gasPrice, nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).WithTxBytes([]byte("transfer")), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	suite.Require().Equal([][2]string{
+		{types.AttributeKeyTxHash, tx.Hash().Hex()},
+		{sdk.AttributeKeyAmount, "0"},
+		{types.AttributeKeyRecipient, recipient.String()},
+	}, attributes(result.Events, types.EventTypeEthereumTx))
+	suite.Require().Empty(attributes(result.Events, types.EventTypeTxLog))
+}
+
+func (suite *EvmTestSuite) TestHandler_LogIndexes() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(1000000)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// contract emitting the Hello(17) event in its constructor, see TestHandler_Logs
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+
+	nonce := uint64(0)
+	deliver := func(ctx sdk.Context) *ethtypes.Log {
+		tx := types.NewMsgEthereumTx(nonce, nil, big.NewInt(0), gasLimit, gasPrice, bytecode)
+		tx.Sign(big.NewInt(3), priv)
+		nonce++
+
+		// the gas meter is set per transaction as done by the ante handler
+		txCtx := ctx.WithTxBytes([]byte(fmt.Sprintf("tx %d", nonce))).WithGasMeter(sdk.NewInfiniteGasMeter())
+		result := suite.handler(txCtx, tx)
+		suite.Require().True(result.IsOK(), result.Log)
+
+		// the delivered Ethereum transactions are counted by the app
+		suite.app.EvmKeeper.IncrementTxCount()
+
+		resultData, err := types.DecodeResultData(result.Data)
+		suite.Require().NoError(err, "failed to decode result data")
+
+		logs, err := suite.app.EvmKeeper.GetTransactionLogs(ctx, resultData.TxHash.Bytes())
+		suite.Require().NoError(err, "failed to get logs")
+		suite.Require().Len(logs, 1)
+		suite.Require().Equal(resultData.Logs, logs)
+
+		receipt, err := suite.app.EvmKeeper.GetTxReceipt(ctx, resultData.TxHash.Bytes())
+		suite.Require().NoError(err, "failed to get receipt")
+		suite.Require().Equal(uint64(logs[0].TxIndex), receipt.TxIndex)
+
+		return logs[0]
+	}
+
+	blockHash1 := common.BytesToHash([]byte("block 1"))
+	evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Hash: blockHash1.Bytes(), Header: abci.Header{Height: 1}})
+
+	for i := uint(0); i < 2; i++ {
+		log := deliver(suite.ctx)
+		suite.Require().Equal(i, log.TxIndex)
+		suite.Require().Equal(i, log.Index)
+		suite.Require().Equal(blockHash1, log.BlockHash)
+		suite.Require().Equal(uint64(1), log.BlockNumber)
+	}
+
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{Height: 1})
+
+	// require the indexes to be relative to the block
+	ctx2 := suite.ctx.WithBlockHeight(2)
+	blockHash2 := common.BytesToHash([]byte("block 2"))
+	evm.BeginBlock(suite.app.EvmKeeper, ctx2, abci.RequestBeginBlock{Hash: blockHash2.Bytes(), Header: abci.Header{Height: 2}})
+
+	log := deliver(ctx2)
+	suite.Require().Equal(uint(0), log.TxIndex)
+	suite.Require().Equal(uint(0), log.Index)
+	suite.Require().Equal(blockHash2, log.BlockHash)
+	suite.Require().Equal(uint64(2), log.BlockNumber)
+
+	// require the transactions checked in the mempool to not be counted
+	recipient := common.BytesToAddress([]byte("recipient"))
+	tx := types.NewMsgEthereumTx(nonce, &recipient, big.NewInt(0), gasLimit, gasPrice, nil)
+	tx.Sign(big.NewInt(3), priv)
+	result := suite.handler(ctx2.WithIsCheckTx(true).WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Equal(1, suite.app.EvmKeeper.GetTxCount())
+}
+
 func (suite *EvmTestSuite) TestQueryTxLogs() {
 	gasLimit := uint64(100000)
 	gasPrice := big.NewInt(1000000)
@@ -139,3 +286,1121 @@ func (suite *EvmTestSuite) TestQueryTxLogs() {
 	resultData.Logs[0].Data = []byte{}
 	suite.Require().Equal(txLogs.Logs[0], resultData.Logs[0])
 }
+
+func (suite *EvmTestSuite) TestQueryTxHash() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	recipient := common.BytesToAddress([]byte("recipient"))
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), 100000, big.NewInt(1000000), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	txBytes := []byte("query tx hash")
+	result := suite.handler(suite.ctx.WithTxBytes(txBytes), tx)
+	suite.Require().True(result.IsOK())
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal(tx.Hash(), resultData.TxHash)
+
+	// query the Tendermint transaction hash from the Ethereum one
+	path := []string{types.QueryTxHash, tx.Hash().Hex()}
+	res, err := suite.querier(suite.ctx, path, abci.RequestQuery{})
+	suite.Require().NoError(err, "failed to query txHash")
+
+	var out types.QueryResTxHash
+	suite.codec.MustUnmarshalJSON(res, &out)
+	suite.Require().Equal(tmtypes.Tx(txBytes).Hash(), out.Hash)
+
+	// require unknown transactions to not be found
+	path = []string{types.QueryTxHash, common.BytesToHash([]byte("unknown")).Hex()}
+	_, err = suite.querier(suite.ctx, path, abci.RequestQuery{})
+	suite.Require().Error(err)
+}
+
+func (suite *EvmTestSuite) TestHandler_TxReceipt() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(1000000)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	// send contract deployment transaction with an event in the constructor
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+	tx := types.NewMsgEthereumTx(1, nil, big.NewInt(0), gasLimit, gasPrice, bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK())
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+
+	suite.Require().Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	suite.Require().Equal(resultData.TxHash, receipt.TxHash)
+	suite.Require().Equal(sender, receipt.From)
+	suite.Require().Nil(receipt.To)
+	suite.Require().Equal(uint64(1), receipt.BlockHeight)
+	suite.Require().Len(receipt.Logs, 1)
+	suite.Require().Equal(resultData.Bloom, receipt.Bloom)
+	suite.Require().True(receipt.CumulativeGasUsed >= receipt.GasUsed)
+
+	contractAddress := crypto.CreateAddress(sender, 1)
+	suite.Require().NotNil(receipt.ContractAddress)
+	suite.Require().Equal(contractAddress, *receipt.ContractAddress)
+	suite.Require().Equal(resultData.Address, *receipt.ContractAddress)
+
+	// require the contract address to not be set for regular transfers
+	recipient := common.BytesToAddress([]byte("recipient"))
+	tx = types.NewMsgEthereumTx(2, &recipient, big.NewInt(0), gasLimit, gasPrice, nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK())
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err = suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+	suite.Require().Nil(receipt.ContractAddress)
+	suite.Require().Equal(&recipient, receipt.To)
+
+	// require the transaction to be indexed by recipient
+	receipts, err := suite.app.EvmKeeper.GetTxsByRecipient(suite.ctx, recipient, 1, 1)
+	suite.Require().NoError(err)
+	suite.Require().Equal([]types.TxReceipt{receipt}, receipts)
+}
+
+func (suite *EvmTestSuite) TestHandler_PendingNonce() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(1000000)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	suite.Require().Equal(uint64(0), suite.app.EvmKeeper.GetPendingNonce(suite.ctx, sender))
+
+	// send sequential transactions from the same account within the block
+	for i := uint64(0); i < 3; i++ {
+		tx := types.NewMsgEthereumTx(i, &recipient, big.NewInt(0), gasLimit, gasPrice, nil)
+		tx.Sign(big.NewInt(3), priv)
+
+		result := suite.handler(suite.ctx, tx)
+		suite.Require().True(result.IsOK(), result.Log)
+		suite.Require().Equal(i+1, suite.app.EvmKeeper.GetPendingNonce(suite.ctx, sender))
+	}
+
+	// require the account nonce to not be modified by the pending nonces
+	committed := suite.app.EvmKeeper.GetNonce(suite.ctx, sender)
+	suite.Require().NotEqual(committed, suite.app.EvmKeeper.GetPendingNonce(suite.ctx, sender))
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryPendingNonce, sender.Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResNonce
+	suite.codec.MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal(uint64(3), res.Nonce)
+
+	// require the pending nonces to be reset at the block boundary
+	evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	suite.Require().Equal(committed, suite.app.EvmKeeper.GetPendingNonce(suite.ctx, sender))
+}
+
+func (suite *EvmTestSuite) TestBlockBloom() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(1000000)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// contract emitting the Hello(17) event in its constructor, see TestHandler_Logs
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+	helloTopic := common.HexToHash("0x775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd738898")
+
+	// block 1 emits the event
+	evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), gasLimit, gasPrice, bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{Height: 1})
+
+	// block 2 has no transactions
+	ctx2 := suite.ctx.WithBlockHeight(2)
+	evm.BeginBlock(suite.app.EvmKeeper, ctx2, abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	evm.EndBlock(suite.app.EvmKeeper, ctx2, abci.RequestEndBlock{Height: 2})
+
+	bloom1, err := suite.app.EvmKeeper.GetBlockBloomMapping(suite.ctx, 1)
+	suite.Require().NoError(err)
+	suite.Require().True(ethtypes.BloomLookup(bloom1, helloTopic))
+
+	bloom2, err := suite.app.EvmKeeper.GetBlockBloomMapping(suite.ctx, 2)
+	suite.Require().NoError(err)
+	suite.Require().False(ethtypes.BloomLookup(bloom2, helloTopic))
+
+	// require the blooms to be exported and imported with the genesis state
+	genesis := evm.ExportGenesis(suite.ctx, suite.app.EvmKeeper, suite.app.AccountKeeper)
+	suite.Require().Equal([]types.BlockBloom{{Height: 1, Bloom: bloom1}, {Height: 2, Bloom: bloom2}}, genesis.Blooms)
+	suite.Require().NoError(types.ValidateGenesis(genesis))
+
+	newApp := app.Setup(false)
+	newCtx := newApp.BaseApp.NewContext(false, abci.Header{Height: 1, ChainID: "3"})
+	evm.InitGenesis(newCtx, newApp.EvmKeeper, genesis)
+
+	bloom, err := newApp.EvmKeeper.GetBlockBloomMapping(newCtx, 1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(bloom1, bloom)
+}
+
+func (suite *EvmTestSuite) TestStateRoot() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// PUSH1 0x01 PUSH1 0x00 SSTORE
+	bytecode := common.FromHex("0x6001600055")
+
+	// blocks 1 and 2 have no transactions
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{Height: 1})
+	ctx2 := suite.ctx.WithBlockHeight(2)
+	evm.EndBlock(suite.app.EvmKeeper, ctx2, abci.RequestEndBlock{Height: 2})
+
+	root1, err := suite.app.EvmKeeper.GetStateRoot(suite.ctx, 1)
+	suite.Require().NoError(err)
+	root2, err := suite.app.EvmKeeper.GetStateRoot(suite.ctx, 2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(root1, root2)
+
+	// block 3 deploys a contract writing to its storage
+	ctx3 := suite.ctx.WithBlockHeight(3).WithGasMeter(sdk.NewInfiniteGasMeter())
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(ctx3, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	evm.EndBlock(suite.app.EvmKeeper, ctx3, abci.RequestEndBlock{Height: 3})
+
+	root3, err := suite.app.EvmKeeper.GetStateRoot(suite.ctx, 3)
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(root2, root3)
+
+	// block 4 has no transactions
+	ctx4 := suite.ctx.WithBlockHeight(4)
+	evm.EndBlock(suite.app.EvmKeeper, ctx4, abci.RequestEndBlock{Height: 4})
+
+	root4, err := suite.app.EvmKeeper.GetStateRoot(suite.ctx, 4)
+	suite.Require().NoError(err)
+	suite.Require().Equal(root3, root4)
+
+	_, err = suite.app.EvmKeeper.GetStateRoot(suite.ctx, 5)
+	suite.Require().Error(err)
+
+	// require the root to be returned by the state root query
+	bz, err := suite.querier(suite.ctx, []string{types.QueryStateRoot, "3"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResStateRoot
+	suite.codec.MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal(root3.Hex(), res.Root)
+}
+
+func (suite *EvmTestSuite) TestHandler_RevertReason() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// abi.encodeWithSignature("Error(string)", "insufficient balance")
+	revertPayload := common.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000")
+
+	// init code that copies the payload appended to it into memory and reverts with it:
+	// PUSH1 0x64 PUSH1 0x0c PUSH1 0x00 CODECOPY PUSH1 0x64 PUSH1 0x00 REVERT
+	bytecode := append(common.FromHex("0x6064600c60003960646000fd"), revertPayload...)
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1000000), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	// require the simulations of the reverted execution to fail
+	result := suite.handler(suite.ctx.WithIsCheckTx(true), tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Contains(result.Log, "insufficient balance")
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal(revertPayload, resultData.Ret)
+
+	// require the delivered reverted execution to be completed, with the revert
+	// payload in the result data and the revert reason in the log
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Contains(result.Log, "insufficient balance")
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal(revertPayload, resultData.Ret)
+
+	// require the receipt to be found by Ethereum hash with a failed status
+	suite.Require().Equal(tx.Hash(), resultData.TxHash)
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, tx.Hash().Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+	suite.Require().Equal(types.ReceiptStatusFailed, receipt.Status)
+	suite.Require().Empty(receipt.Logs)
+	suite.Require().Equal(crypto.CreateAddress(crypto.PubkeyToAddress(priv.PublicKey), 0), *receipt.ContractAddress)
+	suite.Require().True(receipt.GasUsed > 0 && receipt.GasUsed < 100000, "gas used %d", receipt.GasUsed)
+}
+
+func (suite *EvmTestSuite) TestHandler_CustomChainID() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	recipient := common.BytesToAddress([]byte("recipient"))
+	ctx := suite.ctx.WithChainID("ethermint-7")
+
+	// require the EIP-155 chain ID to be parsed from the chain-id string
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), 100000, big.NewInt(1000000), nil)
+	tx.Sign(big.NewInt(7), priv)
+
+	result := suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	// require a transaction signed for another chain ID to fail
+	tx = types.NewMsgEthereumTx(1, &recipient, big.NewInt(0), 100000, big.NewInt(1000000), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(ctx, tx)
+	suite.Require().False(result.IsOK())
+
+	// require chain-id strings without a trailing integer to fail
+	result = suite.handler(suite.ctx.WithChainID("ethermint"), tx)
+	suite.Require().False(result.IsOK())
+}
+
+func (suite *EvmTestSuite) TestHandler_CoinDecimals() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.CoinDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// 1 coin unit is 10^12 wei
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(5000000000000))
+
+	// require amounts not representable in the coin denomination to be rejected,
+	// the transactions don't pay for gas as there is no fee to refund
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(1500000000000), 100000, big.NewInt(0), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(emint.ErrInvalidValue.ABCICode(), uint32(result.Code))
+
+	tx = types.NewMsgEthereumTx(0, &recipient, big.NewInt(2000000000000), 100000, big.NewInt(0), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Equal(0, big.NewInt(3000000000000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, sender)))
+	suite.Require().Equal(0, big.NewInt(2000000000000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, recipient)))
+}
+
+func (suite *EvmTestSuite) TestHandler_NewRecipient() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("new_recipient"))
+
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(5000))
+
+	// require a zero value call not to create an empty account, as per EIP-161
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), 100000, big.NewInt(0), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Nil(suite.app.AccountKeeper.GetAccount(suite.ctx, recipient.Bytes()))
+
+	// require a value transfer to create the recipient account
+	tx = types.NewMsgEthereumTx(1, &recipient, big.NewInt(2000), 100000, big.NewInt(0), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().NotNil(suite.app.AccountKeeper.GetAccount(suite.ctx, recipient.Bytes()))
+	suite.Require().Equal(0, big.NewInt(2000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, recipient)))
+}
+
+func (suite *EvmTestSuite) TestHandler_EvmDenom() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.EvmDenom = "aevmos"
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(5000))
+
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(2000), 100000, big.NewInt(0), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	// require the value to be transferred in the EVM denomination
+	coins := suite.app.BankKeeper.GetCoins(suite.ctx, sender.Bytes())
+	suite.Require().True(sdk.NewInt(3000).Equal(coins.AmountOf("aevmos")), coins.String())
+	suite.Require().True(coins.AmountOf(emint.DenomDefault).IsZero(), coins.String())
+
+	coins = suite.app.BankKeeper.GetCoins(suite.ctx, recipient.Bytes())
+	suite.Require().True(sdk.NewInt(2000).Equal(coins.AmountOf("aevmos")), coins.String())
+}
+
+func (suite *EvmTestSuite) TestHandler_ContractCode() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	// runtime code returning 42: PUSH1 0x2a PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	runtimeCode := common.FromHex("0x602a60005260206000f3")
+
+	// init code that copies the runtime code appended to it into memory and returns it:
+	// PUSH1 0x0a PUSH1 0x0c PUSH1 0x00 CODECOPY PUSH1 0x0a PUSH1 0x00 RETURN
+	bytecode := append(common.FromHex("0x600a600c600039600a6000f3"), runtimeCode...)
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	contractAddress := types.GetContractAddress(sender, 0)
+	suite.Require().Equal(runtimeCode, suite.app.EvmKeeper.GetCode(suite.ctx, contractAddress))
+
+	path := []string{types.QueryCode, contractAddress.Hex()}
+	bz, err := suite.querier(suite.ctx, path, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResCode
+	suite.codec.MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal(runtimeCode, res.Code)
+
+	// require externally-owned accounts to have no code
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, sender))
+}
+
+func (suite *EvmTestSuite) TestHandler_BlockHash() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	// runtime code returning the hash of the previous block:
+	// PUSH1 0x01 NUMBER SUB BLOCKHASH PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	runtimeCode := common.FromHex("0x600143034060005260206000f3")
+	bytecode := append(common.FromHex("0x600d600c600039600d6000f3"), runtimeCode...)
+
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	contractAddress := types.GetContractAddress(sender, 0)
+
+	// the hash of the previous block is recorded at the beginning of the block
+	lastBlockHash := common.BytesToHash([]byte("last_block_hash")).Bytes()
+	header := abci.Header{Height: 10, LastBlockId: abci.BlockID{Hash: lastBlockHash}}
+	ctx := suite.ctx.WithBlockHeader(header)
+	evm.BeginBlock(suite.app.EvmKeeper, ctx, abci.RequestBeginBlock{Header: header})
+
+	tx = types.NewMsgEthereumTx(1, &contractAddress, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(lastBlockHash, resultData.Ret)
+	suite.Require().Equal(common.BytesToHash(lastBlockHash), suite.app.EvmKeeper.GetHeightHash(ctx, 9))
+}
+
+func (suite *EvmTestSuite) TestHandler_BlockGasLimit() {
+	blockGasLimit := uint64(200000)
+	params := types.DefaultParams()
+	params.BlockGasLimit = blockGasLimit
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	// contract returning 42, see TestHandler_ContractCode
+	bytecode := common.FromHex("0x600a600c600039600a6000f3602a60005260206000f3")
+
+	evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+
+	// require a transaction using the whole block gas limit to be accepted
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), blockGasLimit, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	gasUsed := suite.app.EvmKeeper.GetBlockGasUsed()
+	suite.Require().NotZero(gasUsed)
+	suite.Require().True(gasUsed < blockGasLimit)
+
+	// require the transaction exceeding the gas left in the block to be rejected
+	tx = types.NewMsgEthereumTx(1, nil, big.NewInt(0), blockGasLimit-gasUsed+1, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(emint.ErrBlockGasLimitExceeded.ABCICode(), uint32(result.Code))
+	suite.Require().Equal(gasUsed, suite.app.EvmKeeper.GetBlockGasUsed())
+
+	// require a transaction fitting in the gas left in the block to be accepted
+	tx = types.NewMsgEthereumTx(1, nil, big.NewInt(0), blockGasLimit-gasUsed, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().True(suite.app.EvmKeeper.GetBlockGasUsed() > gasUsed)
+
+	// require the gas used to be reset in the next block
+	evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	suite.Require().Zero(suite.app.EvmKeeper.GetBlockGasUsed())
+
+	tx = types.NewMsgEthereumTx(2, nil, big.NewInt(0), blockGasLimit, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	// require CheckTx to reject the transactions exceeding the block gas limit
+	tx = types.NewMsgEthereumTx(3, nil, big.NewInt(0), blockGasLimit+1, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx.WithIsCheckTx(true), tx)
+	suite.Require().Equal(emint.ErrBlockGasLimitExceeded.ABCICode(), uint32(result.Code))
+}
+
+func (suite *EvmTestSuite) TestHandler_Precompiles() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	nonce := uint64(0)
+	call := func(precompile byte, input []byte) sdk.Result {
+		to := common.BytesToAddress([]byte{precompile})
+		tx := types.NewMsgEthereumTx(nonce, &to, big.NewInt(0), 100000, big.NewInt(1), input)
+		tx.Sign(big.NewInt(3), priv)
+		nonce++
+
+		return suite.handler(suite.ctx, tx)
+	}
+
+	// identity returns its input
+	input := []byte("arbitrary input")
+	result := call(4, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal(input, resultData.Ret)
+
+	// ecrecover returns the address of the signer
+	signer, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	suite.Require().NoError(err)
+
+	hash := crypto.Keccak256([]byte("ethermint"))
+	sig, err := crypto.Sign(hash, signer)
+	suite.Require().NoError(err)
+
+	input = make([]byte, 128)
+	copy(input, hash)
+	input[63] = sig[64] + 27
+	copy(input[64:], sig[:64])
+
+	result = call(1, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal(common.LeftPadBytes(crypto.PubkeyToAddress(signer.PublicKey).Bytes(), 32), resultData.Ret)
+
+	// modexp (2 ** 3 % 5) is only available from the byzantium hardfork
+	input = make([]byte, 99)
+	input[31], input[63], input[95] = 1, 1, 1
+	input[96], input[97], input[98] = 2, 3, 5
+
+	result = call(5, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal([]byte{3}, resultData.Ret)
+
+	params := types.DefaultParams()
+	params.Hardfork = types.HardforkHomestead
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	result = call(5, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Empty(resultData.Ret)
+}
+
+func (suite *EvmTestSuite) TestHandler_RefundGas() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	balance := big.NewInt(1000)
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, balance)
+
+	feeCollector := suite.app.SupplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	collected := suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)
+
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(10)
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), gasLimit, gasPrice, nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+
+	// require the fee of the unused gas to be refunded from the fee collector
+	refund := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit-receipt.GasUsed))
+	balance.Add(balance, refund)
+	suite.Require().Equal(0, balance.Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, sender)))
+
+	collected = collected.Sub(sdk.NewIntFromBigInt(refund))
+	suite.Require().True(collected.Equal(suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)))
+}
+
+func (suite *EvmTestSuite) TestHandler_RefundGasCapped() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	// PUSH1 0 PUSH1 0 SSTORE STOP, clearing the slot 0
+	contract := common.BytesToAddress([]byte("contract"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, contract, common.FromHex("0x600060005500"))
+	suite.app.EvmKeeper.SetState(suite.ctx, contract, common.Hash{}, common.BytesToHash([]byte{1}))
+	_, err = suite.app.EvmKeeper.Commit(suite.ctx, false)
+	suite.Require().NoError(err)
+
+	balance := big.NewInt(1000)
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, balance)
+
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(10)
+	tx := types.NewMsgEthereumTx(0, &contract, big.NewInt(0), gasLimit, gasPrice, nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	result := suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Equal(common.Hash{}, suite.app.EvmKeeper.GetState(suite.ctx, contract, common.Hash{}))
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+
+	// require the receipt to hold the gas consumed on the gas meter, net of the
+	// capped refund of the cleared slot
+	suite.Require().Equal(ctx.GasMeter().GasConsumed(), receipt.GasUsed)
+	suite.Require().Equal(receipt.GasUsed, receipt.CumulativeGasUsed)
+
+	// require the fee of the gas left after the refund to be returned
+	refund := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit-receipt.GasUsed))
+	balance.Add(balance, refund)
+	suite.Require().Equal(0, balance.Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, sender)))
+}
+
+func (suite *EvmTestSuite) TestHandler_BaseFee() {
+	params := types.DefaultParams()
+	params.BlockGasLimit = 100000
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	initialBaseFee := big.NewInt(1000000000)
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, initialBaseFee)
+
+	testCases := []struct {
+		name     string
+		gasUsed  uint64
+		expected *big.Int
+	}{
+		{"full block", 100000, big.NewInt(1125000000)},
+		{"gas used at target", 50000, big.NewInt(1125000000)},
+		{"empty block", 0, big.NewInt(984375000)},
+		{"gas used below target", 25000, big.NewInt(922851563)},
+	}
+
+	for i, tc := range testCases {
+		evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: int64(i + 1)}})
+		suite.app.EvmKeeper.AddBlockGasUsed(tc.gasUsed)
+		evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{})
+
+		suite.Require().Equal(tc.expected, suite.app.EvmKeeper.GetBaseFee(suite.ctx), tc.name)
+	}
+
+	// require the base fee to be left unchanged with an unlimited block gas
+	params.BlockGasLimit = 0
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	suite.app.EvmKeeper.AddBlockGasUsed(100000)
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{})
+	suite.Require().Equal(big.NewInt(922851563), suite.app.EvmKeeper.GetBaseFee(suite.ctx))
+}
+
+func (suite *EvmTestSuite) TestHandler_InitialMinBaseFee() {
+	params := types.DefaultParams()
+	params.BlockGasLimit = 100000
+	params.InitialBaseFee = sdk.NewInt(1000000000)
+	params.MinBaseFee = sdk.NewInt(950000000)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the initial base fee applies until the end of the first block
+	suite.Require().Equal(big.NewInt(1000000000), suite.app.EvmKeeper.GetBaseFee(suite.ctx))
+
+	testCases := []struct {
+		name     string
+		gasUsed  uint64
+		expected *big.Int
+	}{
+		{"empty block clamped", 0, big.NewInt(950000000)},
+		{"empty block at floor", 0, big.NewInt(950000000)},
+		{"full block", 100000, big.NewInt(1068750000)},
+	}
+
+	for i, tc := range testCases {
+		evm.BeginBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestBeginBlock{Header: abci.Header{Height: int64(i + 1)}})
+		suite.app.EvmKeeper.AddBlockGasUsed(tc.gasUsed)
+		evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{})
+
+		suite.Require().Equal(tc.expected, suite.app.EvmKeeper.GetBaseFee(suite.ctx), tc.name)
+	}
+
+	// require the initial base fee not to apply once the base fee is set
+	params.InitialBaseFee = sdk.NewInt(2000000000)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+	suite.Require().Equal(big.NewInt(1068750000), suite.app.EvmKeeper.GetBaseFee(suite.ctx))
+}
+
+func (suite *EvmTestSuite) TestHandler_ZeroBaseFee() {
+	params := types.DefaultParams()
+	params.BlockGasLimit = 100000
+	params.InitialBaseFee = sdk.NewInt(1)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// require a base fee dropping to zero not to be reset to the initial one
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{})
+	suite.Require().Equal(big.NewInt(1), suite.app.EvmKeeper.GetBaseFee(suite.ctx))
+
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, big.NewInt(0))
+	evm.EndBlock(suite.app.EvmKeeper, suite.ctx, abci.RequestEndBlock{})
+	suite.Require().Equal(0, suite.app.EvmKeeper.GetBaseFee(suite.ctx).Sign())
+}
+
+func (suite *EvmTestSuite) TestHandler_BurnBaseFee() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	balance := big.NewInt(1000)
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, balance)
+
+	baseFee := big.NewInt(7)
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, baseFee)
+
+	feeCollector := suite.app.SupplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	collected := suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)
+	supply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(emint.DenomDefault)
+
+	gasLimit := uint64(100000)
+	tx := types.NewMsgEthereumTxDynamicFee(0, &recipient, big.NewInt(0), gasLimit, big.NewInt(2), big.NewInt(10), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+
+	// require the unused gas to be refunded at the base fee plus the tip
+	gasPrice := big.NewInt(9)
+	refund := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit-receipt.GasUsed))
+	balance.Add(balance, refund)
+	suite.Require().Equal(0, balance.Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, sender)))
+
+	// require the base fee of the gas used to be burned from the fee collector,
+	// the tip remaining for the proposer
+	burned := sdk.NewIntFromBigInt(new(big.Int).Mul(baseFee, new(big.Int).SetUint64(receipt.GasUsed)))
+	collected = collected.Sub(sdk.NewIntFromBigInt(refund)).Sub(burned)
+	suite.Require().True(collected.Equal(suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)))
+	suite.Require().True(supply.Sub(burned).Equal(suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(emint.DenomDefault)))
+}
+
+func (suite *EvmTestSuite) TestHandler_RevertedGasSettlement() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	balance := big.NewInt(1000)
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, balance)
+
+	baseFee := big.NewInt(7)
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, baseFee)
+
+	feeCollector := suite.app.SupplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	collected := suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)
+	blockGasUsed := suite.app.EvmKeeper.GetBlockGasUsed()
+
+	// init code reverting without payload: PUSH1 0 PUSH1 0 REVERT
+	gasLimit := uint64(100000)
+	tx := types.NewMsgEthereumTxDynamicFee(0, nil, big.NewInt(0), gasLimit, big.NewInt(2), big.NewInt(10), common.FromHex("0x60006000fd"))
+	tx.Sign(big.NewInt(3), priv)
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	result := suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+	suite.Require().Equal(types.ReceiptStatusFailed, receipt.Status)
+
+	// require the gas used by the reverted execution to be consumed and added to
+	// the block gas used
+	suite.Require().True(receipt.GasUsed < gasLimit, "gas used %d", receipt.GasUsed)
+	suite.Require().Equal(receipt.GasUsed, ctx.GasMeter().GasConsumed())
+	suite.Require().Equal(blockGasUsed+receipt.GasUsed, suite.app.EvmKeeper.GetBlockGasUsed())
+
+	// require the unused gas to be refunded and the base fee of the gas used to
+	// be burned, as for a successful execution
+	refund := new(big.Int).Mul(big.NewInt(9), new(big.Int).SetUint64(gasLimit-receipt.GasUsed))
+	balance.Add(balance, refund)
+	suite.Require().Equal(0, balance.Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, sender)))
+
+	burned := sdk.NewIntFromBigInt(new(big.Int).Mul(baseFee, new(big.Int).SetUint64(receipt.GasUsed)))
+	collected = collected.Sub(sdk.NewIntFromBigInt(refund)).Sub(burned)
+	suite.Require().True(collected.Equal(suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)))
+
+	// require an execution out of gas to consume the whole gas limit, added to
+	// the block gas used: JUMPDEST PUSH1 0 JUMP
+	blockGasUsed = suite.app.EvmKeeper.GetBlockGasUsed()
+	tx = types.NewMsgEthereumTx(1, nil, big.NewInt(0), gasLimit, big.NewInt(10), common.FromHex("0x5b600056"))
+	tx.Sign(big.NewInt(3), priv)
+
+	ctx = suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	result = suite.handler(ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(sdkerrors.ErrOutOfGas.ABCICode(), uint32(result.Code))
+	suite.Require().Equal(gasLimit, ctx.GasMeter().GasConsumed())
+	suite.Require().Equal(blockGasUsed+gasLimit, suite.app.EvmKeeper.GetBlockGasUsed())
+}
+
+func (suite *EvmTestSuite) TestHandler_BurnBaseFeeDisabled() {
+	params := types.DefaultParams()
+	params.BurnBaseFee = false
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(1000))
+	suite.app.EvmKeeper.SetBaseFee(suite.ctx, big.NewInt(7))
+
+	feeCollector := suite.app.SupplyKeeper.GetModuleAddress(auth.FeeCollectorName)
+	collected := suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)
+	supply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(emint.DenomDefault)
+
+	gasLimit := uint64(100000)
+	tx := types.NewMsgEthereumTxDynamicFee(0, &recipient, big.NewInt(0), gasLimit, big.NewInt(2), big.NewInt(10), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+
+	receipt, err := suite.app.EvmKeeper.GetTxReceipt(suite.ctx, resultData.TxHash.Bytes())
+	suite.Require().NoError(err, "failed to get receipt")
+
+	// require the base fee to be left in the fee collector along with the tip,
+	// the supply being unchanged
+	refund := new(big.Int).Mul(big.NewInt(9), new(big.Int).SetUint64(gasLimit-receipt.GasUsed))
+	collected = collected.Sub(sdk.NewIntFromBigInt(refund))
+	suite.Require().True(collected.Equal(suite.app.BankKeeper.GetCoins(suite.ctx, feeCollector).AmountOf(emint.DenomDefault)))
+	suite.Require().True(supply.Equal(suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(emint.DenomDefault)))
+}
+
+func (suite *EvmTestSuite) TestHandler_ZeroAddressRecipient() {
+	params := types.DefaultParams()
+	params.RejectZeroAddressRecipient = true
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	zero := common.Address{}
+
+	// require a transaction sent to the zero address to be rejected
+	tx := types.NewMsgEthereumTx(0, &zero, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(sdkerrors.ErrInvalidAddress.ABCICode(), uint32(result.Code))
+
+	// require a contract creation to be accepted
+	tx = types.NewMsgEthereumTxContract(0, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+}
+
+func (suite *EvmTestSuite) TestHandler_EnableCreateCall() {
+	// runtime code returning 42, see TestHandler_ContractCode
+	bytecode := common.FromHex("0x600a600c600039600a6000f3602a60005260206000f3")
+	eoa := common.BytesToAddress([]byte("eoa"))
+
+	testCases := []struct {
+		name         string
+		enableCreate bool
+		enableCall   bool
+	}{
+		{"create and call enabled", true, true},
+		{"create disabled", false, true},
+		{"call disabled", true, false},
+		{"create and call disabled", false, false},
+	}
+
+	for _, tc := range testCases {
+		suite.SetupTest() // reset
+
+		priv, err := crypto.GenerateKey()
+		suite.Require().NoError(err, "failed to create key")
+		sender := crypto.PubkeyToAddress(priv.PublicKey)
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+		// deploy the contract before applying the params
+		tx := types.NewMsgEthereumTxContract(0, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+		tx.Sign(big.NewInt(3), priv)
+
+		result := suite.handler(ctx, tx)
+		suite.Require().True(result.IsOK(), "%s: %s", tc.name, result.Log)
+		contractAddress := types.GetContractAddress(sender, 0)
+
+		params := types.DefaultParams()
+		params.EnableCreate = tc.enableCreate
+		params.EnableCall = tc.enableCall
+		suite.app.EvmKeeper.SetParams(ctx, params)
+
+		tx = types.NewMsgEthereumTxContract(1, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+		tx.Sign(big.NewInt(3), priv)
+
+		result = suite.handler(ctx, tx)
+		if tc.enableCreate {
+			suite.Require().True(result.IsOK(), "%s: %s", tc.name, result.Log)
+		} else {
+			suite.Require().Equal(emint.ErrCreateDisabled.ABCICode(), uint32(result.Code), tc.name)
+		}
+
+		tx = types.NewMsgEthereumTx(suite.app.EvmKeeper.GetNonce(ctx, sender), &contractAddress, big.NewInt(0), 100000, big.NewInt(1), nil)
+		tx.Sign(big.NewInt(3), priv)
+
+		result = suite.handler(ctx, tx)
+		if tc.enableCall {
+			suite.Require().True(result.IsOK(), "%s: %s", tc.name, result.Log)
+		} else {
+			suite.Require().Equal(emint.ErrCallDisabled.ABCICode(), uint32(result.Code), tc.name)
+		}
+
+		// require the transfers to the externally-owned accounts to be accepted
+		tx = types.NewMsgEthereumTx(suite.app.EvmKeeper.GetNonce(ctx, sender), &eoa, big.NewInt(0), 100000, big.NewInt(1), nil)
+		tx.Sign(big.NewInt(3), priv)
+
+		result = suite.handler(ctx, tx)
+		suite.Require().True(result.IsOK(), "%s: %s", tc.name, result.Log)
+	}
+}
+
+func (suite *EvmTestSuite) TestHandler_AllowedDeployers() {
+	privDeployer, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	deployer := crypto.PubkeyToAddress(privDeployer.PublicKey)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+
+	// every account can deploy contracts with an empty list
+	tx := types.NewMsgEthereumTxContract(0, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	params := types.DefaultParams()
+	params.AllowedDeployers = []string{deployer.Hex()}
+	suite.app.EvmKeeper.SetParams(ctx, params)
+
+	tx = types.NewMsgEthereumTxContract(0, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), privDeployer)
+
+	result = suite.handler(ctx, tx)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	// require the contract creations of the other accounts to be rejected
+	tx = types.NewMsgEthereumTxContract(1, big.NewInt(0), 100000, big.NewInt(1), nil)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(sdkerrors.ErrUnauthorized.ABCICode(), uint32(result.Code))
+}
+
+func (suite *EvmTestSuite) TestHandler_MaxTxSize() {
+	params := types.DefaultParams()
+	params.MaxTxSize = 32
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	// require a payload exceeding the max tx size to be rejected
+	tx := types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), 100000, big.NewInt(1), make([]byte, 33))
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx, tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(emint.ErrTxSizeExceeded.ABCICode(), uint32(result.Code))
+	suite.Require().Contains(result.Log, "max tx size 32")
+
+	tx = types.NewMsgEthereumTx(0, &recipient, big.NewInt(0), 100000, big.NewInt(1), make([]byte, 32))
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+}
+
+func (suite *EvmTestSuite) TestHandler_MaxCodeSize() {
+	params := types.DefaultParams()
+	params.MaxCodeSize = 10
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+	sender := crypto.PubkeyToAddress(priv.PublicKey)
+
+	// init code returning a code of 11 zero bytes:
+	// PUSH1 0x0b PUSH1 0x00 RETURN
+	bytecode := common.FromHex("0x600b6000f3")
+
+	tx := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result := suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().False(result.IsOK())
+	suite.Require().Equal(emint.ErrCodeSizeExceeded.ABCICode(), uint32(result.Code))
+	suite.Require().Contains(result.Log, "code size 11 exceeds the max code size 10")
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, crypto.CreateAddress(sender, 0)))
+
+	// require a code within the max code size to be deployed:
+	// PUSH1 0x0a PUSH1 0x00 RETURN
+	bytecode = common.FromHex("0x600a6000f3")
+
+	tx = types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), bytecode)
+	tx.Sign(big.NewInt(3), priv)
+
+	result = suite.handler(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	suite.Require().True(result.IsOK(), result.Log)
+	suite.Require().Len(suite.app.EvmKeeper.GetCode(suite.ctx, crypto.CreateAddress(sender, 0)), 10)
+}
+
+func (suite *EvmTestSuite) TestHandler_ChainConfig() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err, "failed to create key")
+
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.ChainConfig.ByzantiumBlock = sdk.NewInt(10)
+	params.ChainConfig.ConstantinopleBlock = sdk.NewInt(10)
+	params.ChainConfig.PetersburgBlock = sdk.NewInt(10)
+	params.ChainConfig.IstanbulBlock = sdk.NewInt(10)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	nonce := uint64(0)
+	send := func(ctx sdk.Context, to *common.Address, payload []byte) sdk.Result {
+		tx := types.NewMsgEthereumTx(nonce, to, big.NewInt(0), 100000, big.NewInt(1), payload)
+		tx.Sign(big.NewInt(3), priv)
+		nonce++
+
+		// the failed executions use all their gas, so each transaction has its
+		// own gas meter
+		return suite.handler(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), tx)
+	}
+
+	before := suite.ctx.WithBlockHeight(9)
+	after := suite.ctx.WithBlockHeight(10)
+
+	// the SHL opcode (PUSH1 1 PUSH1 1 SHL STOP) is only valid from constantinople
+	shl := common.FromHex("0x600160011b00")
+	suite.Require().False(send(before, nil, shl).IsOK())
+	suite.Require().True(send(after, nil, shl).IsOK())
+
+	// the modexp precompiled contract (2 ** 3 % 5) is only available from byzantium
+	modexp := common.BytesToAddress([]byte{5})
+	input := make([]byte, 99)
+	input[31], input[63], input[95] = 1, 1, 1
+	input[96], input[97], input[98] = 2, 3, 5
+
+	result := send(before, &modexp, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Empty(resultData.Ret)
+
+	result = send(after, &modexp, input)
+	suite.Require().True(result.IsOK(), result.Log)
+
+	resultData, err = types.DecodeResultData(result.Data)
+	suite.Require().NoError(err, "failed to decode result data")
+	suite.Require().Equal([]byte{3}, resultData.Ret)
+}
+
@@ -11,6 +11,10 @@ import (
 	ethvm "github.com/ethereum/go-ethereum/core/vm"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 	ethstate "github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
@@ -18,6 +22,19 @@ import (
 	"math/big"
 )
 
+// DefaultMaxRecipientTxs is the default maximum number of transactions returned
+// by GetTxsByRecipient.
+const DefaultMaxRecipientTxs = 1000
+
+const (
+	// DefaultMaxFilterBlocks is the default maximum number of blocks of the range
+	// filtered by FilterLogs.
+	DefaultMaxFilterBlocks int64 = 10000
+	// DefaultMaxFilterLogs is the default maximum number of logs returned by
+	// FilterLogs.
+	DefaultMaxFilterLogs = 10000
+)
+
 // Keeper wraps the CommitStateDB, allowing us to pass in SDK context while adhering
 // to the StateDB interface.
 type Keeper struct {
@@ -26,22 +43,62 @@ type Keeper struct {
 	// Store key required to update the block bloom filter mappings needed for the
 	// Web3 API
 	blockKey      sdk.StoreKey
+	paramSpace    params.Subspace
 	CommitStateDB *types.CommitStateDB
-	TxCount       int
 	Bloom         *big.Int
+	// txCount counts the Ethereum transactions delivered in the current block,
+	// which sets the index of the next transaction. It is shared by the keeper
+	// copies.
+	txCount *int
+	// blockHash is the hash of the block being delivered, set on the emitted
+	// logs. It is shared by the keeper copies.
+	blockHash *ethcmn.Hash
+	// pendingNonces caches the next nonce of the senders of the transactions
+	// processed in the current block. It is shared by the keeper copies and is
+	// only advisory, the account nonce remains the value written to state.
+	pendingNonces map[ethcmn.Address]uint64
+	// blockGasUsed accumulates the gas used by the EVM transactions delivered in
+	// the current block. It is shared by the keeper copies.
+	blockGasUsed *uint64
+	// supplyKeeper refunds the fees paid for the unused gas from the fee collector
+	supplyKeeper types.SupplyKeeper
+	// maxRecipientTxs caps the number of transactions returned by
+	// GetTxsByRecipient. It is shared by the keeper copies.
+	maxRecipientTxs *int
+	// maxFilterBlocks and maxFilterLogs cap the block range and the number of
+	// logs of FilterLogs. They are shared by the keeper copies.
+	maxFilterBlocks *int64
+	maxFilterLogs   *int
 }
 
 // NewKeeper generates new evm module keeper
 func NewKeeper(
 	cdc *codec.Codec, blockKey, codeKey, storeKey sdk.StoreKey,
-	ak types.AccountKeeper,
+	paramSpace params.Subspace, ak types.AccountKeeper, sk types.SupplyKeeper,
 ) Keeper {
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	maxRecipientTxs := DefaultMaxRecipientTxs
+	maxFilterBlocks, maxFilterLogs := DefaultMaxFilterBlocks, DefaultMaxFilterLogs
+
 	return Keeper{
 		cdc:           cdc,
 		blockKey:      blockKey,
+		paramSpace:    paramSpace,
 		CommitStateDB: types.NewCommitStateDB(sdk.Context{}, codeKey, storeKey, ak),
-		TxCount:       0,
 		Bloom:         big.NewInt(0),
+		txCount:       new(int),
+		blockHash:     new(ethcmn.Hash),
+		pendingNonces: make(map[ethcmn.Address]uint64),
+		blockGasUsed:  new(uint64),
+		supplyKeeper:  sk,
+
+		maxRecipientTxs: &maxRecipientTxs,
+		maxFilterBlocks: &maxFilterBlocks,
+		maxFilterLogs:   &maxFilterLogs,
 	}
 }
 
@@ -71,6 +128,49 @@ func (k *Keeper) GetBlockHashMapping(ctx sdk.Context, hash []byte) (height int64
 	return height
 }
 
+// SetHeightHash sets the hash of the block at the given height in the ring
+// buffer of the recent block hashes, overwriting the hash of the block
+// NumRecentBlockHashes blocks before it.
+func (k *Keeper) SetHeightHash(ctx sdk.Context, height int64, hash []byte) {
+	if len(hash) == 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.blockKey)
+	store.Set(types.HeightHashKey(height), append(sdk.Uint64ToBigEndian(uint64(height)), hash...))
+}
+
+// GetHeightHash returns the hash of the block at the given height if it is
+// still held by the ring buffer of the recent block hashes, the zero hash
+// otherwise.
+func (k *Keeper) GetHeightHash(ctx sdk.Context, height int64) ethcmn.Hash {
+	if height < 0 {
+		return ethcmn.Hash{}
+	}
+
+	store := ctx.KVStore(k.blockKey)
+	bz := store.Get(types.HeightHashKey(height))
+	if len(bz) <= 8 || int64(binary.BigEndian.Uint64(bz[:8])) != height {
+		return ethcmn.Hash{}
+	}
+
+	return ethcmn.BytesToHash(bz[8:])
+}
+
+// GetHashFn returns the function used by the BLOCKHASH opcode to retrieve the
+// hash of a block. Only the NumRecentBlockHashes blocks preceding the current
+// one have a hash, the zero hash being returned for the other heights.
+func (k *Keeper) GetHashFn(ctx sdk.Context) ethvm.GetHashFunc {
+	return func(n uint64) ethcmn.Hash {
+		height := ctx.BlockHeight()
+		if n >= uint64(height) || n+types.NumRecentBlockHashes < uint64(height) {
+			return ethcmn.Hash{}
+		}
+
+		return k.GetHeightHash(ctx, int64(n))
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Block bloom bits mapping functions
 // May be removed when using only as module (only required by rpc api)
@@ -88,16 +188,50 @@ func (k *Keeper) SetBlockBloomMapping(ctx sdk.Context, bloom ethtypes.Bloom, hei
 	return nil
 }
 
-// GetBlockBloomMapping gets bloombits from block height
+// GetBlockBloomMapping gets bloombits from block height. It returns an error if
+// no bloom is stored for the height.
 func (k *Keeper) GetBlockBloomMapping(ctx sdk.Context, height int64) (ethtypes.Bloom, error) {
 	store := ctx.KVStore(k.blockKey)
-	bz := sdk.Uint64ToBigEndian(uint64(height))
-	if len(bz) == 0 {
-		return ethtypes.BytesToBloom([]byte{}), fmt.Errorf("block with height %d not found", height)
+	bz := store.Get(types.BloomKey(sdk.Uint64ToBigEndian(uint64(height))))
+	if bz == nil {
+		return ethtypes.Bloom{}, fmt.Errorf("bloom of block with height %d not found", height)
 	}
 
-	bloom := store.Get(types.BloomKey(bz))
-	return ethtypes.BytesToBloom(bloom), nil
+	return ethtypes.BytesToBloom(bz), nil
+}
+
+// SetStateRoot sets the root of the EVM state committed at the end of the block
+// at the given height.
+func (k *Keeper) SetStateRoot(ctx sdk.Context, height int64, root ethcmn.Hash) {
+	store := ctx.KVStore(k.blockKey)
+	store.Set(types.StateRootKey(height), root.Bytes())
+}
+
+// GetStateRoot gets the root of the EVM state committed at the end of the block
+// at the given height. It returns an error if no root is stored for the height.
+func (k *Keeper) GetStateRoot(ctx sdk.Context, height int64) (ethcmn.Hash, error) {
+	store := ctx.KVStore(k.blockKey)
+	bz := store.Get(types.StateRootKey(height))
+	if bz == nil {
+		return ethcmn.Hash{}, fmt.Errorf("state root of block with height %d not found", height)
+	}
+
+	return ethcmn.BytesToHash(bz), nil
+}
+
+// IterateBlockBlooms iterates over the stored block blooms in ascending height
+// order and performs a callback function.
+func (k *Keeper) IterateBlockBlooms(ctx sdk.Context, cb func(height int64, bloom ethtypes.Bloom) (stop bool)) {
+	store := ctx.KVStore(k.blockKey)
+	iter := sdk.KVStorePrefixIterator(store, types.BloomKey(nil))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		height := int64(binary.BigEndian.Uint64(iter.Key()[len(types.BloomKey(nil)):]))
+		if cb(height, ethtypes.BytesToBloom(iter.Value())) {
+			break
+		}
+	}
 }
 
 // SetTransactionLogs sets the transaction's logs in the KVStore
@@ -123,19 +257,374 @@ func (k *Keeper) GetTransactionLogs(ctx sdk.Context, hash []byte) ([]*ethtypes.L
 	return types.DecodeLogs(encLogs)
 }
 
+// SetTxReceipt sets the receipt of a transaction in the KVStore
+func (k *Keeper) SetTxReceipt(ctx sdk.Context, receipt types.TxReceipt, hash []byte) error {
+	store := ctx.KVStore(k.blockKey)
+	bz, err := types.EncodeTxReceipt(receipt)
+	if err != nil {
+		return err
+	}
+	store.Set(types.ReceiptKey(hash), bz)
+
+	return nil
+}
+
+// GetTxReceipt gets the receipt of a transaction from the KVStore
+func (k *Keeper) GetTxReceipt(ctx sdk.Context, hash []byte) (types.TxReceipt, error) {
+	store := ctx.KVStore(k.blockKey)
+	bz := store.Get(types.ReceiptKey(hash))
+	if len(bz) == 0 {
+		return types.TxReceipt{}, errors.New("cannot get transaction receipt")
+	}
+
+	return types.DecodeTxReceipt(bz)
+}
+
+// SetTxHashMapping sets the mapping from the Ethereum transaction hash to the
+// Tendermint transaction hash
+func (k *Keeper) SetTxHashMapping(ctx sdk.Context, ethHash ethcmn.Hash, txHash []byte) {
+	store := ctx.KVStore(k.blockKey)
+	store.Set(types.TxHashKey(ethHash.Bytes()), txHash)
+}
+
+// GetTxHashMapping gets the Tendermint transaction hash from the Ethereum
+// transaction hash. It returns nil if the transaction is not found.
+func (k *Keeper) GetTxHashMapping(ctx sdk.Context, ethHash ethcmn.Hash) []byte {
+	store := ctx.KVStore(k.blockKey)
+	return store.Get(types.TxHashKey(ethHash.Bytes()))
+}
+
+// SetRecipientTx indexes the transaction with the given hash by its recipient,
+// block height and index in the block.
+func (k *Keeper) SetRecipientTx(ctx sdk.Context, recipient ethcmn.Address, height int64, txIndex uint64, hash []byte) {
+	store := ctx.KVStore(k.blockKey)
+	store.Set(types.RecipientTxKey(recipient, height, txIndex), hash)
+}
+
+// SetMaxRecipientTxs sets the maximum number of transactions returned by
+// GetTxsByRecipient.
+func (k *Keeper) SetMaxRecipientTxs(max int) {
+	*k.maxRecipientTxs = max
+}
+
+// GetTxsByRecipient returns the receipts of the Ethereum transactions sent to
+// the recipient, or creating the contract at its address, from the given block
+// height to the given one included, ordered by height and index in the block.
+// As for FilterLogs, a height that is zero or above the latest one is the latest
+// height. At most the max recipient transactions are returned, so that the
+// remaining transactions are queried from the height of the last receipt.
+func (k *Keeper) GetTxsByRecipient(ctx sdk.Context, recipient ethcmn.Address, fromBlock, toBlock int64) ([]types.TxReceipt, error) {
+	latest := ctx.BlockHeight()
+
+	if fromBlock <= 0 || fromBlock > latest {
+		fromBlock = latest
+	}
+	if toBlock <= 0 || toBlock > latest {
+		toBlock = latest
+	}
+
+	receipts := []types.TxReceipt{}
+	if fromBlock > toBlock {
+		return receipts, nil
+	}
+
+	store := ctx.KVStore(k.blockKey)
+	iterator := store.Iterator(
+		types.RecipientTxHeightPrefix(recipient, fromBlock),
+		types.RecipientTxHeightPrefix(recipient, toBlock+1),
+	)
+	defer iterator.Close()
+
+	for ; iterator.Valid() && len(receipts) < *k.maxRecipientTxs; iterator.Next() {
+		receipt, err := k.GetTxReceipt(ctx, iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		receipts = append(receipts, receipt)
+	}
+
+	return receipts, nil
+}
+
+// SetMaxFilterBlocks sets the maximum number of blocks of the range filtered by
+// FilterLogs.
+func (k *Keeper) SetMaxFilterBlocks(max int64) {
+	*k.maxFilterBlocks = max
+}
+
+// SetMaxFilterLogs sets the maximum number of logs returned by FilterLogs.
+func (k *Keeper) SetMaxFilterLogs(max int) {
+	*k.maxFilterLogs = max
+}
+
+// FilterLogs returns the logs persisted in the KVStore that match the given
+// filter criteria. The blocks of the range are pre-filtered with their bloom,
+// where the bloom of the latest block height is the one being aggregated by
+// the keeper. An error is returned if the range exceeds the max filter blocks
+// or if more than the max filter logs match.
+func (k *Keeper) FilterLogs(ctx sdk.Context, filter types.LogFilter) ([]*ethtypes.Log, error) {
+	latest := ctx.BlockHeight()
+
+	from, to := filter.FromBlock, filter.ToBlock
+	if from <= 0 || from > latest {
+		from = latest
+	}
+	if to <= 0 || to > latest {
+		to = latest
+	}
+
+	if to-from+1 > *k.maxFilterBlocks {
+		return nil, sdkerrors.Wrapf(
+			sdkerrors.ErrUnknownRequest, "exceed maximum block range: %d", *k.maxFilterBlocks,
+		)
+	}
+
+	logs := []*ethtypes.Log{}
+	for height := from; height <= to; height++ {
+		bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
+		if height != latest {
+			var err error
+			// blocks without a stored bloom have no logs
+			bloom, err = k.GetBlockBloomMapping(ctx, height)
+			if err != nil {
+				continue
+			}
+		}
+
+		if !filter.BloomMatches(bloom) {
+			continue
+		}
+
+		blockLogs, err := k.GetBlockLogs(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, filter.FilterLogs(blockLogs)...)
+		if len(logs) > *k.maxFilterLogs {
+			return nil, sdkerrors.Wrapf(
+				sdkerrors.ErrUnknownRequest, "query returned more than %d results", *k.maxFilterLogs,
+			)
+		}
+	}
+
+	return logs, nil
+}
+
+// ----------------------------------------------------------------------------
+// Pending nonce functions
+// ----------------------------------------------------------------------------
+
+// SetPendingNonce sets the next nonce of the given sender for the current block
+func (k *Keeper) SetPendingNonce(addr ethcmn.Address, nonce uint64) {
+	k.pendingNonces[addr] = nonce
+}
+
+// GetPendingNonce returns the next nonce of the given address, including the
+// transactions processed in the current block. It defaults to the account nonce
+// if no transaction from the address has been processed in the block.
+func (k *Keeper) GetPendingNonce(ctx sdk.Context, addr ethcmn.Address) uint64 {
+	nonce := k.GetNonce(ctx, addr)
+	if pending, ok := k.pendingNonces[addr]; ok && pending > nonce {
+		return pending
+	}
+	return nonce
+}
+
+// ResetPendingNonces clears the pending nonces cache. It must be called at the
+// beginning of each block.
+func (k *Keeper) ResetPendingNonces() {
+	for addr := range k.pendingNonces {
+		delete(k.pendingNonces, addr)
+	}
+}
+
+// GetBlockGasUsed returns the gas used by the EVM transactions delivered in the
+// current block.
+func (k *Keeper) GetBlockGasUsed() uint64 {
+	return *k.blockGasUsed
+}
+
+// AddBlockGasUsed adds the gas used by a delivered EVM transaction to the gas
+// used in the current block.
+func (k *Keeper) AddBlockGasUsed(gas uint64) {
+	*k.blockGasUsed += gas
+}
+
+// ResetBlockGasUsed resets the gas used in the current block. It must be called
+// at the beginning of each block.
+func (k *Keeper) ResetBlockGasUsed() {
+	*k.blockGasUsed = 0
+}
+
+// GetTxCount returns the number of EVM transactions delivered in the current
+// block, which is the index of the next transaction in the block.
+func (k *Keeper) GetTxCount() int {
+	return *k.txCount
+}
+
+// IncrementTxCount increments the number of EVM transactions delivered in the
+// current block.
+func (k *Keeper) IncrementTxCount() {
+	*k.txCount++
+}
+
+// GetCurrentBlockHash returns the hash of the block being delivered.
+func (k *Keeper) GetCurrentBlockHash() ethcmn.Hash {
+	return *k.blockHash
+}
+
+// ResetBlock resets the transaction count and the log index of the state DB,
+// and sets the hash of the block being delivered. It must be called at the
+// beginning of each block.
+func (k *Keeper) ResetBlock(hash ethcmn.Hash) {
+	*k.txCount = 0
+	*k.blockHash = hash
+	k.CommitStateDB.ResetLogIndex()
+}
+
+// WithHeight returns a copy of the context reading the state committed at the
+// given block height. The multistore of the context must be the root multistore
+// of the app, the cache wrapped multistores of the transactions and queries not
+// being able to load past versions. An error wrapping ErrStatePruned is returned
+// if the state of the height has been pruned.
+//
+// The state objects cached by the StateDB aren't bound to a height, hence they
+// must be cleared before reading the accounts of another height.
+func (k *Keeper) WithHeight(ctx sdk.Context, height int64) (sdk.Context, error) {
+	cms, ok := ctx.MultiStore().(sdk.CommitMultiStore)
+	if !ok {
+		return ctx, errors.New("historical state queries require the root multistore")
+	}
+
+	latest := cms.LastCommitID().Version
+	if height <= 0 || height > latest {
+		return ctx, sdkerrors.Wrapf(emint.ErrInvalidValue, "invalid height %d, latest height is %d", height, latest)
+	}
+
+	cacheMS, err := cms.CacheMultiStoreWithVersion(height)
+	if err != nil {
+		return ctx, sdkerrors.Wrapf(
+			emint.ErrStatePruned, "state at height %d not available: %s (latest height is %d)", height, err, latest,
+		)
+	}
+
+	return ctx.WithMultiStore(cacheMS).WithBlockHeight(height), nil
+}
+
+// RefundGas refunds the fee paid by the sender for the unused gas of a
+// transaction, at the given price per gas, from the fee collector. The cached
+// accounts of the state DB are updated with the refunded balance.
+func (k *Keeper) RefundGas(ctx sdk.Context, sender ethcmn.Address, leftoverGas uint64, gasPrice *big.Int) error {
+	if leftoverGas == 0 || gasPrice.Sign() == 0 {
+		return nil
+	}
+
+	price, err := types.WeiToCoin(gasPrice, k.GetCoinDecimals(ctx))
+	if err != nil {
+		return sdkerrors.Wrap(err, "invalid gas price")
+	}
+
+	refund := sdk.NewCoins(sdk.NewCoin(k.GetEvmDenom(ctx), price.Mul(sdk.NewIntFromBigInt(new(big.Int).SetUint64(leftoverGas)))))
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, auth.FeeCollectorName, sender.Bytes(), refund); err != nil {
+		return sdkerrors.Wrap(err, "failed to refund the unused gas")
+	}
+
+	k.CommitStateDB.WithContext(ctx).UpdateAccounts()
+	return nil
+}
+
+// GetBaseFee returns the EIP-1559 base fee of the current block, in wei. The
+// InitialBaseFee param applies until a base fee is set at the end of the first
+// block.
+func (k *Keeper) GetBaseFee(ctx sdk.Context) *big.Int {
+	store := ctx.KVStore(k.blockKey)
+	if !store.Has(types.BaseFeeKey) {
+		return k.GetInitialBaseFee(ctx).BigInt()
+	}
+
+	return new(big.Int).SetBytes(store.Get(types.BaseFeeKey))
+}
+
+// SetBaseFee sets the EIP-1559 base fee of the next block, in wei.
+func (k *Keeper) SetBaseFee(ctx sdk.Context, baseFee *big.Int) {
+	store := ctx.KVStore(k.blockKey)
+	store.Set(types.BaseFeeKey, baseFee.Bytes())
+}
+
+// UpdateBaseFee sets the base fee of the next block from the gas used by the
+// EVM transactions of the current block and the block gas limit, without going
+// below the MinBaseFee param. It must be called at the end of each block.
+func (k *Keeper) UpdateBaseFee(ctx sdk.Context) {
+	baseFee := types.CalcBaseFee(k.GetBaseFee(ctx), k.GetBlockGasUsed(), k.GetBlockGasLimit(ctx))
+	if minBaseFee := k.GetMinBaseFee(ctx).BigInt(); baseFee.Cmp(minBaseFee) < 0 {
+		baseFee = minBaseFee
+	}
+
+	k.SetBaseFee(ctx, baseFee)
+}
+
+// BurnBaseFee burns the base fee paid for the gas used by a transaction from
+// the fee collector, the remaining priority fee being distributed to the
+// proposer. The burned amount is rounded down to the coin denomination. The
+// base fee is left in the fee collector if the BurnBaseFee param is disabled.
+func (k *Keeper) BurnBaseFee(ctx sdk.Context, gasUsed uint64, baseFee *big.Int) error {
+	if gasUsed == 0 || baseFee.Sign() == 0 || !k.GetBurnBaseFee(ctx) {
+		return nil
+	}
+
+	weiPerCoin := types.CoinToWei(sdk.OneInt(), k.GetCoinDecimals(ctx))
+	burned := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed))
+	burned.Quo(burned, weiPerCoin)
+	if burned.Sign() == 0 {
+		return nil
+	}
+
+	coins := sdk.NewCoins(sdk.NewCoin(k.GetEvmDenom(ctx), sdk.NewIntFromBigInt(burned)))
+	if err := k.supplyKeeper.SendCoinsFromModuleToModule(ctx, auth.FeeCollectorName, types.ModuleName, coins); err != nil {
+		return sdkerrors.Wrap(err, "failed to collect the base fee")
+	}
+
+	if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+		return sdkerrors.Wrap(err, "failed to burn the base fee")
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 // Genesis
 // ----------------------------------------------------------------------------
 
 // CreateGenesisAccount initializes an account and its balance, code, and storage
 func (k *Keeper) CreateGenesisAccount(ctx sdk.Context, account types.GenesisAccount) {
-	csdb := k.CommitStateDB.WithContext(ctx)
-	csdb.SetBalance(account.Address, account.Balance)
-	csdb.SetCode(account.Address, account.Code)
-	for _, key := range account.Storage {
-		csdb.SetState(account.Address, key, account.Storage[key])
+	csdb := k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx))
+	addr := ethcmn.HexToAddress(account.Address)
+	csdb.SetBalance(addr, account.Balance)
+	csdb.SetCode(addr, account.Code)
+	for _, state := range account.Storage {
+		csdb.SetState(addr, ethcmn.HexToHash(state.Key), ethcmn.HexToHash(state.Value))
 	}
+}
 
+// GetAccountStorage returns the non-zero storage slots of the account with the
+// given address sorted by key. Only the storage prefix of the account is
+// iterated.
+func (k *Keeper) GetAccountStorage(ctx sdk.Context, addr ethcmn.Address) (types.Storage, error) {
+	var storage types.Storage
+	err := k.ForEachStorage(ctx, addr, func(key, value ethcmn.Hash) bool {
+		// a slot cleared by the transaction in progress is zero until committed
+		if value != (ethcmn.Hash{}) {
+			storage = append(storage, types.NewState(key, value))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return storage, nil
 }
 
 // ----------------------------------------------------------------------------
@@ -144,17 +633,17 @@ func (k *Keeper) CreateGenesisAccount(ctx sdk.Context, account types.GenesisAcco
 
 // SetBalance calls CommitStateDB.SetBalance using the passed in context
 func (k *Keeper) SetBalance(ctx sdk.Context, addr ethcmn.Address, amount *big.Int) {
-	k.CommitStateDB.WithContext(ctx).SetBalance(addr, amount)
+	k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx)).SetBalance(addr, amount)
 }
 
 // AddBalance calls CommitStateDB.AddBalance using the passed in context
 func (k *Keeper) AddBalance(ctx sdk.Context, addr ethcmn.Address, amount *big.Int) {
-	k.CommitStateDB.WithContext(ctx).AddBalance(addr, amount)
+	k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx)).AddBalance(addr, amount)
 }
 
 // SubBalance calls CommitStateDB.SubBalance using the passed in context
 func (k *Keeper) SubBalance(ctx sdk.Context, addr ethcmn.Address, amount *big.Int) {
-	k.CommitStateDB.WithContext(ctx).SubBalance(addr, amount)
+	k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx)).SubBalance(addr, amount)
 }
 
 // SetNonce calls CommitStateDB.SetNonce using the passed in context
@@ -198,7 +687,7 @@ func (k *Keeper) SubRefund(ctx sdk.Context, gas uint64) {
 
 // GetBalance calls CommitStateDB.GetBalance using the passed in context
 func (k *Keeper) GetBalance(ctx sdk.Context, addr ethcmn.Address) *big.Int {
-	return k.CommitStateDB.WithContext(ctx).GetBalance(addr)
+	return k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx)).GetBalance(addr)
 }
 
 // GetNonce calls CommitStateDB.GetNonce using the passed in context
@@ -241,14 +730,46 @@ func (k *Keeper) GetCommittedState(ctx sdk.Context, addr ethcmn.Address, hash et
 	return k.CommitStateDB.WithContext(ctx).GetCommittedState(addr, hash)
 }
 
+// SetLogs calls CommitStateDB.SetLogs using the passed in context
+func (k *Keeper) SetLogs(ctx sdk.Context, hash ethcmn.Hash, logs []*ethtypes.Log) error {
+	return k.CommitStateDB.WithContext(ctx).SetLogs(hash, logs)
+}
+
 // GetLogs calls CommitStateDB.GetLogs using the passed in context
 func (k *Keeper) GetLogs(ctx sdk.Context, hash ethcmn.Hash) ([]*ethtypes.Log, error) {
 	return k.CommitStateDB.WithContext(ctx).GetLogs(hash)
 }
 
-// AllLogs calls CommitStateDB.AllLogs using the passed in context
-func (k *Keeper) AllLogs(ctx sdk.Context) []*ethtypes.Log {
-	return k.CommitStateDB.WithContext(ctx).AllLogs()
+// GetBlockLogs calls CommitStateDB.GetBlockLogs using the passed in context
+func (k *Keeper) GetBlockLogs(ctx sdk.Context, height int64) ([]*ethtypes.Log, error) {
+	return k.CommitStateDB.WithContext(ctx).GetBlockLogs(height)
+}
+
+// RevertBlockLogs deletes the logs emitted at the given block height, when the
+// block is rolled back during a chain reorganization, and returns them with the
+// Removed flag set so that they can be notified to the log subscribers.
+func (k *Keeper) RevertBlockLogs(ctx sdk.Context, height int64) ([]*ethtypes.Log, error) {
+	logs, err := k.CommitStateDB.WithContext(ctx).DeleteBlockLogs(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.RemovedLogs(logs), nil
+}
+
+// AllLogs returns all the logs persisted in the KVStore. It is intended to be
+// used for full reindexing.
+func (k *Keeper) AllLogs(ctx sdk.Context) ([]*ethtypes.Log, error) {
+	logs := []*ethtypes.Log{}
+	err := k.CommitStateDB.WithContext(ctx).IterateLogs(func(_ ethcmn.Hash, txLogs []*ethtypes.Log) bool {
+		logs = append(logs, txLogs...)
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
 }
 
 // GetRefund calls CommitStateDB.GetRefund using the passed in context
@@ -361,5 +882,5 @@ func (k *Keeper) ForEachStorage(ctx sdk.Context, addr ethcmn.Address, cb func(ke
 
 // GetOrNewStateObject calls CommitStateDB.GetOrNetStateObject using the passed in context
 func (k *Keeper) GetOrNewStateObject(ctx sdk.Context, addr ethcmn.Address) types.StateObject {
-	return k.CommitStateDB.WithContext(ctx).GetOrNewStateObject(addr)
+	return k.CommitStateDB.WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx)).GetOrNewStateObject(addr)
 }
@@ -1,21 +1,32 @@
 package keeper_test
 
 import (
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkparams "github.com/cosmos/cosmos-sdk/x/params"
 
 	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/crypto"
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/keeper"
+	"github.com/cosmos/ethermint/x/evm/types"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
 )
 
 var address = ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b4c1")
@@ -76,3 +87,683 @@ func (suite *KeeperTestSuite) TestDBStorage() {
 	// simulate BaseApp EndBlocker commitment
 	suite.app.Commit()
 }
+
+func (suite *KeeperTestSuite) TestBlockBloomMappingNotFound() {
+	_, err := suite.app.EvmKeeper.GetBlockBloomMapping(suite.ctx, 5)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestHeightHash() {
+	hash1 := ethcmn.BytesToHash([]byte("block_1"))
+	hash2 := ethcmn.BytesToHash([]byte("block_2"))
+
+	suite.app.EvmKeeper.SetHeightHash(suite.ctx, 1, hash1.Bytes())
+	suite.Require().Equal(hash1, suite.app.EvmKeeper.GetHeightHash(suite.ctx, 1))
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.GetHeightHash(suite.ctx, 2))
+
+	// the hash of a block overwrites the one NumRecentBlockHashes blocks before it
+	suite.app.EvmKeeper.SetHeightHash(suite.ctx, 1+types.NumRecentBlockHashes, hash2.Bytes())
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.GetHeightHash(suite.ctx, 1))
+	suite.Require().Equal(hash2, suite.app.EvmKeeper.GetHeightHash(suite.ctx, 1+types.NumRecentBlockHashes))
+
+	// only the hashes of the recent blocks preceding the current one are available
+	getHash := suite.app.EvmKeeper.GetHashFn(suite.ctx.WithBlockHeight(2 + types.NumRecentBlockHashes))
+	suite.Require().Equal(hash2, getHash(1+types.NumRecentBlockHashes))
+	suite.Require().Equal(ethcmn.Hash{}, getHash(2+types.NumRecentBlockHashes))
+
+	getHash = suite.app.EvmKeeper.GetHashFn(suite.ctx.WithBlockHeight(2 + 2*types.NumRecentBlockHashes))
+	suite.Require().Equal(ethcmn.Hash{}, getHash(1+types.NumRecentBlockHashes))
+}
+
+func (suite *KeeperTestSuite) TestLogs() {
+	txHash1 := ethcmn.BytesToHash([]byte("tx_hash_1"))
+	txHash2 := ethcmn.BytesToHash([]byte("tx_hash_2"))
+	emptyTxHash := ethcmn.BytesToHash([]byte("tx_hash_empty"))
+
+	logs1 := []*ethtypes.Log{
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x1")}, Data: []byte{0x1}, BlockNumber: 1, TxHash: txHash1},
+	}
+	logs2 := []*ethtypes.Log{
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x2")}, Data: []byte{0x2}, BlockNumber: 1, TxHash: txHash2},
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x3")}, Data: []byte{0x3}, BlockNumber: 1, TxHash: txHash2, Index: 1},
+	}
+
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(suite.ctx, txHash1, logs1))
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(suite.ctx, txHash2, logs2))
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(suite.ctx, emptyTxHash, []*ethtypes.Log{}))
+
+	logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, txHash1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(logs1, logs)
+
+	logs, err = suite.app.EvmKeeper.GetLogs(suite.ctx, txHash2)
+	suite.Require().NoError(err)
+	suite.Require().Equal(logs2, logs)
+
+	// require empty logs to round-trip to an empty slice
+	logs, err = suite.app.EvmKeeper.GetLogs(suite.ctx, emptyTxHash)
+	suite.Require().NoError(err)
+	suite.Require().NotNil(logs)
+	suite.Require().Empty(logs)
+
+	logs, err = suite.app.EvmKeeper.AllLogs(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().Len(logs, 3)
+
+	logs, err = suite.app.EvmKeeper.GetBlockLogs(suite.ctx, 1)
+	suite.Require().NoError(err)
+	suite.Require().Len(logs, 3)
+
+	logs, err = suite.app.EvmKeeper.GetBlockLogs(suite.ctx, 2)
+	suite.Require().NoError(err)
+	suite.Require().Empty(logs)
+}
+
+func (suite *KeeperTestSuite) TestRevertBlockLogs() {
+	txHash1 := ethcmn.BytesToHash([]byte("tx_hash_1"))
+	txHash2 := ethcmn.BytesToHash([]byte("tx_hash_2"))
+	txHash3 := ethcmn.BytesToHash([]byte("tx_hash_3"))
+
+	ctx1, ctx2 := suite.ctx.WithBlockHeight(1), suite.ctx.WithBlockHeight(2)
+	logs1 := []*ethtypes.Log{
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x1")}, Data: []byte{0x1}, BlockNumber: 1, TxHash: txHash1},
+	}
+	logs2 := []*ethtypes.Log{
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x2")}, Data: []byte{0x2}, BlockNumber: 2, TxHash: txHash2},
+		{Address: address, Topics: []ethcmn.Hash{ethcmn.HexToHash("0x3")}, Data: []byte{0x3}, BlockNumber: 2, TxHash: txHash3, Index: 1},
+	}
+
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx1, txHash1, logs1))
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx2, txHash2, logs2[:1]))
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx2, txHash3, logs2[1:]))
+
+	// simulate a reorg rolling back the block 2
+	removed, err := suite.app.EvmKeeper.RevertBlockLogs(ctx2, 2)
+	suite.Require().NoError(err)
+	suite.Require().Len(removed, len(logs2))
+	for i, log := range removed {
+		suite.Require().True(log.Removed)
+		suite.Require().False(logs2[i].Removed, "the original logs must be left unchanged")
+
+		expected := *logs2[i]
+		expected.Removed = true
+		suite.Require().Equal(&expected, log)
+	}
+
+	// require the logs of the block 2 to be deleted and the ones of block 1 kept
+	logs, err := suite.app.EvmKeeper.GetBlockLogs(ctx2, 2)
+	suite.Require().NoError(err)
+	suite.Require().Empty(logs)
+
+	logs, err = suite.app.EvmKeeper.GetLogs(ctx2, txHash2)
+	suite.Require().NoError(err)
+	suite.Require().Empty(logs)
+
+	logs, err = suite.app.EvmKeeper.GetBlockLogs(ctx2, 1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(logs1, logs)
+
+	// require a second revert of the same height to be a no-op
+	removed, err = suite.app.EvmKeeper.RevertBlockLogs(ctx2, 2)
+	suite.Require().NoError(err)
+	suite.Require().Empty(removed)
+}
+
+func (suite *KeeperTestSuite) TestFilterLogs() {
+	address2 := ethcmn.BytesToAddress([]byte("address_2"))
+	topicA := ethcmn.HexToHash("0xa")
+	topicB := ethcmn.HexToHash("0xb")
+	topicC := ethcmn.HexToHash("0xc")
+
+	// block 1 logs, with the bloom stored in the KVStore
+	ctx1 := suite.ctx.WithBlockHeight(1)
+	logs1 := []*ethtypes.Log{
+		{Address: address, Topics: []ethcmn.Hash{topicA, topicB}, BlockNumber: 1},
+		{Address: address2, Topics: []ethcmn.Hash{topicC}, BlockNumber: 1},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx1, ethcmn.BytesToHash([]byte("tx_hash_1")), logs1))
+	suite.Require().NoError(suite.app.EvmKeeper.SetBlockBloomMapping(ctx1, ethtypes.BytesToBloom(ethtypes.LogsBloom(logs1).Bytes()), 1))
+
+	// block 2 logs, with the bloom aggregated by the keeper
+	ctx2 := suite.ctx.WithBlockHeight(2)
+	logs2 := []*ethtypes.Log{
+		{Address: address2, Topics: []ethcmn.Hash{topicA}, BlockNumber: 2},
+		{Address: address, Topics: []ethcmn.Hash{topicB, topicC}, BlockNumber: 2},
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx2, ethcmn.BytesToHash([]byte("tx_hash_2")), logs2))
+	suite.app.EvmKeeper.Bloom = ethtypes.LogsBloom(logs2)
+
+	testCases := []struct {
+		name     string
+		filter   types.LogFilter
+		expected []*ethtypes.Log
+	}{
+		{"all logs", types.LogFilter{FromBlock: 1}, append(logs1, logs2...)},
+		{"latest block only", types.LogFilter{}, logs2},
+		{"first block only", types.LogFilter{FromBlock: 1, ToBlock: 1}, logs1},
+		{"address", types.LogFilter{FromBlock: 1, Addresses: []ethcmn.Address{address}}, []*ethtypes.Log{logs1[0], logs2[1]}},
+		{"unknown address", types.LogFilter{FromBlock: 1, Addresses: []ethcmn.Address{ethcmn.BytesToAddress([]byte("unknown"))}}, []*ethtypes.Log{}},
+		{"first topic", types.LogFilter{FromBlock: 1, Topics: [][]ethcmn.Hash{{topicA}}}, []*ethtypes.Log{logs1[0], logs2[0]}},
+		{"wildcard first topic", types.LogFilter{FromBlock: 1, Topics: [][]ethcmn.Hash{nil, {topicC}}}, []*ethtypes.Log{logs2[1]}},
+		{"topic OR set", types.LogFilter{FromBlock: 1, Topics: [][]ethcmn.Hash{{topicA, topicC}}}, []*ethtypes.Log{logs1[0], logs1[1], logs2[0]}},
+		{"address and topic", types.LogFilter{FromBlock: 1, Addresses: []ethcmn.Address{address2}, Topics: [][]ethcmn.Hash{{topicA}}}, []*ethtypes.Log{logs2[0]}},
+		{"to block after latest", types.LogFilter{FromBlock: 2, ToBlock: 10, Addresses: []ethcmn.Address{address}}, []*ethtypes.Log{logs2[1]}},
+	}
+
+	for _, tc := range testCases {
+		logs, err := suite.app.EvmKeeper.FilterLogs(ctx2, tc.filter)
+		suite.Require().NoError(err, tc.name)
+		suite.Require().Equal(tc.expected, logs, tc.name)
+	}
+
+	// require the block range to be capped to the max filter blocks
+	suite.app.EvmKeeper.SetMaxFilterBlocks(1)
+
+	_, err := suite.app.EvmKeeper.FilterLogs(ctx2, types.LogFilter{FromBlock: 1})
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "exceed maximum block range: 1")
+
+	logs, err := suite.app.EvmKeeper.FilterLogs(ctx2, types.LogFilter{FromBlock: 2})
+	suite.Require().NoError(err)
+	suite.Require().Equal(logs2, logs)
+
+	// require the matching logs to be capped to the max filter logs, the querier
+	// sharing the limits of the keeper
+	suite.app.EvmKeeper.SetMaxFilterBlocks(keeper.DefaultMaxFilterBlocks)
+	suite.app.EvmKeeper.SetMaxFilterLogs(3)
+
+	_, err = suite.app.EvmKeeper.FilterLogs(ctx2, types.LogFilter{FromBlock: 1})
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "query returned more than 3 results")
+
+	logs, err = suite.app.EvmKeeper.FilterLogs(ctx2, types.LogFilter{FromBlock: 1, Addresses: []ethcmn.Address{address}})
+	suite.Require().NoError(err)
+	suite.Require().Len(logs, 2)
+
+	filter, err := suite.app.Codec().MarshalJSON(types.LogFilter{FromBlock: 1})
+	suite.Require().NoError(err)
+	_, err = suite.querier(ctx2, []string{types.QueryFilterLogs}, abci.RequestQuery{Data: filter})
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "query returned more than 3 results")
+}
+
+func (suite *KeeperTestSuite) TestParams() {
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	suite.Require().Equal(types.DefaultParams(), params)
+
+	params.MinGasPrice = sdk.NewInt(10)
+	params.MaxGasPrice = sdk.NewInt(1000)
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	suite.Require().Equal(params, suite.app.EvmKeeper.GetParams(suite.ctx))
+}
+
+func (suite *KeeperTestSuite) TestTraceTx() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	chainID := big.NewInt(3)
+
+	// PUSH1 0x01 PUSH1 0x00 SSTORE
+	initCode := ethcmn.FromHex("0x6001600055")
+
+	predecessor := types.NewMsgEthereumTx(0, nil, big.NewInt(0), 100000, big.NewInt(1), initCode)
+	predecessor.Sign(chainID, priv.ToECDSA())
+	msg := types.NewMsgEthereumTx(1, nil, big.NewInt(0), 100000, big.NewInt(1), initCode)
+	msg.Sign(chainID, priv.ToECDSA())
+
+	predecessorBz, err := suite.app.Codec().MarshalBinaryLengthPrefixed(predecessor)
+	suite.Require().NoError(err)
+	msgBz, err := suite.app.Codec().MarshalBinaryLengthPrefixed(msg)
+	suite.Require().NoError(err)
+
+	req := types.TraceTxRequest{
+		Predecessors: [][]byte{predecessorBz},
+		Tx:           msgBz,
+		BlockHeight:  1,
+		BlockTime:    time.Now().UTC(),
+	}
+
+	// trace on a cached context as done by the ABCI queries
+	cacheCtx, _ := suite.ctx.CacheContext()
+	res, err := suite.app.EvmKeeper.TraceTx(cacheCtx, req)
+	suite.Require().NoError(err)
+	suite.Require().False(res.Failed)
+	suite.Require().Len(res.StructLogs, 4)
+
+	ops := []string{"PUSH1", "PUSH1", "SSTORE", "STOP"}
+	for i, log := range res.StructLogs {
+		suite.Require().Equal(ops[i], log.Op)
+		suite.Require().Equal(1, log.Depth)
+	}
+
+	sstore := res.StructLogs[2]
+	suite.Require().Equal([]string{
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+	}, sstore.Stack)
+	suite.Require().Equal(map[string]string{
+		"0000000000000000000000000000000000000000000000000000000000000000": "0000000000000000000000000000000000000000000000000000000000000001",
+	}, sstore.Storage)
+
+	// require the disabled fields not to be captured
+	req.Config = types.TraceConfig{DisableStack: true, DisableMemory: true, DisableStorage: true}
+	cacheCtx, _ = suite.ctx.CacheContext()
+	res, err = suite.app.EvmKeeper.TraceTx(cacheCtx, req)
+	suite.Require().NoError(err)
+	suite.Require().Len(res.StructLogs, 4)
+	suite.Require().Nil(res.StructLogs[2].Stack)
+	suite.Require().Nil(res.StructLogs[2].Storage)
+
+	// require the replay not to modify the state
+	contractAddr := types.GetContractAddress(sender, 1)
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, contractAddr))
+	suite.Require().Equal(uint64(0), suite.app.EvmKeeper.GetNonce(suite.ctx, sender))
+}
+
+func (suite *KeeperTestSuite) TestTraceCallTx() {
+	priv, err := crypto.GenerateKey()
+	suite.Require().NoError(err)
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	chainID := big.NewInt(3)
+
+	// callee runtime code returning 42:
+	// PUSH1 0x2a PUSH1 0x00 MSTORE PUSH1 0x20 PUSH1 0x00 RETURN
+	callee := types.GetContractAddress(sender, 0)
+	calleeCode := ethcmn.FromHex("0x600a600c600039600a6000f3602a60005260206000f3")
+
+	// caller runtime code returning the output of the callee:
+	// PUSH1 0x20 PUSH1 0x00 PUSH1 0x00 PUSH1 0x00 PUSH1 0x00 PUSH20 <callee> GAS CALL
+	// POP PUSH1 0x20 PUSH1 0x00 RETURN
+	caller := types.GetContractAddress(sender, 1)
+	callerRuntime := append(append(ethcmn.FromHex("0x6020600060006000600073"), callee.Bytes()...), ethcmn.FromHex("0x5af15060206000f3")...)
+	callerCode := append(ethcmn.FromHex("0x6027600c60003960276000f3"), callerRuntime...)
+
+	var predecessors [][]byte
+	for i, code := range [][]byte{calleeCode, callerCode} {
+		tx := types.NewMsgEthereumTx(uint64(i), nil, big.NewInt(0), 100000, big.NewInt(1), code)
+		tx.Sign(chainID, priv.ToECDSA())
+		bz, err := suite.app.Codec().MarshalBinaryLengthPrefixed(tx)
+		suite.Require().NoError(err)
+		predecessors = append(predecessors, bz)
+	}
+
+	msg := types.NewMsgEthereumTx(2, &caller, big.NewInt(0), 100000, big.NewInt(1), nil)
+	msg.Sign(chainID, priv.ToECDSA())
+	msgBz, err := suite.app.Codec().MarshalBinaryLengthPrefixed(msg)
+	suite.Require().NoError(err)
+
+	req := types.TraceTxRequest{
+		Predecessors: predecessors,
+		Tx:           msgBz,
+		BlockHeight:  1,
+		BlockTime:    time.Now().UTC(),
+		Config:       types.TraceConfig{Tracer: types.CallTracerName},
+	}
+
+	cacheCtx, _ := suite.ctx.CacheContext()
+	res, err := suite.app.EvmKeeper.TraceCallTx(cacheCtx, req)
+	suite.Require().NoError(err)
+
+	output := hexutil.Encode(ethcmn.LeftPadBytes([]byte{42}, 32))
+	suite.Require().Equal("CALL", res.Type)
+	suite.Require().Equal(hexutil.Encode(sender.Bytes()), res.From)
+	suite.Require().Equal(hexutil.Encode(caller.Bytes()), res.To)
+	suite.Require().Equal(output, res.Output)
+	suite.Require().Empty(res.Error)
+
+	// require the internal call to be nested in the transaction frame
+	suite.Require().Len(res.Calls, 1)
+	call := res.Calls[0]
+	suite.Require().Equal("CALL", call.Type)
+	suite.Require().Equal(hexutil.Encode(caller.Bytes()), call.From)
+	suite.Require().Equal(hexutil.Encode(callee.Bytes()), call.To)
+	suite.Require().Equal("0x0", call.Value)
+	suite.Require().Equal("0x", call.Input)
+	suite.Require().Equal(output, call.Output)
+	suite.Require().NotEmpty(call.GasUsed)
+	suite.Require().Empty(call.Calls)
+
+	// require the tracer to be selected by the trace query
+	bz, err := suite.app.Codec().MarshalJSON(req)
+	suite.Require().NoError(err)
+
+	cacheCtx, _ = suite.ctx.CacheContext()
+	resBz, err := suite.querier(cacheCtx, []string{types.QueryTraceTx}, abci.RequestQuery{Data: bz})
+	suite.Require().NoError(err)
+
+	var frame types.CallFrame
+	suite.Require().NoError(suite.app.Codec().UnmarshalJSON(resBz, &frame))
+	suite.Require().Equal(*res, frame)
+
+	req.Config.Tracer = "prestateTracer"
+	bz, err = suite.app.Codec().MarshalJSON(req)
+	suite.Require().NoError(err)
+	_, err = suite.querier(cacheCtx, []string{types.QueryTraceTx}, abci.RequestQuery{Data: bz})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestCoinDecimals() {
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.CoinDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+	suite.Require().Equal(uint32(6), suite.app.EvmKeeper.GetCoinDecimals(suite.ctx))
+
+	suite.app.EvmKeeper.CreateAccount(suite.ctx, address)
+
+	// 1 coin unit is 10^12 wei
+	suite.app.EvmKeeper.SetBalance(suite.ctx, address, big.NewInt(2000000000000))
+	suite.Require().Equal(0, big.NewInt(2000000000000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, address)))
+
+	so := suite.app.EvmKeeper.GetOrNewStateObject(suite.ctx, address)
+	suite.Require().Equal(0, big.NewInt(2000000000000).Cmp(so.Balance()))
+
+	// amounts not representable in the coin denomination don't modify the balance
+	suite.app.EvmKeeper.AddBalance(suite.ctx, address, big.NewInt(999999999999))
+	suite.app.EvmKeeper.SubBalance(suite.ctx, address, big.NewInt(1))
+	suite.Require().Equal(0, big.NewInt(2000000000000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, address)))
+
+	suite.app.EvmKeeper.SubBalance(suite.ctx, address, big.NewInt(1000000000000))
+	suite.Require().Equal(0, big.NewInt(1000000000000).Cmp(suite.app.EvmKeeper.GetBalance(suite.ctx, address)))
+}
+
+func (suite *KeeperTestSuite) TestQueryStorage() {
+	key := ethcmn.HexToHash("0x1")
+	value := ethcmn.BytesToHash([]byte("value"))
+
+	suite.app.EvmKeeper.CreateAccount(suite.ctx, address)
+	suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx).SetState(address, key, value)
+	suite.Require().Equal(value, suite.app.EvmKeeper.GetState(suite.ctx, address, key))
+
+	testCases := []struct {
+		name     string
+		key      ethcmn.Hash
+		expected ethcmn.Hash
+	}{
+		{"set slot", key, value},
+		{"unset slot", ethcmn.HexToHash("0x2"), ethcmn.Hash{}},
+	}
+
+	for _, tc := range testCases {
+		path := []string{types.QueryStorage, address.Hex(), tc.key.Hex()}
+		bz, err := suite.querier(suite.ctx, path, abci.RequestQuery{})
+		suite.Require().NoError(err, tc.name)
+
+		var res types.QueryResStorage
+		suite.Require().NoError(suite.app.Codec().UnmarshalJSON(bz, &res), tc.name)
+		suite.Require().Equal(tc.expected.Bytes(), res.Value, tc.name)
+	}
+}
+
+func (suite *KeeperTestSuite) TestQueryStorageDump() {
+	other := ethcmn.BytesToAddress([]byte("other"))
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	// the slots are set in reverse order to require the dump to be sorted
+	var expected types.Storage
+	for i := 5; i > 0; i-- {
+		key, value := ethcmn.BigToHash(big.NewInt(int64(i))), ethcmn.BytesToHash([]byte(fmt.Sprintf("value_%d", i)))
+		stateDB.SetState(address, key, value)
+		expected = append(types.Storage{types.NewState(key, value)}, expected...)
+	}
+
+	// slots of another account and a cleared slot
+	stateDB.SetState(other, ethcmn.BigToHash(big.NewInt(1)), ethcmn.BytesToHash([]byte("other_value")))
+	stateDB.SetState(address, ethcmn.BigToHash(big.NewInt(6)), ethcmn.BytesToHash([]byte("cleared")))
+	suite.Require().NoError(stateDB.Finalise(false))
+	stateDB.SetState(address, ethcmn.BigToHash(big.NewInt(6)), ethcmn.Hash{})
+
+	testCases := []struct {
+		name     string
+		addr     ethcmn.Address
+		expected types.Storage
+	}{
+		{"account storage", address, expected},
+		{"empty storage", ethcmn.BytesToAddress([]byte("empty")), nil},
+	}
+
+	for _, tc := range testCases {
+		path := []string{types.QueryStorageDump, tc.addr.Hex()}
+		bz, err := suite.querier(suite.ctx, path, abci.RequestQuery{})
+		suite.Require().NoError(err, tc.name)
+
+		var res types.QueryResStorageDump
+		suite.Require().NoError(suite.app.Codec().UnmarshalJSON(bz, &res), tc.name)
+		suite.Require().Equal(tc.expected, res.Storage, tc.name)
+	}
+
+	// require the keys and values to be hex encoded 32 bytes hashes
+	bz, err := suite.querier(suite.ctx, []string{types.QueryStorageDump, address.Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	suite.Require().Contains(string(bz), `"key": "0x0000000000000000000000000000000000000000000000000000000000000001"`)
+
+	// require an empty storage to be dumped as an empty list
+	bz, err = suite.querier(suite.ctx, []string{types.QueryStorageDump, ethcmn.BytesToAddress([]byte("empty")).Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	suite.Require().Contains(string(bz), `"storage": []`)
+}
+
+func (suite *KeeperTestSuite) TestQueryAccountSummary() {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	eoa := ethcmn.BytesToAddress([]byte("eoa"))
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	stateDB.SetCode(contract, []byte("code"))
+	stateDB.SetNonce(contract, 1)
+	stateDB.SetState(contract, ethcmn.BigToHash(big.NewInt(1)), ethcmn.BytesToHash([]byte("value_1")))
+	stateDB.SetState(contract, ethcmn.BigToHash(big.NewInt(2)), ethcmn.BytesToHash([]byte("value_2")))
+	stateDB.SetBalance(eoa, big.NewInt(100))
+	stateDB.SetNonce(eoa, 3)
+	suite.Require().NoError(stateDB.Finalise(false))
+
+	testCases := []struct {
+		name     string
+		addr     ethcmn.Address
+		expected types.QueryResAccountSummary
+	}{
+		{
+			"contract", contract,
+			types.QueryResAccountSummary{
+				Address: contract.Hex(), CosmosAddress: sdk.AccAddress(contract.Bytes()), Contract: true,
+				Balance: sdk.NewInt64Coin(emint.DenomDefault, 0), Nonce: 1, CodeSize: 4, StorageSlots: 2,
+			},
+		},
+		{
+			"eoa", eoa,
+			types.QueryResAccountSummary{
+				Address: eoa.Hex(), CosmosAddress: sdk.AccAddress(eoa.Bytes()), Contract: false,
+				Balance: sdk.NewInt64Coin(emint.DenomDefault, 100), Nonce: 3,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		bz, err := suite.querier(suite.ctx, []string{types.QueryAccountSummary, tc.addr.Hex()}, abci.RequestQuery{})
+		suite.Require().NoError(err, tc.name)
+
+		var res types.QueryResAccountSummary
+		suite.Require().NoError(suite.app.Codec().UnmarshalJSON(bz, &res), tc.name)
+		suite.Require().Equal(tc.expected, res, tc.name)
+	}
+}
+
+func (suite *KeeperTestSuite) TestQueryParams() {
+	params := types.NewParams(sdk.NewInt(10), sdk.NewInt(100))
+	params.BlockGasLimit = 1000000
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryParams}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.Params
+	suite.Require().NoError(suite.app.Codec().UnmarshalJSON(bz, &res))
+	suite.Require().Equal(params, res)
+
+	// require the params changed through a governance proposal to be returned
+	proposal := sdkparams.NewParameterChangeProposal("evm denom", "change the evm denom", []sdkparams.ParamChange{
+		sdkparams.NewParamChange(types.DefaultParamspace, string(types.KeyEvmDenom), `"aevmos"`),
+	})
+	suite.Require().NoError(sdkparams.NewParamChangeProposalHandler(suite.app.ParamsKeeper)(suite.ctx, proposal))
+
+	bz, err = suite.querier(suite.ctx, []string{types.QueryParams}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(suite.app.Codec().UnmarshalJSON(bz, &res))
+	params.EvmDenom = "aevmos"
+	suite.Require().Equal(params, res)
+}
+
+func (suite *KeeperTestSuite) TestQueryTxReceipt() {
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+	contractAddress := ethcmn.BytesToAddress([]byte("contract"))
+	receipt := types.TxReceipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 200,
+		GasUsed:           100,
+		Bloom:             ethtypes.BytesToBloom([]byte("bloom")),
+		Logs: []*ethtypes.Log{
+			{Address: contractAddress, Topics: []ethcmn.Hash{txHash}, Data: []byte("log"), BlockNumber: 1, TxHash: txHash},
+		},
+		TxHash:          txHash,
+		ContractAddress: &contractAddress,
+		From:            address,
+		BlockHeight:     1,
+		TxIndex:         2,
+	}
+	suite.Require().NoError(suite.app.EvmKeeper.SetTxReceipt(suite.ctx, receipt, txHash.Bytes()))
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryTxReceipt, txHash.Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	res, decodeErr := types.DecodeTxReceipt(bz)
+	suite.Require().NoError(decodeErr)
+	suite.Require().Equal(receipt, res)
+
+	// require an error for an unknown transaction
+	_, err = suite.querier(suite.ctx, []string{types.QueryTxReceipt, ethcmn.Hash{}.Hex()}, abci.RequestQuery{})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestGetTxsByRecipient() {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	other := ethcmn.BytesToAddress([]byte("other"))
+
+	// store the receipts of the transactions sent to the contract at each of the
+	// blocks 1 to 5, two of them at the block 3, and to another recipient
+	var receipts []types.TxReceipt
+	setTx := func(to ethcmn.Address, height int64, txIndex uint64) types.TxReceipt {
+		txHash := ethcmn.BytesToHash([]byte(fmt.Sprintf("tx_hash_%s_%d_%d", to.Hex(), height, txIndex)))
+		receipt := types.TxReceipt{
+			Status:      types.ReceiptStatusSuccessful,
+			TxHash:      txHash,
+			From:        address,
+			To:          &to,
+			BlockHeight: uint64(height),
+			TxIndex:     txIndex,
+		}
+		suite.Require().NoError(suite.app.EvmKeeper.SetTxReceipt(suite.ctx, receipt, txHash.Bytes()))
+		suite.app.EvmKeeper.SetRecipientTx(suite.ctx, to, height, txIndex, txHash.Bytes())
+		return receipt
+	}
+
+	for height := int64(1); height <= 5; height++ {
+		setTx(other, height, 0)
+		receipts = append(receipts, setTx(contract, height, 1))
+		if height == 3 {
+			receipts = append(receipts, setTx(contract, height, 2))
+		}
+	}
+
+	ctx := suite.ctx.WithBlockHeight(5)
+
+	// require the transactions of the block range to be ordered by height and index
+	res, err := suite.app.EvmKeeper.GetTxsByRecipient(ctx, contract, 2, 4)
+	suite.Require().NoError(err)
+	suite.Require().Equal(receipts[1:5], res)
+
+	// require a zero height to be the latest one
+	res, err = suite.app.EvmKeeper.GetTxsByRecipient(ctx, contract, 1, 0)
+	suite.Require().NoError(err)
+	suite.Require().Equal(receipts, res)
+
+	res, err = suite.app.EvmKeeper.GetTxsByRecipient(ctx, ethcmn.BytesToAddress([]byte("unknown")), 1, 5)
+	suite.Require().NoError(err)
+	suite.Require().Empty(res)
+
+	// require the results to be capped to the max recipient transactions
+	suite.app.EvmKeeper.SetMaxRecipientTxs(3)
+
+	res, err = suite.app.EvmKeeper.GetTxsByRecipient(ctx, contract, 1, 5)
+	suite.Require().NoError(err)
+	suite.Require().Equal(receipts[:3], res)
+
+	// require the query to return the amino encoded receipts, the querier sharing
+	// the max recipient transactions of the keeper
+	bz, err := suite.querier(ctx, []string{types.QueryTxsByRecipient, contract.Hex(), "3", "5"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var queryRes types.QueryResTxReceipts
+	suite.Require().NoError(types.ModuleCdc.UnmarshalBinaryLengthPrefixed(bz, &queryRes))
+	suite.Require().Equal(receipts[2:5], queryRes.Receipts)
+
+	_, err = suite.querier(ctx, []string{types.QueryTxsByRecipient, "0x1234", "3", "5"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestWithHeight() {
+	key := ethcmn.HexToHash("0x1")
+
+	// commit writes the value in a new version of the root multistore and
+	// returns its height
+	commit := func(application *app.EthermintApp, value ethcmn.Hash) int64 {
+		cms := application.CommitMultiStore()
+		cacheMS := cms.CacheMultiStore()
+		ctx := sdk.NewContext(cacheMS, abci.Header{ChainID: "3"}, false, log.NewNopLogger())
+
+		application.EvmKeeper.SetState(ctx, address, key, value)
+		_, err := application.EvmKeeper.Commit(ctx, false)
+		suite.Require().NoError(err)
+		application.EvmKeeper.CommitStateDB.ClearStateObjects()
+
+		cacheMS.Write()
+		return cms.Commit().Version
+	}
+
+	archiveApp := app.NewEthermintApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, 0, bam.SetPruning(store.PruneNothing))
+	k := archiveApp.EvmKeeper
+
+	height := commit(archiveApp, ethcmn.HexToHash("0x2"))
+	commit(archiveApp, ethcmn.HexToHash("0x3"))
+
+	ctx := sdk.NewContext(archiveApp.CommitMultiStore(), abci.Header{ChainID: "3"}, true, log.NewNopLogger())
+
+	// require the past and latest heights to read their committed value
+	pastCtx, err := k.WithHeight(ctx, height)
+	suite.Require().NoError(err)
+	suite.Require().Equal(height, pastCtx.BlockHeight())
+	suite.Require().Equal(ethcmn.HexToHash("0x2"), k.GetState(pastCtx, address, key))
+	k.CommitStateDB.ClearStateObjects()
+
+	latestCtx, err := k.WithHeight(ctx, height+1)
+	suite.Require().NoError(err)
+	suite.Require().Equal(ethcmn.HexToHash("0x3"), k.GetState(latestCtx, address, key))
+
+	// require the heights beyond the latest one to be invalid
+	_, err = k.WithHeight(ctx, height+2)
+	suite.Require().True(emint.ErrInvalidValue.Is(err), err)
+	_, err = k.WithHeight(ctx, 0)
+	suite.Require().True(emint.ErrInvalidValue.Is(err), err)
+
+	// require the cache wrapped multistores to be rejected
+	_, err = k.WithHeight(suite.ctx, height)
+	suite.Require().Error(err)
+
+	// require a pruned height to be reported
+	pruningApp := app.NewEthermintApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, 0, bam.SetPruning(store.PruneEverything))
+	height = commit(pruningApp, ethcmn.HexToHash("0x2"))
+	commit(pruningApp, ethcmn.HexToHash("0x3"))
+
+	ctx = sdk.NewContext(pruningApp.CommitMultiStore(), abci.Header{ChainID: "3"}, true, log.NewNopLogger())
+	_, err = pruningApp.EvmKeeper.WithHeight(ctx, height)
+	suite.Require().True(emint.ErrStatePruned.Is(err), err)
+}
@@ -0,0 +1,77 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	emint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// GetParams returns the total set of evm parameters. Parameters missing from the
+// store, eg. on chains upgraded from a version without them, are set to their
+// default values.
+func (k *Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	params = types.DefaultParams()
+	for _, pair := range params.ParamSetPairs() {
+		k.paramSpace.GetIfExists(ctx, pair.Key, pair.Value)
+	}
+	return params
+}
+
+// SetParams sets the evm parameters to the param space.
+func (k *Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// GetEvmDenom returns the coin denomination holding the account balances.
+func (k *Keeper) GetEvmDenom(ctx sdk.Context) string {
+	denom := emint.DenomDefault
+	k.paramSpace.GetIfExists(ctx, types.KeyEvmDenom, &denom)
+	return denom
+}
+
+// GetCoinDecimals returns the number of decimals of the coin denomination
+// holding the account balances.
+func (k *Keeper) GetCoinDecimals(ctx sdk.Context) uint32 {
+	decimals := types.DefaultCoinDecimals
+	k.paramSpace.GetIfExists(ctx, types.KeyCoinDecimals, &decimals)
+	return decimals
+}
+
+// GetBlockGasLimit returns the total gas that can be used by the EVM
+// transactions of a block, zero meaning no limit.
+func (k *Keeper) GetBlockGasLimit(ctx sdk.Context) uint64 {
+	var limit uint64
+	k.paramSpace.GetIfExists(ctx, types.KeyBlockGasLimit, &limit)
+	return limit
+}
+
+// GetHardfork returns the hardfork selecting the set of precompiled contracts
+// available to the EVM.
+func (k *Keeper) GetHardfork(ctx sdk.Context) string {
+	hardfork := types.DefaultHardfork
+	k.paramSpace.GetIfExists(ctx, types.KeyHardfork, &hardfork)
+	return hardfork
+}
+
+// GetBurnBaseFee returns true if the base fee of the gas used by the Ethereum
+// transactions is burned.
+func (k *Keeper) GetBurnBaseFee(ctx sdk.Context) bool {
+	burn := true
+	k.paramSpace.GetIfExists(ctx, types.KeyBurnBaseFee, &burn)
+	return burn
+}
+
+// GetInitialBaseFee returns the base fee, in wei, of the first block with a
+// base fee.
+func (k *Keeper) GetInitialBaseFee(ctx sdk.Context) sdk.Int {
+	baseFee := sdk.ZeroInt()
+	k.paramSpace.GetIfExists(ctx, types.KeyInitialBaseFee, &baseFee)
+	return baseFee
+}
+
+// GetMinBaseFee returns the floor, in wei, of the base fee.
+func (k *Keeper) GetMinBaseFee(ctx sdk.Context) sdk.Int {
+	baseFee := sdk.ZeroInt()
+	k.paramSpace.GetIfExists(ctx, types.KeyMinBaseFee, &baseFee)
+	return baseFee
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/utils"
 	"github.com/cosmos/ethermint/version"
 	"github.com/cosmos/ethermint/x/evm/types"
@@ -35,6 +36,8 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			bz, err = queryCode(ctx, path, keeper)
 		case types.QueryNonce:
 			bz, err = queryNonce(ctx, path, keeper)
+		case types.QueryPendingNonce:
+			bz, err = queryPendingNonce(ctx, path, keeper)
 		case types.QueryHashToHeight:
 			bz, err = queryHashToHeight(ctx, path, keeper)
 		case types.QueryTxLogs:
@@ -45,6 +48,26 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			bz, err = queryLogs(ctx, keeper)
 		case types.QueryAccount:
 			bz, err = queryAccount(ctx, path, keeper)
+		case types.QueryFilterLogs:
+			bz, err = queryFilterLogs(ctx, req, keeper)
+		case types.QueryTxHash:
+			bz, err = queryTxHash(ctx, path, keeper)
+		case types.QueryTraceTx:
+			bz, err = queryTraceTx(ctx, req, keeper)
+		case types.QueryParams:
+			bz, err = queryParams(ctx, keeper)
+		case types.QueryTxReceipt:
+			bz, err = queryTxReceipt(ctx, path, keeper)
+		case types.QueryBaseFee:
+			bz, err = queryBaseFee(ctx, keeper)
+		case types.QueryStorageDump:
+			bz, err = queryStorageDump(ctx, path, keeper)
+		case types.QueryAccountSummary:
+			bz, err = queryAccountSummary(ctx, path, keeper)
+		case types.QueryStateRoot:
+			bz, err = queryStateRoot(ctx, path, keeper)
+		case types.QueryTxsByRecipient:
+			bz, err = queryTxsByRecipient(ctx, path, keeper)
 		default:
 			bz, err = nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
 		}
@@ -100,6 +123,61 @@ func queryStorage(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error)
 	return bz, nil
 }
 
+func queryStorageDump(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	addr := ethcmn.HexToAddress(path[1])
+	storage, err := keeper.GetAccountStorage(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	// amino encodes an empty slice as null
+	if storage == nil {
+		storage = types.Storage{}
+	}
+
+	res := types.QueryResStorageDump{Storage: storage}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryAccountSummary(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	addr := ethcmn.HexToAddress(path[1])
+
+	// the balance is held in the evm denomination, hence representable in it
+	balance, err := types.WeiToCoin(keeper.GetBalance(ctx, addr), keeper.GetCoinDecimals(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	storageSlots := 0
+	err = keeper.ForEachStorage(ctx, addr, func(_, _ ethcmn.Hash) bool {
+		storageSlots++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	codeSize := keeper.GetCodeSize(ctx, addr)
+	res := types.QueryResAccountSummary{
+		Address:       addr.Hex(),
+		CosmosAddress: emint.CosmosFromEthAddress(addr),
+		Contract:      codeSize > 0,
+		Balance:       sdk.NewCoin(keeper.GetEvmDenom(ctx), balance),
+		Nonce:         keeper.GetNonce(ctx, addr),
+		CodeSize:      codeSize,
+		StorageSlots:  storageSlots,
+	}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
 func queryCode(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	addr := ethcmn.HexToAddress(path[1])
 	code := keeper.GetCode(ctx, addr)
@@ -124,6 +202,18 @@ func queryNonce(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	return bz, nil
 }
 
+func queryPendingNonce(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	addr := ethcmn.HexToAddress(path[1])
+	nonce := keeper.GetPendingNonce(ctx, addr)
+	nRes := types.QueryResNonce{Nonce: nonce}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, nRes)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
 func queryHashToHeight(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	blockHash := ethcmn.FromHex(path[1])
 	blockNumber := keeper.GetBlockHashMapping(ctx, blockHash)
@@ -143,12 +233,28 @@ func queryBlockLogsBloom(ctx sdk.Context, path []string, keeper Keeper) ([]byte,
 		return nil, fmt.Errorf("could not unmarshal block number: %w", err)
 	}
 
-	bloom, err := keeper.GetBlockBloomMapping(ctx, num)
+	// blocks without a stored bloom (eg. the genesis block) have an empty bloom
+	bloom, _ := keeper.GetBlockBloomMapping(ctx, num)
+
+	res := types.QueryBloomFilter{Bloom: bloom}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block bloom mapping: %w", err)
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
 
-	res := types.QueryBloomFilter{Bloom: bloom}
+	return bz, nil
+}
+
+func queryStateRoot(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	num, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal block number: %w", err)
+	}
+
+	// blocks without a stored root (eg. the genesis block) have an empty root
+	root, _ := keeper.GetStateRoot(ctx, num)
+
+	res := types.QueryResStateRoot{Root: root.Hex()}
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
@@ -174,7 +280,29 @@ func queryTxLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error)
 }
 
 func queryLogs(ctx sdk.Context, keeper Keeper) ([]byte, error) {
-	logs := keeper.AllLogs(ctx)
+	logs, err := keeper.AllLogs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res := types.QueryETHLogs{Logs: logs}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryFilterLogs(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var filter types.LogFilter
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &filter); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	logs, err := keeper.FilterLogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
 
 	res := types.QueryETHLogs{Logs: logs}
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
@@ -184,6 +312,108 @@ func queryLogs(ctx sdk.Context, keeper Keeper) ([]byte, error) {
 	return bz, nil
 }
 
+func queryTxHash(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	ethHash := ethcmn.HexToHash(path[1])
+	txHash := keeper.GetTxHashMapping(ctx, ethHash)
+	if txHash == nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "transaction %s not found", ethHash.Hex())
+	}
+
+	res := types.QueryResTxHash{Hash: txHash}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTraceTx(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var traceReq types.TraceTxRequest
+	if err := keeper.cdc.UnmarshalJSON(req.Data, &traceReq); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	var (
+		res interface{}
+		err error
+	)
+
+	switch traceReq.Config.Tracer {
+	case "":
+		res, err = keeper.TraceTx(ctx, traceReq)
+	case types.CallTracerName:
+		res, err = keeper.TraceCallTx(ctx, traceReq)
+	default:
+		err = sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unsupported tracer %s", traceReq.Config.Tracer)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryBaseFee(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	res := types.QueryResBaseFee{BaseFee: utils.MarshalBigInt(keeper.GetBaseFee(ctx))}
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTxReceipt(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	txHash := ethcmn.HexToHash(path[1])
+	receipt, err := keeper.GetTxReceipt(ctx, txHash.Bytes())
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "receipt of transaction %s not found", txHash.Hex())
+	}
+
+	// the receipt is amino encoded as its hashes and addresses don't support the
+	// amino JSON encoding
+	return types.EncodeTxReceipt(receipt)
+}
+
+func queryTxsByRecipient(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) != 4 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "expected the recipient and the from and to blocks")
+	}
+
+	if !ethcmn.IsHexAddress(path[1]) {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid recipient address %s", path[1])
+	}
+
+	fromBlock, err := strconv.ParseInt(path[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal from block number: %w", err)
+	}
+
+	toBlock, err := strconv.ParseInt(path[3], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal to block number: %w", err)
+	}
+
+	receipts, err := keeper.GetTxsByRecipient(ctx, ethcmn.HexToAddress(path[1]), fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// the receipts are amino encoded as for the transaction receipt query
+	return types.ModuleCdc.MarshalBinaryLengthPrefixed(types.QueryResTxReceipts{Receipts: receipts})
+}
+
 func queryAccount(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	addr := ethcmn.HexToAddress(path[1])
 	so := keeper.GetOrNewStateObject(ctx, addr)
@@ -0,0 +1,157 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	emint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// TraceTx re-executes a transaction with the struct logger and returns the
+// captured execution steps. The predecessors of the transaction in its block
+// are replayed first, so the context must hold the state of the previous block.
+//
+// The state changes are applied to a fresh state DB over the context store and
+// are never committed, hence the context must use a cached store (as ABCI
+// queries do).
+func (k *Keeper) TraceTx(ctx sdk.Context, req types.TraceTxRequest) (*types.ExecutionResult, error) {
+	tracer := types.NewStructLogger(req.Config)
+	gasUsed, failed, err := k.traceTx(ctx, req, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ExecutionResult{
+		Gas:         gasUsed,
+		Failed:      failed,
+		ReturnValue: fmt.Sprintf("%x", tracer.Output()),
+		StructLogs:  types.FormatLogs(tracer.StructLogs()),
+	}, nil
+}
+
+// TraceCallTx re-executes a transaction with the call tracer and returns the
+// frame of the transaction, which nests the frames of the internal calls. The
+// transaction is replayed as by TraceTx.
+func (k *Keeper) TraceCallTx(ctx sdk.Context, req types.TraceTxRequest) (*types.CallFrame, error) {
+	tracer := types.NewCallTracer()
+	gasUsed, _, err := k.traceTx(ctx, req, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	frame := tracer.Result()
+	if frame == nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "transaction not executed by the EVM")
+	}
+
+	// the transaction frame reports the gas of the transaction, including the
+	// intrinsic gas
+	frame.GasUsed = hexutil.EncodeUint64(gasUsed)
+	return frame, nil
+}
+
+// traceTx replays the predecessors of a transaction then executes it with the
+// given tracer and returns the gas used and whether the traced transaction
+// failed.
+func (k *Keeper) traceTx(ctx sdk.Context, req types.TraceTxRequest, tracer vm.Tracer) (uint64, bool, error) {
+	chainID, err := emint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return 0, false, err
+	}
+
+	ctx = ctx.WithBlockHeight(req.BlockHeight).WithBlockTime(req.BlockTime)
+
+	// use a new state DB so that the replay doesn't modify the keeper state objects
+	csdb := k.CommitStateDB.Copy().WithContext(ctx).WithCoinDecimals(k.GetCoinDecimals(ctx)).WithEvmDenom(k.GetEvmDenom(ctx))
+	if err := csdb.Reset(ethcmn.Hash{}); err != nil {
+		return 0, false, err
+	}
+
+	params := k.GetParams(ctx)
+	decoder := types.TxDecoder(k.cdc)
+	for i, bz := range req.Predecessors {
+		tx, sdkErr := decoder(bz)
+		if sdkErr != nil {
+			return 0, false, sdkErr
+		}
+
+		// only the Ethereum transactions are replayed
+		msg, ok := tx.(types.MsgEthereumTx)
+		if !ok {
+			continue
+		}
+
+		// failed transactions don't modify the state so their error is ignored
+		_, _ = k.applyMsgEthereumTx(ctx, csdb, msg, chainID, i, params, nil)
+	}
+
+	tx, sdkErr := decoder(req.Tx)
+	if sdkErr != nil {
+		return 0, false, sdkErr
+	}
+
+	msg, ok := tx.(types.MsgEthereumTx)
+	if !ok {
+		return 0, false, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "cannot trace transaction of type %T", tx)
+	}
+
+	gasUsed, err := k.applyMsgEthereumTx(ctx, csdb, msg, chainID, len(req.Predecessors), params, tracer)
+	return gasUsed, err != nil, nil
+}
+
+// applyMsgEthereumTx executes an Ethereum transaction against the given state
+// DB as the handler does and returns the gas used, including the intrinsic gas.
+func (k *Keeper) applyMsgEthereumTx(
+	ctx sdk.Context, csdb *types.CommitStateDB, msg types.MsgEthereumTx,
+	chainID *big.Int, txIndex int, params types.Params, tracer vm.Tracer,
+) (uint64, error) {
+	sender, err := msg.VerifySig(chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	intrinsicGas, err := types.IntrinsicGas(msg.Data.Payload, msg.Data.Accesses, msg.To() == nil)
+	if err != nil {
+		return 0, err
+	}
+
+	// consume the intrinsic gas as the ante handler does
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	ctx.GasMeter().ConsumeGas(intrinsicGas, "eth intrinsic gas")
+
+	ethHash := msg.Hash()
+
+	st := types.StateTransition{
+		Sender:         sender,
+		AccountNonce:   msg.Data.AccountNonce,
+		Price:          msg.GasPrice(),
+		GasLimit:       msg.Data.GasLimit,
+		Recipient:      msg.Data.Recipient,
+		Amount:         msg.Data.Amount,
+		Payload:        msg.Data.Payload,
+		AccessList:     msg.Data.Accesses,
+		Csdb:           csdb.WithContext(ctx),
+		ChainID:        chainID,
+		THash:          &ethHash,
+		Hardfork:       params.Hardfork,
+		ChainConfig:    &params.ChainConfig,
+		MaxCodeSize:    params.MaxCodeSize,
+		ExtraEIPs:      params.ExtraEIPs,
+		GetHashFn:      k.GetHashFn(ctx),
+		Tracer:         tracer,
+	}
+
+	csdb.Prepare(ethHash, ethcmn.Hash{}, txIndex)
+
+	returnData, err := st.TransitionCSDB(ctx)
+	if err == nil && returnData.Reverted {
+		err = returnData.Err
+	}
+	return ctx.GasMeter().GasConsumed(), err
+}
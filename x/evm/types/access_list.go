@@ -0,0 +1,58 @@
+package types
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// AccessList is an EIP-2930 access list, ie. the set of addresses and storage
+// keys that a transaction plans to access.
+type AccessList []AccessTuple
+
+// AccessTuple is the element type of an access list.
+type AccessTuple struct {
+	Address     ethcmn.Address `json:"address"`
+	StorageKeys []ethcmn.Hash  `json:"storageKeys"`
+}
+
+// StorageKeys returns the total number of storage keys in the access list.
+func (al AccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+	return sum
+}
+
+// Validate performs a basic validation of the access list. It returns an error
+// if a storage key is duplicated for a given address.
+func (al AccessList) Validate() error {
+	seen := make(map[ethcmn.Address]map[ethcmn.Hash]bool)
+
+	for _, tuple := range al {
+		keys, ok := seen[tuple.Address]
+		if !ok {
+			keys = make(map[ethcmn.Hash]bool)
+			seen[tuple.Address] = keys
+		}
+
+		for _, key := range tuple.StorageKeys {
+			if keys[key] {
+				return fmt.Errorf("duplicate storage key %s for address %s", key.Hex(), tuple.Address.Hex())
+			}
+			keys[key] = true
+		}
+	}
+
+	return nil
+}
+
+// rlpList returns the access list as a non-nil slice so that a missing access
+// list is always encoded as an empty RLP list.
+func (al AccessList) rlpList() AccessList {
+	if al == nil {
+		return AccessList{}
+	}
+	return al
+}
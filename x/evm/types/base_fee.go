@@ -0,0 +1,52 @@
+package types
+
+import (
+	"math/big"
+)
+
+const (
+	// BaseFeeChangeDenominator bounds the amount the base fee can change
+	// between blocks to 1/8, as defined by EIP-1559
+	BaseFeeChangeDenominator = 8
+	// ElasticityMultiplier bounds the gas used by a block to twice its gas
+	// target, ie. the gas target is 50% of the block gas limit
+	ElasticityMultiplier = 2
+)
+
+// CalcBaseFee returns the base fee of the next block from the base fee and the
+// gas used of the current block, following the EIP-1559 formula. The base fee
+// increases when the gas used is above the gas target and decreases when it is
+// below, by at most 1/8. The base fee is left unchanged if the block gas is
+// unlimited, since there is no gas target.
+func CalcBaseFee(baseFee *big.Int, gasUsed, gasLimit uint64) *big.Int {
+	if baseFee == nil {
+		baseFee = new(big.Int)
+	}
+
+	gasTarget := gasLimit / ElasticityMultiplier
+	if gasTarget == 0 || gasUsed == gasTarget {
+		return new(big.Int).Set(baseFee)
+	}
+
+	target := new(big.Int).SetUint64(gasTarget)
+	denominator := big.NewInt(BaseFeeChangeDenominator)
+
+	if gasUsed > gasTarget {
+		// the base fee increases by at least 1 wei so that it can leave zero
+		delta := new(big.Int).SetUint64(gasUsed - gasTarget)
+		delta.Mul(delta, baseFee)
+		delta.Quo(delta, target)
+		delta.Quo(delta, denominator)
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return delta.Add(delta, baseFee)
+	}
+
+	delta := new(big.Int).SetUint64(gasTarget - gasUsed)
+	delta.Mul(delta, baseFee)
+	delta.Quo(delta, target)
+	delta.Quo(delta, denominator)
+
+	return delta.Sub(baseFee, delta)
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalcBaseFee(t *testing.T) {
+	testCases := []struct {
+		name     string
+		baseFee  *big.Int
+		gasUsed  uint64
+		gasLimit uint64
+		expected *big.Int
+	}{
+		{"unlimited block gas", big.NewInt(1000000000), 100000, 0, big.NewInt(1000000000)},
+		{"gas used at target", big.NewInt(1000000000), 5000000, 10000000, big.NewInt(1000000000)},
+		{"full block", big.NewInt(1000000000), 10000000, 10000000, big.NewInt(1125000000)},
+		{"gas used above target", big.NewInt(1000000000), 7500000, 10000000, big.NewInt(1062500000)},
+		{"empty block", big.NewInt(1000000000), 0, 10000000, big.NewInt(875000000)},
+		{"gas used below target", big.NewInt(1000000000), 2500000, 10000000, big.NewInt(937500000)},
+		{"minimum increase", big.NewInt(7), 5000001, 10000000, big.NewInt(8)},
+		{"zero base fee above target", big.NewInt(0), 10000000, 10000000, big.NewInt(1)},
+		{"zero base fee below target", big.NewInt(0), 0, 10000000, big.NewInt(0)},
+		{"nil base fee", nil, 5000000, 10000000, big.NewInt(0)},
+	}
+
+	for _, tc := range testCases {
+		baseFee := CalcBaseFee(tc.baseFee, tc.gasUsed, tc.gasLimit)
+		require.Equal(t, 0, tc.expected.Cmp(baseFee), "%s: expected %s, got %s", tc.name, tc.expected, baseFee)
+	}
+
+	// require consecutive full blocks to compound the increase and the parent
+	// base fee to be left unmodified
+	parent := big.NewInt(1000000000)
+	baseFee := CalcBaseFee(CalcBaseFee(parent, 10000000, 10000000), 10000000, 10000000)
+	require.Equal(t, big.NewInt(1265625000), baseFee)
+	require.Equal(t, big.NewInt(1000000000), parent)
+}
@@ -0,0 +1,33 @@
+package types
+
+import (
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// LogsToBloom returns the bloom filter of the given logs. Each log address and
+// topic is added to the bloom by setting the 3 bits derived from its Keccak-256
+// hash. The result matches go-ethereum's ethtypes.CreateBloom.
+func LogsToBloom(logs []*ethtypes.Log) ethtypes.Bloom {
+	var bloom ethtypes.Bloom
+
+	for _, log := range logs {
+		addToBloom(&bloom, log.Address.Bytes())
+		for _, topic := range log.Topics {
+			addToBloom(&bloom, topic.Bytes())
+		}
+	}
+
+	return bloom
+}
+
+// addToBloom sets the 3 bloom bits of the given data. Each bit index is derived
+// from the low 11 bits of the first 3 byte pairs of the data hash.
+func addToBloom(bloom *ethtypes.Bloom, data []byte) {
+	hash := ethcrypto.Keccak256(data)
+
+	for i := 0; i < 6; i += 2 {
+		bit := (uint(hash[i+1]) + (uint(hash[i]) << 8)) & 2047
+		bloom[ethtypes.BloomByteLength-1-bit/8] |= byte(1) << (bit % 8)
+	}
+}
@@ -0,0 +1,285 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// CallTracerName is the tracer option selecting the call tracer instead of the
+// struct logger.
+const CallTracerName = "callTracer"
+
+var _ vm.Tracer = &CallTracer{}
+
+// CallFrame defines a call of the call tree captured by the call tracer, in the
+// format of the go-ethereum callTracer. The quantities are hex encoded and the
+// calls made by the frame are nested in execution order.
+type CallFrame struct {
+	Type    string      `json:"type"`
+	From    string      `json:"from"`
+	To      string      `json:"to,omitempty"`
+	Value   string      `json:"value,omitempty"`
+	Gas     string      `json:"gas,omitempty"`
+	GasUsed string      `json:"gasUsed,omitempty"`
+	Input   string      `json:"input"`
+	Output  string      `json:"output,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Calls   []CallFrame `json:"calls,omitempty"`
+}
+
+// callFrame is a call being executed, along with the values captured when it
+// was entered to compute its gas used and output when it returns.
+type callFrame struct {
+	CallFrame
+
+	gasIn   uint64
+	gasCost uint64
+	gas     uint64
+	gasSet  bool
+	outOff  *big.Int
+	outSize *big.Int
+}
+
+// CallTracer is an EVM tracer capturing the call tree of a transaction, as the
+// go-ethereum callTracer. The nested calls are detected from the CALL, CALLCODE,
+// DELEGATECALL, STATICCALL, CREATE, CREATE2 and SELFDESTRUCT opcodes and closed
+// when the execution returns to the depth of their caller.
+type CallTracer struct {
+	callStack []*callFrame
+	descended bool
+}
+
+// NewCallTracer returns a new call tracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{}
+}
+
+// CaptureStart implements vm.Tracer by opening the frame of the transaction.
+func (t *CallTracer) CaptureStart(from, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	callType := vm.CALL.String()
+	if create {
+		callType = vm.CREATE.String()
+	}
+
+	t.callStack = []*callFrame{{
+		CallFrame: CallFrame{
+			Type:  callType,
+			From:  hexAddress(from),
+			To:    hexAddress(to),
+			Value: hexutil.EncodeBig(value),
+			Gas:   hexutil.EncodeUint64(gas),
+			Input: hexutil.Encode(input),
+		},
+	}}
+	return nil
+}
+
+// CaptureState implements vm.Tracer by opening a frame on each call opcode and
+// closing it when the execution returns to the depth of the caller.
+func (t *CallTracer) CaptureState(
+	env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack,
+	contract *vm.Contract, depth int, err error,
+) error {
+	if err != nil {
+		return t.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+	}
+
+	if len(t.callStack) == 0 {
+		return nil
+	}
+
+	switch op {
+	case vm.CREATE, vm.CREATE2:
+		t.callStack = append(t.callStack, &callFrame{
+			CallFrame: CallFrame{
+				Type:  op.String(),
+				From:  hexAddress(contract.Address()),
+				Value: hexutil.EncodeBig(stack.Back(0)),
+				Input: hexutil.Encode(memorySlice(memory, stack.Back(1), stack.Back(2))),
+			},
+			gasIn:   gas,
+			gasCost: cost,
+		})
+		t.descended = true
+		return nil
+
+	case vm.SELFDESTRUCT:
+		parent := t.callStack[len(t.callStack)-1]
+		parent.Calls = append(parent.Calls, CallFrame{
+			Type:    op.String(),
+			From:    hexAddress(contract.Address()),
+			To:      hexAddress(common.BigToAddress(stack.Back(0))),
+			Value:   hexutil.EncodeBig(env.StateDB.GetBalance(contract.Address())),
+			Gas:     hexutil.EncodeUint64(gas),
+			GasUsed: hexutil.EncodeUint64(cost),
+			Input:   hexutil.Encode(nil),
+		})
+		return nil
+
+	case vm.CALL, vm.CALLCODE, vm.DELEGATECALL, vm.STATICCALL:
+		// the value is only on the stack of the CALL and CALLCODE opcodes
+		off := 0
+		if op == vm.CALL || op == vm.CALLCODE {
+			off = 1
+		}
+
+		frame := &callFrame{
+			CallFrame: CallFrame{
+				Type:  op.String(),
+				From:  hexAddress(contract.Address()),
+				To:    hexAddress(common.BigToAddress(stack.Back(1))),
+				Input: hexutil.Encode(memorySlice(memory, stack.Back(2+off), stack.Back(3+off))),
+			},
+			gasIn:   gas,
+			gasCost: cost,
+			outOff:  new(big.Int).Set(stack.Back(4 + off)),
+			outSize: new(big.Int).Set(stack.Back(5 + off)),
+		}
+		if off == 1 {
+			frame.Value = hexutil.EncodeBig(stack.Back(2))
+		}
+
+		t.callStack = append(t.callStack, frame)
+		t.descended = true
+		return nil
+	}
+
+	// the first step of a call captures the gas it has been given, the calls
+	// without code returning before any step
+	if t.descended {
+		if depth >= len(t.callStack) {
+			frame := t.callStack[len(t.callStack)-1]
+			frame.gas = gas
+			frame.gasSet = true
+		}
+		t.descended = false
+	}
+
+	if op == vm.REVERT {
+		t.callStack[len(t.callStack)-1].Error = callErrReverted
+		return nil
+	}
+
+	// the execution returned to the caller of the last frame
+	if depth == len(t.callStack)-1 {
+		frame := t.callStack[len(t.callStack)-1]
+		t.callStack = t.callStack[:len(t.callStack)-1]
+
+		if frame.gasSet {
+			frame.Gas = hexutil.EncodeUint64(frame.gas)
+		}
+
+		ret := stack.Back(0)
+		if frame.Type == vm.CREATE.String() || frame.Type == vm.CREATE2.String() {
+			frame.GasUsed = hexutil.EncodeUint64(frame.gasIn - frame.gasCost - gas)
+			if ret.Sign() != 0 {
+				addr := common.BigToAddress(ret)
+				frame.To = hexAddress(addr)
+				frame.Output = hexutil.Encode(env.StateDB.GetCode(addr))
+			} else if frame.Error == "" {
+				frame.Error = callErrInternalFailure
+			}
+		} else {
+			if frame.gasSet {
+				frame.GasUsed = hexutil.EncodeUint64(frame.gasIn - frame.gasCost + frame.gas - gas)
+			}
+			if ret.Sign() != 0 {
+				frame.Output = hexutil.Encode(memorySlice(memory, frame.outOff, frame.outSize))
+			} else if frame.Error == "" {
+				frame.Error = callErrInternalFailure
+			}
+		}
+
+		parent := t.callStack[len(t.callStack)-1]
+		parent.Calls = append(parent.Calls, frame.CallFrame)
+	}
+
+	return nil
+}
+
+// CaptureFault implements vm.Tracer by closing the failed frame, which consumed
+// all of its gas. A reverted frame is closed when returning to its caller.
+func (t *CallTracer) CaptureFault(
+	_ *vm.EVM, _ uint64, _ vm.OpCode, _, _ uint64, _ *vm.Memory, _ *vm.Stack, _ *vm.Contract, _ int, err error,
+) error {
+	t.descended = false
+	if len(t.callStack) == 0 || t.callStack[len(t.callStack)-1].Error != "" {
+		return nil
+	}
+
+	frame := t.callStack[len(t.callStack)-1]
+	frame.Error = err.Error()
+
+	// the transaction frame is closed by CaptureEnd
+	if len(t.callStack) == 1 {
+		return nil
+	}
+
+	t.callStack = t.callStack[:len(t.callStack)-1]
+	if frame.gasSet {
+		frame.Gas = hexutil.EncodeUint64(frame.gas)
+		frame.GasUsed = frame.Gas
+	}
+
+	parent := t.callStack[len(t.callStack)-1]
+	parent.Calls = append(parent.Calls, frame.CallFrame)
+	return nil
+}
+
+// CaptureEnd implements vm.Tracer by closing the frame of the transaction.
+func (t *CallTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	if len(t.callStack) == 0 {
+		return nil
+	}
+
+	frame := t.callStack[0]
+	frame.GasUsed = hexutil.EncodeUint64(gasUsed)
+	frame.Output = hexutil.Encode(output)
+	if err != nil && frame.Error == "" {
+		frame.Error = err.Error()
+	}
+	return nil
+}
+
+// Result returns the frame of the traced transaction, nil if the transaction
+// didn't reach the EVM.
+func (t *CallTracer) Result() *CallFrame {
+	if len(t.callStack) == 0 {
+		return nil
+	}
+	return &t.callStack[0].CallFrame
+}
+
+// the errors of the calls which aren't captured as a fault by the EVM, in the
+// go-ethereum callTracer format
+const (
+	callErrReverted        = "execution reverted"
+	callErrInternalFailure = "internal failure"
+)
+
+// hexAddress returns the lower case hex encoding of an address, as the
+// go-ethereum tracers do.
+func hexAddress(addr common.Address) string {
+	return hexutil.Encode(addr.Bytes())
+}
+
+// memorySlice returns a copy of the memory range starting at offset, bounded
+// by the size of the memory.
+func memorySlice(memory *vm.Memory, offset, size *big.Int) []byte {
+	data := memory.Data()
+	if !offset.IsUint64() || offset.Uint64() >= uint64(len(data)) || size.Sign() == 0 {
+		return nil
+	}
+
+	start := offset.Uint64()
+	end := uint64(len(data))
+	if size.IsUint64() && size.Uint64() < end-start {
+		end = start + size.Uint64()
+	}
+
+	return common.CopyBytes(data[start:end])
+}
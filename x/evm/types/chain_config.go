@@ -1,26 +1,167 @@
 package types
 
 import (
+	"fmt"
 	"math/big"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// GenerateChainConfig returns an Ethereum chainconfig for EVM state transitions
-func GenerateChainConfig(chainID *big.Int) *params.ChainConfig {
-	// TODO: Update chainconfig to take in parameters for fork blocks
+// defaultEIP150Hash is the EIP150 fork block hash of the Ethereum mainnet
+const defaultEIP150Hash = "0x2086799aeebeae135c246c65021c82b4e15a2c451340993aacfd2751886514f0"
+
+// ChainConfig defines the block heights at which the Ethereum hardforks are
+// activated for the EVM state transitions. A negative height leaves the fork
+// disabled and a zero height activates it from genesis. The go-ethereum v1.9.0
+// EVM predates London, hence there is no London block and the EIP-3198 BASEFEE
+// opcode is invalid, the base fee being only available through the keeper.
+type ChainConfig struct {
+	HomesteadBlock sdk.Int `json:"homestead_block" yaml:"homestead_block"` // Homestead switch block
+
+	DAOForkBlock   sdk.Int `json:"dao_fork_block" yaml:"dao_fork_block"`     // TheDAO hard-fork switch block
+	DAOForkSupport bool    `json:"dao_fork_support" yaml:"dao_fork_support"` // Whether the nodes supports or opposes the DAO hard-fork
+
+	// EIP150 implements the Gas price changes (https://github.com/ethereum/EIPs/issues/150)
+	EIP150Block sdk.Int `json:"eip150_block" yaml:"eip150_block"` // EIP150 HF block
+	EIP150Hash  string  `json:"eip150_hash" yaml:"eip150_hash"`   // EIP150 HF hash (needed for header only clients as only gas pricing changed)
+
+	EIP155Block sdk.Int `json:"eip155_block" yaml:"eip155_block"` // EIP155 HF block
+	EIP158Block sdk.Int `json:"eip158_block" yaml:"eip158_block"` // EIP158 HF block
+
+	ByzantiumBlock      sdk.Int `json:"byzantium_block" yaml:"byzantium_block"`           // Byzantium switch block
+	ConstantinopleBlock sdk.Int `json:"constantinople_block" yaml:"constantinople_block"` // Constantinople switch block
+	PetersburgBlock     sdk.Int `json:"petersburg_block" yaml:"petersburg_block"`         // Petersburg switch block
+	// IstanbulBlock is the Istanbul switch block. The go-ethereum EVM doesn't
+	// implement the Istanbul changes, hence it has no effect on the state
+	// transitions.
+	IstanbulBlock sdk.Int `json:"istanbul_block" yaml:"istanbul_block"`
+}
+
+// DefaultChainConfig returns the default chain config, which activates all the
+// hardforks from genesis.
+func DefaultChainConfig() ChainConfig {
+	return ChainConfig{
+		HomesteadBlock:      sdk.ZeroInt(),
+		DAOForkBlock:        sdk.ZeroInt(),
+		DAOForkSupport:      true,
+		EIP150Block:         sdk.ZeroInt(),
+		EIP150Hash:          defaultEIP150Hash,
+		EIP155Block:         sdk.ZeroInt(),
+		EIP158Block:         sdk.ZeroInt(),
+		ByzantiumBlock:      sdk.ZeroInt(),
+		ConstantinopleBlock: sdk.ZeroInt(),
+		PetersburgBlock:     sdk.ZeroInt(),
+		IstanbulBlock:       sdk.ZeroInt(),
+	}
+}
+
+// EthereumConfig returns the go-ethereum chain config of the activation
+// heights for the given EIP-155 chain ID.
+func (cc ChainConfig) EthereumConfig(chainID *big.Int) *params.ChainConfig {
 	return &params.ChainConfig{
 		ChainID:             chainID,
-		HomesteadBlock:      big.NewInt(0),
-		DAOForkBlock:        big.NewInt(0),
-		DAOForkSupport:      true,
-		EIP150Block:         big.NewInt(0),
-		EIP150Hash:          common.HexToHash("0x2086799aeebeae135c246c65021c82b4e15a2c451340993aacfd2751886514f0"),
-		EIP155Block:         big.NewInt(0),
-		EIP158Block:         big.NewInt(0),
-		ByzantiumBlock:      big.NewInt(0),
-		ConstantinopleBlock: big.NewInt(0),
-		PetersburgBlock:     big.NewInt(0),
+		HomesteadBlock:      getBlockValue(cc.HomesteadBlock),
+		DAOForkBlock:        getBlockValue(cc.DAOForkBlock),
+		DAOForkSupport:      cc.DAOForkSupport,
+		EIP150Block:         getBlockValue(cc.EIP150Block),
+		EIP150Hash:          common.HexToHash(cc.EIP150Hash),
+		EIP155Block:         getBlockValue(cc.EIP155Block),
+		EIP158Block:         getBlockValue(cc.EIP158Block),
+		ByzantiumBlock:      getBlockValue(cc.ByzantiumBlock),
+		ConstantinopleBlock: getBlockValue(cc.ConstantinopleBlock),
+		PetersburgBlock:     getBlockValue(cc.PetersburgBlock),
+	}
+}
+
+// IsIstanbul returns whether the Istanbul hardfork is active at the height.
+func (cc ChainConfig) IsIstanbul(height *big.Int) bool {
+	return isForked(getBlockValue(cc.IstanbulBlock), height)
+}
+
+// Validate performs a stateless validation of the chain config. The activation
+// heights must be in the order of the hardforks, and a disabled hardfork
+// requires the following ones to be disabled as well.
+func (cc ChainConfig) Validate() error {
+	if cc.DAOForkBlock == (sdk.Int{}) {
+		return fmt.Errorf("DAO fork block cannot be empty")
+	}
+
+	if err := validateHash(cc.EIP150Hash); err != nil {
+		return fmt.Errorf("invalid EIP150 hash: %w", err)
+	}
+
+	forks := []struct {
+		name  string
+		block sdk.Int
+	}{
+		{"homestead", cc.HomesteadBlock},
+		{"eip150", cc.EIP150Block},
+		{"eip155", cc.EIP155Block},
+		{"eip158", cc.EIP158Block},
+		{"byzantium", cc.ByzantiumBlock},
+		{"constantinople", cc.ConstantinopleBlock},
+		{"petersburg", cc.PetersburgBlock},
+		{"istanbul", cc.IstanbulBlock},
+	}
+
+	var last struct {
+		name  string
+		block *big.Int
+	}
+
+	for i, fork := range forks {
+		if fork.block == (sdk.Int{}) {
+			return fmt.Errorf("%s block cannot be empty", fork.name)
+		}
+
+		block := getBlockValue(fork.block)
+		switch {
+		case i == 0:
+		case last.block == nil && block != nil:
+			return fmt.Errorf("%s fork is enabled at block %s while the %s fork is disabled", fork.name, block, last.name)
+		case last.block != nil && block != nil && block.Cmp(last.block) < 0:
+			return fmt.Errorf(
+				"%s fork block %s is lower than the %s fork block %s", fork.name, block, last.name, last.block,
+			)
+		}
+
+		last.name, last.block = fork.name, block
+	}
+
+	return nil
+}
+
+// validateHash returns an error if the string isn't a hex encoded 32 bytes hash.
+func validateHash(hex string) error {
+	if strings.TrimSpace(hex) == "" {
+		return fmt.Errorf("hash cannot be blank")
+	}
+
+	bz := common.FromHex(hex)
+	if len(bz) != common.HashLength {
+		return fmt.Errorf("invalid hash length %d", len(bz))
+	}
+
+	return nil
+}
+
+// getBlockValue returns the activation height of a fork, or nil if it is
+// disabled.
+func getBlockValue(block sdk.Int) *big.Int {
+	if block == (sdk.Int{}) || block.IsNegative() {
+		return nil
+	}
+	return block.BigInt()
+}
+
+// isForked returns whether a fork scheduled at block is active at the height.
+func isForked(block, height *big.Int) bool {
+	if block == nil || height == nil {
+		return false
 	}
+	return block.Cmp(height) <= 0
 }
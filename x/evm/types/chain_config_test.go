@@ -0,0 +1,72 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestChainConfigValidate(t *testing.T) {
+	disabledAfterByzantium := DefaultChainConfig()
+	disabledAfterByzantium.ConstantinopleBlock = sdk.NewInt(-1)
+	disabledAfterByzantium.PetersburgBlock = sdk.NewInt(-1)
+	disabledAfterByzantium.IstanbulBlock = sdk.NewInt(-1)
+
+	scheduled := DefaultChainConfig()
+	scheduled.ConstantinopleBlock = sdk.NewInt(10)
+	scheduled.PetersburgBlock = sdk.NewInt(10)
+	scheduled.IstanbulBlock = sdk.NewInt(20)
+
+	decreasing := DefaultChainConfig()
+	decreasing.ByzantiumBlock = sdk.NewInt(10)
+
+	enabledAfterDisabled := DefaultChainConfig()
+	enabledAfterDisabled.PetersburgBlock = sdk.NewInt(-1)
+
+	emptyBlock := DefaultChainConfig()
+	emptyBlock.EIP155Block = sdk.Int{}
+
+	invalidHash := DefaultChainConfig()
+	invalidHash.EIP150Hash = "0x1234"
+
+	testCases := []struct {
+		name     string
+		config   ChainConfig
+		expError bool
+	}{
+		{"default", DefaultChainConfig(), false},
+		{"disabled after byzantium", disabledAfterByzantium, false},
+		{"scheduled", scheduled, false},
+		{"decreasing", decreasing, true},
+		{"enabled after disabled", enabledAfterDisabled, true},
+		{"empty block", emptyBlock, true},
+		{"invalid hash", invalidHash, true},
+		{"empty", ChainConfig{}, true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.config.Validate()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestChainConfigEthereumConfig(t *testing.T) {
+	config := DefaultChainConfig()
+	config.ConstantinopleBlock = sdk.NewInt(10)
+	config.PetersburgBlock = sdk.NewInt(-1)
+	config.IstanbulBlock = sdk.NewInt(-1)
+
+	ethConfig := config.EthereumConfig(big.NewInt(3))
+	require.Equal(t, big.NewInt(3), ethConfig.ChainID)
+	require.True(t, ethConfig.IsByzantium(big.NewInt(0)))
+	require.False(t, ethConfig.IsConstantinople(big.NewInt(9)))
+	require.True(t, ethConfig.IsConstantinople(big.NewInt(10)))
+	require.Nil(t, ethConfig.PetersburgBlock)
+}
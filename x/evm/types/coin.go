@@ -0,0 +1,49 @@
+package types
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	emint "github.com/cosmos/ethermint/types"
+)
+
+const (
+	// WeiDecimals defines the number of decimals of the wei unit used by the EVM
+	WeiDecimals uint32 = 18
+	// DefaultCoinDecimals defines the default number of decimals of the coin
+	// denomination, which is denominated in wei
+	DefaultCoinDecimals = WeiDecimals
+)
+
+// weiPerCoin returns the amount of wei represented by one unit of a coin with
+// the given number of decimals, ie. 10^(18 - decimals).
+func weiPerCoin(decimals uint32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(WeiDecimals-decimals)), nil)
+}
+
+// CoinToWei converts an amount of the coin denomination with the given number
+// of decimals to the equivalent amount of wei. The decimals must not exceed
+// WeiDecimals.
+func CoinToWei(amount sdk.Int, decimals uint32) *big.Int {
+	return new(big.Int).Mul(amount.BigInt(), weiPerCoin(decimals))
+}
+
+// WeiToCoin converts an amount of wei to the equivalent amount of the coin
+// denomination with the given number of decimals. It returns an error if the
+// amount isn't representable in the coin denomination, ie. if it isn't a
+// multiple of 10^(18 - decimals) wei. The decimals must not exceed WeiDecimals.
+func WeiToCoin(wei *big.Int, decimals uint32) (sdk.Int, error) {
+	if wei == nil {
+		return sdk.ZeroInt(), nil
+	}
+
+	amount, remainder := new(big.Int).QuoRem(wei, weiPerCoin(decimals), new(big.Int))
+	if remainder.Sign() != 0 {
+		return sdk.Int{}, sdkerrors.Wrapf(
+			emint.ErrInvalidValue, "amount of %s wei is not representable with %d decimals", wei, decimals,
+		)
+	}
+
+	return sdk.NewIntFromBigInt(amount), nil
+}
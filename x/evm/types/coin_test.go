@@ -0,0 +1,69 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	emint "github.com/cosmos/ethermint/types"
+)
+
+func TestCoinToWei(t *testing.T) {
+	testCases := []struct {
+		amount   sdk.Int
+		decimals uint32
+		expected *big.Int
+	}{
+		{sdk.NewInt(1), 18, big.NewInt(1)},
+		{sdk.NewInt(1), 6, big.NewInt(1000000000000)},
+		{sdk.NewInt(1), 0, new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)},
+		{sdk.NewInt(25), 17, big.NewInt(250)},
+		{sdk.ZeroInt(), 6, big.NewInt(0)},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, 0, tc.expected.Cmp(CoinToWei(tc.amount, tc.decimals)), "test: %v", i)
+	}
+}
+
+func TestWeiToCoin(t *testing.T) {
+	testCases := []struct {
+		wei      *big.Int
+		decimals uint32
+		expected sdk.Int
+		expError bool
+	}{
+		{big.NewInt(1), 18, sdk.NewInt(1), false},
+		{big.NewInt(1000000000000), 6, sdk.NewInt(1), false},
+		{big.NewInt(999999999999), 6, sdk.Int{}, true},
+		{big.NewInt(1000000000001), 6, sdk.Int{}, true},
+		{big.NewInt(2000000000000), 6, sdk.NewInt(2), false},
+		{big.NewInt(1), 17, sdk.Int{}, true},
+		{big.NewInt(10), 17, sdk.NewInt(1), false},
+		{big.NewInt(0), 0, sdk.ZeroInt(), false},
+		{nil, 6, sdk.ZeroInt(), false},
+	}
+
+	for i, tc := range testCases {
+		amount, err := WeiToCoin(tc.wei, tc.decimals)
+		if tc.expError {
+			require.Error(t, err, "test: %v", i)
+			require.True(t, emint.ErrInvalidValue.Is(err), "test: %v", i)
+			continue
+		}
+
+		require.NoError(t, err, "test: %v", i)
+		require.True(t, tc.expected.Equal(amount), "test: %v", i)
+	}
+}
+
+func TestCoinWeiRoundTrip(t *testing.T) {
+	amount := sdk.NewInt(123456789)
+	for decimals := uint32(0); decimals <= WeiDecimals; decimals++ {
+		coin, err := WeiToCoin(CoinToWei(amount, decimals), decimals)
+		require.NoError(t, err)
+		require.True(t, amount.Equal(coin), "decimals: %d", decimals)
+	}
+}
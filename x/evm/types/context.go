@@ -0,0 +1,25 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// senderContextKey is the context key of the Ethereum transaction sender
+// recovered by the ante handler
+type senderContextKey struct{}
+
+// WithSender returns a copy of the context holding the sender recovered from
+// the signature of the Ethereum transaction being processed. The handler reads
+// it back to avoid recovering the signer a second time.
+func WithSender(ctx sdk.Context, sender ethcmn.Address) sdk.Context {
+	return ctx.WithValue(senderContextKey{}, sender)
+}
+
+// SenderFromContext returns the transaction sender set on the context by
+// WithSender, if any.
+func SenderFromContext(ctx sdk.Context) (ethcmn.Address, bool) {
+	sender, ok := ctx.Value(senderContextKey{}).(ethcmn.Address)
+	return sender, ok
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"math/big"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// EIP712DomainName defines the name of the EIP-712 signing domain
+	EIP712DomainName = "Ethermint"
+	// EIP712DomainVersion defines the version of the EIP-712 signing domain
+	EIP712DomainVersion = "1"
+)
+
+var (
+	// eip712DomainTypeHash is the hash of the EIP-712 domain type definition
+	eip712DomainTypeHash = ethcrypto.Keccak256(
+		[]byte("EIP712Domain(string name,string version,uint256 chainId)"),
+	)
+
+	// eip712TxTypeHash is the hash of the EIP-712 type definition of the
+	// MsgEthereumTx fields
+	eip712TxTypeHash = ethcrypto.Keccak256(
+		[]byte("MsgEthereumTx(uint256 nonce,address to,uint256 value,uint256 gas,uint256 gasPrice,bytes data)"),
+	)
+)
+
+// EIP712SignBytes returns the EIP-712 typed-data hash of an Ethereum
+// transaction message with a given chainID used for signing, ie.
+// keccak256("\x19\x01" || domainSeparator || hashStruct(msg)). The recipient of
+// contract creation transactions is encoded as the zero address.
+func (msg MsgEthereumTx) EIP712SignBytes(chainID *big.Int) ([]byte, error) {
+	if chainID == nil || chainID.Sign() <= 0 {
+		return nil, sdkerrors.Wrapf(types.ErrInvalidChainID, "chain ID must be positive, got %s", chainID)
+	}
+
+	domainSeparator := ethcrypto.Keccak256(
+		eip712DomainTypeHash,
+		ethcrypto.Keccak256([]byte(EIP712DomainName)),
+		ethcrypto.Keccak256([]byte(EIP712DomainVersion)),
+		encodeUint256(chainID),
+	)
+
+	var to ethcmn.Address
+	if msg.Data.Recipient != nil {
+		to = *msg.Data.Recipient
+	}
+
+	structHash := ethcrypto.Keccak256(
+		eip712TxTypeHash,
+		encodeUint256(new(big.Int).SetUint64(msg.Data.AccountNonce)),
+		ethcmn.LeftPadBytes(to.Bytes(), 32),
+		encodeUint256(msg.Data.Amount),
+		encodeUint256(new(big.Int).SetUint64(msg.Data.GasLimit)),
+		encodeUint256(msg.Data.Price),
+		ethcrypto.Keccak256(msg.Data.Payload),
+	)
+
+	return ethcrypto.Keccak256([]byte("\x19\x01"), domainSeparator, structHash), nil
+}
+
+// VerifyEIP712Sig attempts to verify an EIP-712 signature of the transaction
+// message for a given chainID and returns the address of the signer. The
+// signature must be in the 65 byte [R || S || V] format, where V is either 0/1
+// or 27/28 as returned by wallets such as MetaMask.
+func (msg MsgEthereumTx) VerifyEIP712Sig(chainID *big.Int, sig []byte) (ethcmn.Address, error) {
+	if len(sig) != 65 {
+		return ethcmn.Address{}, sdkerrors.Wrapf(types.ErrInvalidSignature, "invalid signature length %d", len(sig))
+	}
+
+	sigHash, err := msg.EIP712SignBytes(chainID)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	V := new(big.Int).SetUint64(uint64(sig[64]))
+	if V.Uint64() < 27 {
+		V.Add(V, big.NewInt(27))
+	}
+
+	R := new(big.Int).SetBytes(sig[:32])
+	S := new(big.Int).SetBytes(sig[32:64])
+
+	return recoverEthSig(R, S, V, ethcmn.BytesToHash(sigHash))
+}
+
+// encodeUint256 returns the 32 byte big-endian encoding of a non-negative
+// integer. A nil value is encoded as zero.
+func encodeUint256(x *big.Int) []byte {
+	if x == nil {
+		return make([]byte, 32)
+	}
+	return ethcmn.LeftPadBytes(x.Bytes(), 32)
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"fmt"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	emint "github.com/cosmos/ethermint/types"
+
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// EIP3541 rejects the deployment of new contracts whose code starts with the
+	// 0xEF byte, reserved for the EVM object format.
+	EIP3541 = 3541
+	// EIP3860 limits the size of the init code of the contract creation
+	// transactions, and charges the init code gas per word of it. The go-ethereum
+	// v1.9.0 EVM can't charge it on the CREATE and CREATE2 opcodes, hence the
+	// init code of the nested creations is neither bounded nor charged.
+	EIP3860 = 3860
+)
+
+const (
+	// MaxInitCodeSize is the max init code size of a contract creation from
+	// EIP-3860, twice the EIP-170 max code size.
+	MaxInitCodeSize = 2 * ethparams.MaxCodeSize
+	// InitCodeWordGas is the gas charged per word of init code from EIP-3860.
+	InitCodeWordGas uint64 = 2
+)
+
+// activatableEIPs defines the EIPs that can be activated by the ExtraEIPs param.
+// The go-ethereum v1.9.0 EVM can't activate EIPs on its jump table, so they are
+// enforced by the state transition.
+var activatableEIPs = map[int]bool{
+	EIP3541: true,
+	EIP3860: true,
+}
+
+// ValidateExtraEIPs returns an error listing the EIPs that can't be activated.
+func ValidateExtraEIPs(eips []int) error {
+	var invalid []int
+	for _, eip := range eips {
+		if !activatableEIPs[eip] {
+			invalid = append(invalid, eip)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid extra EIPs, not activatable: %v", invalid)
+	}
+
+	return nil
+}
+
+// isEIPActivated returns true if the EIP is in the list of extra EIPs.
+func isEIPActivated(eips []int, eip int) bool {
+	for _, e := range eips {
+		if e == eip {
+			return true
+		}
+	}
+	return false
+}
+
+// InitCodeGas returns the EIP-3860 gas of an init code of the given size, or an
+// error if it exceeds the max init code size.
+func InitCodeGas(size uint64) (uint64, error) {
+	if size > MaxInitCodeSize {
+		return 0, sdkerrors.Wrapf(
+			emint.ErrCodeSizeExceeded, "init code size %d exceeds the max init code size %d", size, MaxInitCodeSize,
+		)
+	}
+
+	return InitCodeWordGas * ((size + 31) / 32), nil
+}
@@ -4,8 +4,14 @@ package types
 const (
 	EventTypeEthermint  = TypeMsgEthermint
 	EventTypeEthereumTx = TypeMsgEthereumTx
+	EventTypeBlockBloom = "block_bloom"
+	EventTypeTxLog      = "tx_log"
 
 	AttributeKeyContractAddress = "contract"
 	AttributeKeyRecipient       = "recipient"
+	AttributeKeyEthereumBloom   = "bloom"
+	AttributeKeyTxHash          = "ethereum_tx_hash"
+	AttributeKeyTopic           = "topic"
+	AttributeKeyLogIndex        = "log_index"
 	AttributeValueCategory      = ModuleName
 )
@@ -0,0 +1,117 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// LogFilter defines the criteria of an eth_getLogs query. A FromBlock or
+// ToBlock value lower or equal to zero defines the latest block height.
+//
+// The topics are matched by position, where each position defines the set of
+// topics that match (OR) and an empty position matches any topic:
+//
+//	{} or nil          matches any topic list
+//	{{A}}              matches topic A in first position
+//	{{}, {B}}          matches any topic in first position AND B in second position
+//	{{A}, {B}}         matches topic A in first position AND B in second position
+//	{{A, B}, {C, D}}   matches topic (A OR B) in first position AND (C OR D) in second position
+type LogFilter struct {
+	FromBlock int64            `json:"fromBlock"`
+	ToBlock   int64            `json:"toBlock"`
+	Addresses []ethcmn.Address `json:"addresses"`
+	Topics    [][]ethcmn.Hash  `json:"topics"`
+}
+
+// BloomMatches returns false if the given bloom filter proves that none of
+// the logs it was built from can match the filter criteria.
+func (f LogFilter) BloomMatches(bloom ethtypes.Bloom) bool {
+	if len(f.Addresses) > 0 {
+		var included bool
+		for _, addr := range f.Addresses {
+			if ethtypes.BloomLookup(bloom, addr) {
+				included = true
+				break
+			}
+		}
+
+		if !included {
+			return false
+		}
+	}
+
+	for _, sub := range f.Topics {
+		// empty positions match any topic
+		included := len(sub) == 0
+		for _, topic := range sub {
+			if ethtypes.BloomLookup(bloom, topic) {
+				included = true
+				break
+			}
+		}
+
+		if !included {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Matches returns true if the log matches the address and topics criteria of
+// the filter. The block range is not checked.
+func (f LogFilter) Matches(log *ethtypes.Log) bool {
+	if len(f.Addresses) > 0 && !includesAddress(f.Addresses, log.Address) {
+		return false
+	}
+
+	// a log with less topics than the filter positions can't match
+	if len(f.Topics) > len(log.Topics) {
+		return false
+	}
+
+	for i, sub := range f.Topics {
+		if len(sub) == 0 {
+			continue
+		}
+
+		if !includesTopic(sub, log.Topics[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterLogs returns the logs matching the address and topics criteria of the
+// filter.
+func (f LogFilter) FilterLogs(logs []*ethtypes.Log) []*ethtypes.Log {
+	ret := []*ethtypes.Log{}
+	for _, log := range logs {
+		if f.Matches(log) {
+			ret = append(ret, log)
+		}
+	}
+
+	return ret
+}
+
+func includesAddress(addresses []ethcmn.Address, a ethcmn.Address) bool {
+	for _, addr := range addresses {
+		if addr == a {
+			return true
+		}
+	}
+
+	return false
+}
+
+func includesTopic(topics []ethcmn.Hash, t ethcmn.Hash) bool {
+	for _, topic := range topics {
+		if topic == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,34 @@
+//go:build gofuzz
+// +build gofuzz
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FuzzDecodeTx is the go-fuzz harness of DecodeTxBytes, run with:
+//
+//	go-fuzz-build -func FuzzDecodeTx github.com/cosmos/ethermint/x/evm/types
+//	go-fuzz -bin types-fuzz.zip
+//
+// The decoder must never panic, and a decoded transaction must be re-encoded
+// to the same bytes since the RLP decoding only accepts canonical encodings.
+func FuzzDecodeTx(data []byte) int {
+	msg, err := DecodeTxBytes(data)
+	if err != nil {
+		return 0
+	}
+
+	bz, err := EncodeTx(&msg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode a decoded transaction: %s", err))
+	}
+
+	if !bytes.Equal(bz, data) {
+		panic(fmt.Sprintf("transaction round trip mismatch: %x != %x", bz, data))
+	}
+
+	return 1
+}
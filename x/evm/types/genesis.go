@@ -2,37 +2,67 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"math/big"
 
-	"github.com/cosmos/ethermint/types"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 type (
 	// GenesisState defines the application's genesis state. It contains all the
 	// information required and accounts to initialize the blockchain.
 	GenesisState struct {
+		Params   Params           `json:"params"`
 		Accounts []GenesisAccount `json:"accounts"`
+		Blooms   []BlockBloom     `json:"blooms,omitempty"`
 	}
 
 	// GenesisAccount defines an account to be initialized in the genesis state.
+	// The address is hex encoded as amino can't decode the JSON encoding of
+	// ethcmn.Address.
 	GenesisAccount struct {
-		Address ethcmn.Address `json:"address"`
-		Balance *big.Int       `json:"balance"`
-		Code    []byte         `json:"code,omitempty"`
-		Storage types.Storage  `json:"storage,omitempty"`
+		Address string   `json:"address"`
+		Balance *big.Int `json:"balance"`
+		Code    []byte   `json:"code,omitempty"`
+		Storage Storage  `json:"storage,omitempty"`
+	}
+
+	// BlockBloom defines the bloom of the logs emitted in the block at a given
+	// height.
+	BlockBloom struct {
+		Height int64          `json:"height"`
+		Bloom  ethtypes.Bloom `json:"bloom"`
 	}
 )
 
 // ValidateGenesis validates evm genesis config
 func ValidateGenesis(data GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
 	for _, acct := range data.Accounts {
-		if len(acct.Address.Bytes()) == 0 {
-			return errors.New("invalid GenesisAccount: address cannot be empty")
+		if !ethcmn.IsHexAddress(acct.Address) {
+			return fmt.Errorf("invalid GenesisAccount: address %q is not a valid hex address", acct.Address)
 		}
 		if acct.Balance == nil {
 			return errors.New("invalid GenesisAccount: balance cannot be empty")
 		}
+		if err := acct.Storage.Validate(); err != nil {
+			return fmt.Errorf("invalid GenesisAccount %s: %w", acct.Address, err)
+		}
+	}
+
+	seenHeights := make(map[int64]bool)
+	for _, bloom := range data.Blooms {
+		if bloom.Height < 0 {
+			return fmt.Errorf("invalid BlockBloom: height cannot be negative %d", bloom.Height)
+		}
+		if seenHeights[bloom.Height] {
+			return fmt.Errorf("invalid BlockBloom: duplicated height %d", bloom.Height)
+		}
+		seenHeights[bloom.Height] = true
 	}
 	return nil
 }
@@ -40,6 +70,7 @@ func ValidateGenesis(data GenesisState) error {
 // DefaultGenesisState sets default evm genesis config
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
+		Params:   DefaultParams(),
 		Accounts: []GenesisAccount{},
 	}
 }
@@ -1,5 +1,11 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
 const (
 	// ModuleName string name of module
 	ModuleName = "evm"
@@ -13,10 +19,24 @@ const (
 
 	// RouterKey uses module name for routing
 	RouterKey = ModuleName
+
+	// NumRecentBlockHashes is the number of recent block hashes available to
+	// the BLOCKHASH opcode
+	NumRecentBlockHashes = 256
 )
 
 var bloomPrefix = []byte("bloom")
 var logsPrefix = []byte("logs")
+var receiptPrefix = []byte("receipt")
+var blockLogsPrefix = []byte("blockLogs")
+var txHashPrefix = []byte("txHash")
+var storagePrefix = []byte("storage")
+var heightHashPrefix = []byte("heightHash")
+var stateRootPrefix = []byte("stateRoot")
+var recipientTxPrefix = []byte("recipientTx")
+
+// BaseFeeKey is the key of the base fee of the next block
+var BaseFeeKey = []byte("baseFee")
 
 func BloomKey(key []byte) []byte {
 	return append(bloomPrefix, key...)
@@ -25,3 +45,63 @@ func BloomKey(key []byte) []byte {
 func LogsKey(key []byte) []byte {
 	return append(logsPrefix, key...)
 }
+
+func ReceiptKey(key []byte) []byte {
+	return append(receiptPrefix, key...)
+}
+
+// TxHashKey returns the key of the mapping from an Ethereum transaction hash to
+// the Tendermint transaction hash.
+func TxHashKey(hash []byte) []byte {
+	return append(txHashPrefix, hash...)
+}
+
+// BlockLogsKey returns the key of the transaction hash index of the logs
+// emitted at the given block height.
+func BlockLogsKey(height int64, hash []byte) []byte {
+	return append(BlockLogsPrefix(height), hash...)
+}
+
+// BlockLogsPrefix returns the key prefix of the transaction hashes with logs
+// emitted at the given block height.
+func BlockLogsPrefix(height int64) []byte {
+	return append(append([]byte{}, blockLogsPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// AddressStoragePrefix returns the key prefix of the storage of the account
+// with the given address.
+func AddressStoragePrefix(addr ethcmn.Address) []byte {
+	return append(append([]byte{}, storagePrefix...), addr.Bytes()...)
+}
+
+// StorageKey returns the key of a storage slot of the account with the given
+// address.
+func StorageKey(addr ethcmn.Address, key ethcmn.Hash) []byte {
+	return append(AddressStoragePrefix(addr), key.Bytes()...)
+}
+
+// HeightHashKey returns the key of the ring buffer slot holding the hash of the
+// block at the given height.
+func HeightHashKey(height int64) []byte {
+	return append(append([]byte{}, heightHashPrefix...), sdk.Uint64ToBigEndian(uint64(height%NumRecentBlockHashes))...)
+}
+
+// StateRootKey returns the key of the EVM state root committed at the end of
+// the block at the given height.
+func StateRootKey(height int64) []byte {
+	return append(append([]byte{}, stateRootPrefix...), sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// RecipientTxKey returns the key of the index of the transaction sent to the
+// recipient at the given block height and transaction index, ordering the
+// transactions of a recipient by height and index.
+func RecipientTxKey(recipient ethcmn.Address, height int64, txIndex uint64) []byte {
+	return append(RecipientTxHeightPrefix(recipient, height), sdk.Uint64ToBigEndian(txIndex)...)
+}
+
+// RecipientTxHeightPrefix returns the key prefix of the transactions sent to
+// the recipient from the given block height.
+func RecipientTxHeightPrefix(recipient ethcmn.Address, height int64) []byte {
+	prefix := append(append([]byte{}, recipientTxPrefix...), recipient.Bytes()...)
+	return append(prefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
@@ -1,11 +1,14 @@
 package types
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
+	"runtime"
+	"sync"
 	"sync/atomic"
 
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -31,6 +34,16 @@ const (
 	TypeMsgEthereumTx = "ethereum"
 )
 
+// Ethereum transaction envelope types
+const (
+	// LegacyTxType defines the original (pre EIP-2718) transaction format
+	LegacyTxType = uint8(0x00)
+	// AccessListTxType defines the EIP-2930 transaction format
+	AccessListTxType = uint8(0x01)
+	// DynamicFeeTxType defines the EIP-1559 transaction format
+	DynamicFeeTxType = uint8(0x02)
+)
+
 // MsgEthereumTx encapsulates an Ethereum transaction as an SDK message.
 type (
 	MsgEthereumTx struct {
@@ -59,6 +72,47 @@ type (
 
 		// hash is only used when marshaling to JSON
 		Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+		// typed transaction fields, these are excluded from the legacy RLP
+		// encoding and are only set for non-legacy transactions
+		Type                 uint8      `json:"type" rlp:"-"`
+		ChainID              *big.Int   `json:"chainId,omitempty" rlp:"-"`
+		MaxPriorityFeePerGas *big.Int   `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+		MaxFeePerGas         *big.Int   `json:"maxFeePerGas,omitempty" rlp:"-"`
+		Accesses             AccessList `json:"accessList,omitempty" rlp:"-"`
+	}
+
+	// accessListTxRLP defines the RLP payload of an EIP-2930 transaction as it
+	// is encoded after the transaction type byte.
+	accessListTxRLP struct {
+		ChainID      *big.Int
+		AccountNonce uint64
+		Price        *big.Int
+		GasLimit     uint64
+		Recipient    *ethcmn.Address `rlp:"nil"`
+		Amount       *big.Int
+		Payload      []byte
+		AccessList   AccessList
+		V            *big.Int
+		R            *big.Int
+		S            *big.Int
+	}
+
+	// dynamicFeeTxRLP defines the RLP payload of an EIP-1559 transaction as it
+	// is encoded after the transaction type byte.
+	dynamicFeeTxRLP struct {
+		ChainID              *big.Int
+		AccountNonce         uint64
+		MaxPriorityFeePerGas *big.Int
+		MaxFeePerGas         *big.Int
+		GasLimit             uint64
+		Recipient            *ethcmn.Address `rlp:"nil"`
+		Amount               *big.Int
+		Payload              []byte
+		AccessList           AccessList
+		V                    *big.Int
+		R                    *big.Int
+		S                    *big.Int
 	}
 
 	// sigCache is used to cache the derived sender and contains the signer used
@@ -87,6 +141,48 @@ func NewMsgEthereumTxContract(
 	return newMsgEthereumTx(nonce, nil, amount, gasLimit, gasPrice, payload)
 }
 
+// NewMsgEthereumTxDynamicFee returns a reference to a new EIP-1559 Ethereum
+// transaction message. A nil recipient designates a contract creation. The
+// legacy gas price is set to the max fee per gas as it is the maximum price the
+// sender is willing to pay.
+func NewMsgEthereumTxDynamicFee(
+	nonce uint64, to *ethcmn.Address, amount *big.Int, gasLimit uint64,
+	maxPriorityFeePerGas, maxFeePerGas *big.Int, payload []byte,
+) MsgEthereumTx {
+
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, maxFeePerGas, payload)
+
+	msg.Data.Type = DynamicFeeTxType
+	msg.Data.ChainID = new(big.Int)
+	msg.Data.MaxPriorityFeePerGas = new(big.Int)
+	msg.Data.MaxFeePerGas = new(big.Int)
+
+	if maxPriorityFeePerGas != nil {
+		msg.Data.MaxPriorityFeePerGas.Set(maxPriorityFeePerGas)
+	}
+	if maxFeePerGas != nil {
+		msg.Data.MaxFeePerGas.Set(maxFeePerGas)
+	}
+
+	return msg
+}
+
+// NewMsgEthereumTxAccessList returns a reference to a new EIP-2930 Ethereum
+// transaction message. A nil recipient designates a contract creation.
+func NewMsgEthereumTxAccessList(
+	nonce uint64, to *ethcmn.Address, amount *big.Int, gasLimit uint64,
+	gasPrice *big.Int, payload []byte, accesses AccessList,
+) MsgEthereumTx {
+
+	msg := newMsgEthereumTx(nonce, to, amount, gasLimit, gasPrice, payload)
+
+	msg.Data.Type = AccessListTxType
+	msg.Data.ChainID = new(big.Int)
+	msg.Data.Accesses = accesses
+
+	return msg
+}
+
 func newMsgEthereumTx(
 	nonce uint64, to *ethcmn.Address, amount *big.Int,
 	gasLimit uint64, gasPrice *big.Int, payload []byte,
@@ -127,7 +223,9 @@ func (msg MsgEthereumTx) Type() string { return TypeMsgEthereumTx }
 // ValidateBasic implements the sdk.Msg interface. It performs basic validation
 // checks of a Transaction. If returns an error if validation fails.
 func (msg MsgEthereumTx) ValidateBasic() sdk.Error {
-	if msg.Data.Price.Sign() != 1 {
+	// The EIP-1559 transactions are priced by their fee caps, validated below,
+	// while the gas price of the other transactions must be positive.
+	if msg.Data.Type != DynamicFeeTxType && (msg.Data.Price == nil || msg.Data.Price.Sign() != 1) {
 		return sdk.ConvertError(
 			sdkerrors.Wrapf(types.ErrInvalidValue, "price must be positive %s", msg.Data.Price),
 		)
@@ -140,6 +238,65 @@ func (msg MsgEthereumTx) ValidateBasic() sdk.Error {
 		)
 	}
 
+	// A nil recipient creates a contract, while an explicit zero address is a
+	// call to the zero address. Being stateless, the validation cannot reject
+	// the latter, which is left to the RejectZeroAddressRecipient param.
+	intrinsicGas, err := IntrinsicGas(msg.Data.Payload, msg.Data.Accesses, msg.To() == nil)
+	if err != nil {
+		return sdk.ConvertError(
+			sdkerrors.Wrap(types.ErrInvalidValue, err.Error()),
+		)
+	}
+
+	if msg.Data.GasLimit < intrinsicGas {
+		return sdk.ConvertError(
+			sdkerrors.Wrapf(
+				types.ErrInvalidValue, "gas limit %d is below the intrinsic gas %d", msg.Data.GasLimit, intrinsicGas,
+			),
+		)
+	}
+
+	switch msg.Data.Type {
+	case LegacyTxType:
+		if len(msg.Data.Accesses) > 0 {
+			return sdk.ConvertError(
+				sdkerrors.Wrap(types.ErrInvalidValue, "legacy transactions cannot contain an access list"),
+			)
+		}
+	case AccessListTxType:
+	case DynamicFeeTxType:
+		if msg.Data.MaxFeePerGas == nil || msg.Data.MaxPriorityFeePerGas == nil {
+			return sdk.ConvertError(
+				sdkerrors.Wrap(types.ErrInvalidValue, "max fee and max priority fee per gas must be set"),
+			)
+		}
+
+		if msg.Data.MaxPriorityFeePerGas.Sign() == -1 {
+			return sdk.ConvertError(
+				sdkerrors.Wrapf(types.ErrInvalidValue, "max priority fee per gas cannot be negative %s", msg.Data.MaxPriorityFeePerGas),
+			)
+		}
+
+		if msg.Data.MaxPriorityFeePerGas.Cmp(msg.Data.MaxFeePerGas) > 0 {
+			return sdk.ConvertError(
+				sdkerrors.Wrapf(
+					types.ErrInvalidValue, "max priority fee per gas %s higher than max fee per gas %s",
+					msg.Data.MaxPriorityFeePerGas, msg.Data.MaxFeePerGas,
+				),
+			)
+		}
+	default:
+		return sdk.ConvertError(
+			sdkerrors.Wrapf(types.ErrInvalidValue, "unsupported transaction type %d", msg.Data.Type),
+		)
+	}
+
+	if err := msg.Data.Accesses.Validate(); err != nil {
+		return sdk.ConvertError(
+			sdkerrors.Wrap(types.ErrInvalidValue, err.Error()),
+		)
+	}
+
 	return nil
 }
 
@@ -176,8 +333,35 @@ func (msg MsgEthereumTx) GetSignBytes() []byte {
 }
 
 // RLPSignBytes returns the RLP hash of an Ethereum transaction message with a
-// given chainID used for signing.
+// given chainID used for signing. Typed transactions are hashed according to
+// their EIP-2718 payload format, ie. keccak256(type || rlp(payload)).
 func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
+	switch msg.Data.Type {
+	case AccessListTxType:
+		return prefixedRlpHash(msg.Data.Type, []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			msg.Data.Price,
+			msg.Data.GasLimit,
+			msg.Data.Recipient,
+			msg.Data.Amount,
+			msg.Data.Payload,
+			msg.Data.Accesses.rlpList(),
+		})
+	case DynamicFeeTxType:
+		return prefixedRlpHash(msg.Data.Type, []interface{}{
+			chainID,
+			msg.Data.AccountNonce,
+			msg.Data.MaxPriorityFeePerGas,
+			msg.Data.MaxFeePerGas,
+			msg.Data.GasLimit,
+			msg.Data.Recipient,
+			msg.Data.Amount,
+			msg.Data.Payload,
+			msg.Data.Accesses.rlpList(),
+		})
+	}
+
 	return rlpHash([]interface{}{
 		msg.Data.AccountNonce,
 		msg.Data.Price,
@@ -189,16 +373,57 @@ func (msg MsgEthereumTx) RLPSignBytes(chainID *big.Int) ethcmn.Hash {
 	})
 }
 
-// EncodeRLP implements the rlp.Encoder interface.
+// HomesteadSignBytes returns the RLP hash of a legacy Ethereum transaction
+// message signed without replay protection, ie. prior to EIP-155.
+func (msg MsgEthereumTx) HomesteadSignBytes() ethcmn.Hash {
+	return rlpHash([]interface{}{
+		msg.Data.AccountNonce,
+		msg.Data.Price,
+		msg.Data.GasLimit,
+		msg.Data.Recipient,
+		msg.Data.Amount,
+		msg.Data.Payload,
+	})
+}
+
+// EncodeRLP implements the rlp.Encoder interface. Legacy transactions are
+// encoded as an RLP list while typed transactions are encoded as an RLP string
+// containing the type byte followed by the RLP encoded payload.
 func (msg *MsgEthereumTx) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &msg.Data)
+	if msg.Data.Type == LegacyTxType {
+		return rlp.Encode(w, &msg.Data)
+	}
+
+	bz, err := msg.encodeTyped()
+	if err != nil {
+		return err
+	}
+
+	return rlp.Encode(w, bz)
 }
 
 // DecodeRLP implements the rlp.Decoder interface.
 func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+
+	if kind != rlp.List {
+		bz, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+
+		if err := msg.decodeTyped(bz); err != nil {
+			return err
+		}
 
-	err := s.Decode(&msg.Data)
+		msg.size.Store(ethcmn.StorageSize(rlp.ListSize(size)))
+		return nil
+	}
+
+	err = s.Decode(&msg.Data)
 	if err == nil {
 		msg.size.Store(ethcmn.StorageSize(rlp.ListSize(size)))
 	}
@@ -206,12 +431,127 @@ func (msg *MsgEthereumTx) DecodeRLP(s *rlp.Stream) error {
 	return err
 }
 
+// encodeTyped returns the EIP-2718 envelope of a typed transaction, ie. the
+// transaction type byte followed by the RLP encoded payload.
+func (msg *MsgEthereumTx) encodeTyped() ([]byte, error) {
+	var (
+		payload []byte
+		err     error
+	)
+
+	switch msg.Data.Type {
+	case AccessListTxType:
+		payload, err = rlp.EncodeToBytes(&accessListTxRLP{
+			ChainID:      msg.Data.ChainID,
+			AccountNonce: msg.Data.AccountNonce,
+			Price:        msg.Data.Price,
+			GasLimit:     msg.Data.GasLimit,
+			Recipient:    msg.Data.Recipient,
+			Amount:       msg.Data.Amount,
+			Payload:      msg.Data.Payload,
+			AccessList:   msg.Data.Accesses.rlpList(),
+			V:            msg.Data.V,
+			R:            msg.Data.R,
+			S:            msg.Data.S,
+		})
+	case DynamicFeeTxType:
+		payload, err = rlp.EncodeToBytes(&dynamicFeeTxRLP{
+			ChainID:              msg.Data.ChainID,
+			AccountNonce:         msg.Data.AccountNonce,
+			MaxPriorityFeePerGas: msg.Data.MaxPriorityFeePerGas,
+			MaxFeePerGas:         msg.Data.MaxFeePerGas,
+			GasLimit:             msg.Data.GasLimit,
+			Recipient:            msg.Data.Recipient,
+			Amount:               msg.Data.Amount,
+			Payload:              msg.Data.Payload,
+			AccessList:           msg.Data.Accesses.rlpList(),
+			V:                    msg.Data.V,
+			R:                    msg.Data.R,
+			S:                    msg.Data.S,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported transaction type %d", msg.Data.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{msg.Data.Type}, payload...), nil
+}
+
+// decodeTyped decodes an EIP-2718 envelope into the transaction data.
+func (msg *MsgEthereumTx) decodeTyped(bz []byte) error {
+	if len(bz) == 0 {
+		return errors.New("typed transaction too short")
+	}
+
+	switch bz[0] {
+	case AccessListTxType:
+		var tx accessListTxRLP
+		if err := rlp.DecodeBytes(bz[1:], &tx); err != nil {
+			return err
+		}
+
+		msg.Data = TxData{
+			Type:         AccessListTxType,
+			ChainID:      tx.ChainID,
+			AccountNonce: tx.AccountNonce,
+			Price:        tx.Price,
+			GasLimit:     tx.GasLimit,
+			Recipient:    tx.Recipient,
+			Amount:       tx.Amount,
+			Payload:      tx.Payload,
+			Accesses:     tx.AccessList,
+			V:            tx.V,
+			R:            tx.R,
+			S:            tx.S,
+		}
+
+		return nil
+	case DynamicFeeTxType:
+		var tx dynamicFeeTxRLP
+		if err := rlp.DecodeBytes(bz[1:], &tx); err != nil {
+			return err
+		}
+
+		msg.Data = TxData{
+			Type:                 DynamicFeeTxType,
+			ChainID:              tx.ChainID,
+			AccountNonce:         tx.AccountNonce,
+			MaxPriorityFeePerGas: tx.MaxPriorityFeePerGas,
+			MaxFeePerGas:         tx.MaxFeePerGas,
+			Price:                new(big.Int).Set(tx.MaxFeePerGas),
+			GasLimit:             tx.GasLimit,
+			Recipient:            tx.Recipient,
+			Amount:               tx.Amount,
+			Payload:              tx.Payload,
+			Accesses:             tx.AccessList,
+			V:                    tx.V,
+			R:                    tx.R,
+			S:                    tx.S,
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported transaction type %d", bz[0])
+	}
+}
+
 // Sign calculates a secp256k1 ECDSA signature and signs the transaction. It
 // takes a private key and chainID to sign an Ethereum transaction according to
-// EIP155 standard. It mutates the transaction as it populates the V, R, S
-// fields of the Transaction's Signature.
+// EIP155 standard. Legacy transactions signed with a nil or zero chain ID are
+// signed without replay protection, as by the homestead signer. It mutates the
+// transaction as it populates the V, R, S fields of the Transaction's Signature.
 func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) {
+	if chainID == nil {
+		chainID = new(big.Int)
+	}
+
 	txHash := msg.RLPSignBytes(chainID)
+	if msg.Data.Type == LegacyTxType && chainID.Sign() == 0 {
+		txHash = msg.HomesteadSignBytes()
+	}
 
 	sig, err := ethcrypto.Sign(txHash[:], priv)
 	if err != nil {
@@ -227,7 +567,12 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) {
 
 	var v *big.Int
 
-	if chainID.Sign() == 0 {
+	if msg.Data.Type != LegacyTxType {
+		// typed transactions carry the chain ID in the payload and the
+		// signature V value is the plain recovery ID
+		v = new(big.Int).SetBytes([]byte{sig[64]})
+		msg.Data.ChainID = new(big.Int).Set(chainID)
+	} else if chainID.Sign() == 0 {
 		v = new(big.Int).SetBytes([]byte{sig[64] + 27})
 	} else {
 		v = big.NewInt(int64(sig[64] + 35))
@@ -243,8 +588,28 @@ func (msg *MsgEthereumTx) Sign(chainID *big.Int, priv *ecdsa.PrivateKey) {
 
 // VerifySig attempts to verify a Transaction's signature for a given chainID.
 // A derived address is returned upon success or an error if recovery fails.
+// The legacy transactions with a V value of 27 or 28 aren't replay protected
+// and are verified with the homestead signer for any chain ID, which may then
+// be nil or zero. The returned error wraps types.ErrInvalidChainID if the
+// transaction is signed for another chain ID, types.ErrInvalidSignature if the
+// signature values are malformed, or types.ErrRecoveryFailed if the signer
+// public key cannot be recovered.
 func (msg *MsgEthereumTx) VerifySig(chainID *big.Int) (ethcmn.Address, error) {
-	signer := ethtypes.NewEIP155Signer(chainID)
+	if chainID == nil {
+		chainID = new(big.Int)
+	}
+
+	unprotected := msg.Data.Type == LegacyTxType && !isProtectedV(msg.Data.V)
+
+	// do not allow recovery of replay protected transactions for a zero chainID
+	if chainID.Sign() == 0 && !unprotected {
+		return ethcmn.Address{}, fmt.Errorf("chainID cannot be zero: %w", types.ErrInvalidChainID)
+	}
+
+	var signer ethtypes.Signer = ethtypes.NewEIP155Signer(chainID)
+	if unprotected {
+		signer = ethtypes.HomesteadSigner{}
+	}
 
 	if sc := msg.from.Load(); sc != nil {
 		sigCache := sc.(sigCache)
@@ -255,16 +620,11 @@ func (msg *MsgEthereumTx) VerifySig(chainID *big.Int) (ethcmn.Address, error) {
 		}
 	}
 
-	// do not allow recovery for transactions with an unprotected chainID
-	if chainID.Sign() == 0 {
-		return ethcmn.Address{}, errors.New("chainID cannot be zero")
+	V, sigHash, err := msg.signatureValues(chainID, unprotected)
+	if err != nil {
+		return ethcmn.Address{}, err
 	}
 
-	chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
-	V := new(big.Int).Sub(msg.Data.V, chainIDMul)
-	V.Sub(V, big8)
-
-	sigHash := msg.RLPSignBytes(chainID)
 	sender, err := recoverEthSig(msg.Data.R, msg.Data.S, V, sigHash)
 	if err != nil {
 		return ethcmn.Address{}, err
@@ -274,28 +634,171 @@ func (msg *MsgEthereumTx) VerifySig(chainID *big.Int) (ethcmn.Address, error) {
 	return sender, nil
 }
 
+// RecoverPubKey returns the 65 bytes uncompressed secp256k1 public key of the
+// signer of the transaction for the given chain ID. An error is returned if the
+// transaction isn't signed for the chain ID or if the public key doesn't hash to
+// the sender returned by VerifySig.
+func (msg *MsgEthereumTx) RecoverPubKey(chainID *big.Int) ([]byte, error) {
+	sender, err := msg.VerifySig(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	if chainID == nil {
+		chainID = new(big.Int)
+	}
+
+	unprotected := msg.Data.Type == LegacyTxType && !isProtectedV(msg.Data.V)
+	V, sigHash, err := msg.signatureValues(chainID, unprotected)
+	if err != nil {
+		return nil, err
+	}
+
+	pub, err := recoverEthPubKey(msg.Data.R, msg.Data.S, V, sigHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(ethcrypto.Keccak256(pub[1:])[12:], sender.Bytes()) {
+		return nil, fmt.Errorf("public key doesn't match sender %s: %w", sender.Hex(), types.ErrRecoveryFailed)
+	}
+
+	return pub, nil
+}
+
+// signatureValues returns the V value in the [27, 28] range and the signing hash
+// of the transaction signature for the given chain ID.
+func (msg *MsgEthereumTx) signatureValues(chainID *big.Int, unprotected bool) (*big.Int, ethcmn.Hash, error) {
+	switch {
+	case msg.Data.Type != LegacyTxType:
+		if msg.Data.ChainID == nil || msg.Data.ChainID.Cmp(chainID) != 0 {
+			return nil, ethcmn.Hash{}, fmt.Errorf(
+				"chain ID %s does not match expected %s: %w", msg.Data.ChainID, chainID, types.ErrInvalidChainID,
+			)
+		}
+
+		return new(big.Int).Add(msg.Data.V, big.NewInt(27)), msg.RLPSignBytes(chainID), nil
+	case unprotected:
+		return msg.Data.V, msg.HomesteadSignBytes(), nil
+	default:
+		if err := checkReplayProtection(msg.Data.V, chainID); err != nil {
+			return nil, ethcmn.Hash{}, err
+		}
+
+		chainIDMul := new(big.Int).Mul(chainID, big.NewInt(2))
+		V := new(big.Int).Sub(msg.Data.V, chainIDMul)
+		return V.Sub(V, big8), msg.RLPSignBytes(chainID), nil
+	}
+}
+
+// GetSender returns the sender of the transaction for the given chain ID. The
+// sender is only recovered from the signature on the first call and is cached
+// on the message for the later calls with the same chain ID. As for VerifySig,
+// the zero address and an error are returned if the transaction isn't signed
+// for the chain ID.
+func (msg *MsgEthereumTx) GetSender(chainID *big.Int) (ethcmn.Address, error) {
+	return msg.VerifySig(chainID)
+}
+
 // GetGas implements the GasTx interface. It returns the GasLimit of the transaction.
 func (msg MsgEthereumTx) GetGas() uint64 {
 	return msg.Data.GasLimit
 }
 
-// Fee returns gasprice * gaslimit.
-func (msg MsgEthereumTx) Fee() *big.Int {
-	return new(big.Int).Mul(msg.Data.Price, new(big.Int).SetUint64(msg.Data.GasLimit))
+// GasPrice returns the gas price of the transaction, a nil price being zero.
+func (msg MsgEthereumTx) GasPrice() *big.Int {
+	if msg.Data.Price == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(msg.Data.Price)
+}
+
+// GasFeeCap returns the maximum price per gas the transaction pays, which is
+// the max fee per gas of the EIP-1559 transactions and the gas price of the
+// others.
+func (msg MsgEthereumTx) GasFeeCap() *big.Int {
+	if msg.Data.Type != DynamicFeeTxType || msg.Data.MaxFeePerGas == nil {
+		return msg.GasPrice()
+	}
+	return new(big.Int).Set(msg.Data.MaxFeePerGas)
+}
+
+// GasTipCap returns the maximum price per gas the transaction pays above the
+// base fee, which is the max priority fee per gas of the EIP-1559 transactions
+// and the gas price of the others.
+func (msg MsgEthereumTx) GasTipCap() *big.Int {
+	if msg.Data.Type != DynamicFeeTxType || msg.Data.MaxPriorityFeePerGas == nil {
+		return msg.GasPrice()
+	}
+	return new(big.Int).Set(msg.Data.MaxPriorityFeePerGas)
+}
+
+// EffectiveGasPrice returns the price per gas paid by the transaction for the
+// given base fee. The EIP-1559 transactions pay the base fee plus their max
+// priority fee per gas, capped to their max fee per gas, while the others pay
+// their gas price. A nil base fee is zero.
+func (msg MsgEthereumTx) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	if msg.Data.Type != DynamicFeeTxType || msg.Data.MaxPriorityFeePerGas == nil || msg.Data.MaxFeePerGas == nil {
+		return msg.GasPrice()
+	}
+
+	price := new(big.Int).Set(msg.Data.MaxPriorityFeePerGas)
+	if baseFee != nil {
+		price.Add(price, baseFee)
+	}
+
+	if price.Cmp(msg.Data.MaxFeePerGas) > 0 {
+		return new(big.Int).Set(msg.Data.MaxFeePerGas)
+	}
+	return price
+}
+
+// EffectiveGasTip returns the price per gas paid by the transaction above the
+// given base fee, which is the priority fee received by the proposer. It is
+// negative if the gas fee cap is below the base fee.
+func (msg MsgEthereumTx) EffectiveGasTip(baseFee *big.Int) *big.Int {
+	tip := msg.EffectiveGasPrice(baseFee)
+	if baseFee != nil {
+		tip.Sub(tip, baseFee)
+	}
+	return tip
+}
+
+// Fee returns the effective gas price for the given base fee * gaslimit.
+func (msg MsgEthereumTx) Fee(baseFee *big.Int) *big.Int {
+	return new(big.Int).Mul(msg.EffectiveGasPrice(baseFee), new(big.Int).SetUint64(msg.Data.GasLimit))
 }
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (msg *MsgEthereumTx) ChainID() *big.Int {
+	if msg.Data.Type != LegacyTxType && msg.Data.ChainID != nil {
+		return new(big.Int).Set(msg.Data.ChainID)
+	}
 	return deriveChainID(msg.Data.V)
 }
 
-// Cost returns amount + gasprice * gaslimit.
-func (msg MsgEthereumTx) Cost() *big.Int {
-	total := msg.Fee()
+// Cost returns amount + effective gas price * gaslimit for the given base fee.
+func (msg MsgEthereumTx) Cost(baseFee *big.Int) *big.Int {
+	total := msg.Fee(baseFee)
 	total.Add(total, msg.Data.Amount)
 	return total
 }
 
+// Hash returns the Ethereum transaction hash, ie. the keccak256 hash of the
+// canonical encoding of the transaction.
+func (msg *MsgEthereumTx) Hash() ethcmn.Hash {
+	if msg.Data.Type == LegacyTxType {
+		return rlpHash(&msg.Data)
+	}
+
+	bz, err := msg.encodeTyped()
+	if err != nil {
+		panic(err)
+	}
+
+	return ethcrypto.Keccak256Hash(bz)
+}
+
 // From loads the ethereum sender address from the sigcache and returns an
 // sdk.AccAddress from its bytes
 func (msg *MsgEthereumTx) From() sdk.AccAddress {
@@ -313,6 +816,33 @@ func (msg *MsgEthereumTx) From() sdk.AccAddress {
 	return sdk.AccAddress(sigCache.from.Bytes())
 }
 
+// isProtectedV returns whether the given legacy signature V value is EIP-155
+// replay protected, ie. it isn't 27 or 28.
+func isProtectedV(v *big.Int) bool {
+	if v != nil && v.BitLen() <= 8 {
+		vb := v.Uint64()
+		return vb != 27 && vb != 28
+	}
+	return true
+}
+
+// checkReplayProtection returns an error wrapping types.ErrInvalidChainID if
+// the given EIP-155 signature V value is not protected for the chain ID.
+// Values that are not EIP-155 values are left to the signature validation.
+func checkReplayProtection(v, chainID *big.Int) error {
+	if v == nil || (v.BitLen() <= 8 && v.Uint64() < 35) {
+		return nil
+	}
+
+	if txChainID := deriveChainID(v); txChainID.Cmp(chainID) != 0 {
+		return fmt.Errorf(
+			"chain ID %s does not match expected %s: %w", txChainID, chainID, types.ErrInvalidChainID,
+		)
+	}
+
+	return nil
+}
+
 // deriveChainID derives the chain id from the given v parameter
 func deriveChainID(v *big.Int) *big.Int {
 	if v.BitLen() <= 64 {
@@ -329,8 +859,77 @@ func deriveChainID(v *big.Int) *big.Int {
 // ----------------------------------------------------------------------------
 // Auxiliary
 
+// EncodeTx returns the canonical Ethereum encoding of a transaction as used on
+// the wire (eg. by eth_sendRawTransaction). Legacy transactions are encoded as
+// an RLP list while typed transactions are encoded as the transaction type byte
+// followed by the RLP encoded payload.
+func EncodeTx(msg *MsgEthereumTx) ([]byte, error) {
+	if msg.Data.Type == LegacyTxType {
+		return rlp.EncodeToBytes(&msg.Data)
+	}
+	return msg.encodeTyped()
+}
+
+// DecodeTx decodes a transaction from its canonical Ethereum encoding. Legacy
+// and typed payloads are distinguished by inspecting the first byte: an RLP
+// list always starts with a byte >= 0xc0, while a typed transaction starts with
+// its transaction type in the range [0x00, 0x7f].
+func DecodeTx(bz []byte) (*MsgEthereumTx, error) {
+	if len(bz) == 0 {
+		return nil, errors.New("transaction bytes are empty")
+	}
+
+	msg := new(MsgEthereumTx)
+
+	if bz[0] > 0x7f {
+		if err := rlp.DecodeBytes(bz, msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+
+	if err := msg.decodeTyped(bz); err != nil {
+		return nil, err
+	}
+
+	msg.size.Store(ethcmn.StorageSize(len(bz)))
+	return msg, nil
+}
+
+// MaxTxBytesSize is the maximum size of the raw transactions decoded by
+// DecodeTxBytes. It leaves room for a payload of DefaultMaxTxSize along with
+// the other transaction fields and an access list.
+const MaxTxBytesSize = 2 * DefaultMaxTxSize
+
+// DecodeTxBytes decodes a transaction from its canonical Ethereum encoding, as
+// DecodeTx does, guarding against the untrusted inputs such as the raw
+// transactions submitted over RPC. The raw bytes must not exceed
+// MaxTxBytesSize and must hold a single RLP item or typed envelope, without
+// trailing bytes. A malformed input returns an error and never panics.
+func DecodeTxBytes(raw []byte) (msg MsgEthereumTx, err error) {
+	if len(raw) > MaxTxBytesSize {
+		return MsgEthereumTx{}, fmt.Errorf("transaction size %d exceeds the maximum of %d bytes", len(raw), MaxTxBytesSize)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			msg, err = MsgEthereumTx{}, fmt.Errorf("malformed transaction: %v", r)
+		}
+	}()
+
+	decoded, err := DecodeTx(raw)
+	if err != nil {
+		return MsgEthereumTx{}, err
+	}
+
+	return *decoded, nil
+}
+
 // TxDecoder returns an sdk.TxDecoder that can decode both auth.StdTx and
-// MsgEthereumTx transactions.
+// MsgEthereumTx transactions. The transactions are Amino encoded, falling back
+// to the canonical Ethereum encoding for the raw Ethereum transactions, which
+// are decoded as a MsgEthereumTx so that they are routed to the Ethereum ante
+// handler.
 func TxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 	return func(txBytes []byte) (sdk.Tx, sdk.Error) {
 		var tx sdk.Tx
@@ -344,13 +943,38 @@ func TxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 		// sdk.Tx is an interface. The concrete message types
 		// are registered by MakeTxCodec
 		err := cdc.UnmarshalBinaryLengthPrefixed(txBytes, &tx)
-		if err != nil {
+		if err == nil {
+			return tx, nil
+		}
+
+		if !isEthTxBytes(txBytes) {
 			return nil, sdk.ConvertError(
 				sdkerrors.Wrap(sdkerrors.ErrTxDecode, err.Error()),
 			)
 		}
 
-		return tx, nil
+		msg, rlpErr := DecodeTxBytes(txBytes)
+		if rlpErr != nil {
+			return nil, sdk.ConvertError(
+				sdkerrors.Wrapf(sdkerrors.ErrTxDecode, "%s; Ethereum decoding: %s", err, rlpErr),
+			)
+		}
+
+		return msg, nil
+	}
+}
+
+// isEthTxBytes returns true if the bytes look like a transaction in the
+// canonical Ethereum encoding: an RLP list for the legacy transactions or a
+// supported transaction type followed by an RLP list for the typed ones.
+func isEthTxBytes(bz []byte) bool {
+	switch {
+	case len(bz) > 0 && bz[0] >= 0xc0:
+		return true
+	case len(bz) > 1 && (bz[0] == AccessListTxType || bz[0] == DynamicFeeTxType):
+		return bz[1] >= 0xc0
+	default:
+		return false
 	}
 }
 
@@ -360,13 +984,28 @@ func TxDecoder(cdc *codec.Codec) sdk.TxDecoder {
 // Ref: Ethereum Yellow Paper (BYZANTIUM VERSION 69351d5) Appendix F
 // nolint: gocritic
 func recoverEthSig(R, S, Vb *big.Int, sigHash ethcmn.Hash) (ethcmn.Address, error) {
+	pub, err := recoverEthPubKey(R, S, Vb, sigHash)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	var addr ethcmn.Address
+	copy(addr[:], ethcrypto.Keccak256(pub[1:])[12:])
+
+	return addr, nil
+}
+
+// recoverEthPubKey returns the 65 bytes uncompressed public key recovered from
+// the signature values and the signing hash.
+// nolint: gocritic
+func recoverEthPubKey(R, S, Vb *big.Int, sigHash ethcmn.Hash) ([]byte, error) {
 	if Vb.BitLen() > 8 {
-		return ethcmn.Address{}, errors.New("invalid signature")
+		return nil, fmt.Errorf("V value out of range: %w", types.ErrInvalidSignature)
 	}
 
 	V := byte(Vb.Uint64() - 27)
 	if !ethcrypto.ValidateSignatureValues(V, R, S, true) {
-		return ethcmn.Address{}, errors.New("invalid signature")
+		return nil, fmt.Errorf("invalid signature values: %w", types.ErrInvalidSignature)
 	}
 
 	// encode the signature in uncompressed format
@@ -380,15 +1019,63 @@ func recoverEthSig(R, S, Vb *big.Int, sigHash ethcmn.Hash) (ethcmn.Address, erro
 	// recover the public key from the signature
 	pub, err := ethcrypto.Ecrecover(sigHash[:], sig)
 	if err != nil {
-		return ethcmn.Address{}, err
+		return nil, fmt.Errorf("%s: %w", err, types.ErrRecoveryFailed)
 	}
 
-	if len(pub) == 0 || pub[0] != 4 {
-		return ethcmn.Address{}, errors.New("invalid public key")
+	if len(pub) != 65 || pub[0] != 4 {
+		return nil, fmt.Errorf("invalid public key: %w", types.ErrRecoveryFailed)
 	}
 
-	var addr ethcmn.Address
-	copy(addr[:], ethcrypto.Keccak256(pub[1:])[12:])
+	return pub, nil
+}
 
-	return addr, nil
+// VerifySigs verifies the signatures of a batch of transactions for a given
+// chainID, recovering the signers in parallel with a worker pool sized to
+// GOMAXPROCS. The derived addresses are returned in the same order as the
+// given transactions and are identical to the ones returned by VerifySig.
+//
+// If a signature fails to verify, the remaining work is stopped and an error
+// wrapping the failure of the lowest failed transaction index is returned.
+func VerifySigs(chainID *big.Int, msgs []MsgEthereumTx) ([]ethcmn.Address, error) {
+	signers := make([]ethcmn.Address, len(msgs))
+	errs := make([]error, len(msgs))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(msgs) {
+		workers = len(msgs)
+	}
+
+	var (
+		wg     sync.WaitGroup
+		next   int64 = -1
+		failed int32
+	)
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for atomic.LoadInt32(&failed) == 0 {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(msgs) {
+					return
+				}
+
+				signers[i], errs[i] = msgs[i].VerifySig(chainID)
+				if errs[i] != nil {
+					atomic.StoreInt32(&failed, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of transaction %d: %w", i, err)
+		}
+	}
+
+	return signers, nil
 }
@@ -1,9 +1,15 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
 	"github.com/cosmos/ethermint/utils"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 // EncodableTxData implements the Ethereum transaction data structure. It is used
@@ -23,6 +29,13 @@ type EncodableTxData struct {
 
 	// hash is only used when marshaling to JSON
 	Hash *ethcmn.Hash `json:"hash" rlp:"-"`
+
+	// typed transaction fields
+	Type                 uint8      `json:"type" rlp:"-"`
+	ChainID              string     `json:"chainId,omitempty" rlp:"-"`
+	MaxPriorityFeePerGas string     `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+	MaxFeePerGas         string     `json:"maxFeePerGas,omitempty" rlp:"-"`
+	Accesses             AccessList `json:"accessList,omitempty" rlp:"-"`
 }
 
 func marshalAmino(td EncodableTxData) (string, error) {
@@ -34,6 +47,24 @@ func unmarshalAmino(td *EncodableTxData, text string) error {
 	return ModuleCdc.UnmarshalBinaryBare([]byte(text), td)
 }
 
+// marshalOptionalBigInt encodes an optional big int, where a nil value is
+// encoded as an empty string.
+func marshalOptionalBigInt(i *big.Int) string {
+	if i == nil {
+		return ""
+	}
+	return utils.MarshalBigInt(i)
+}
+
+// unmarshalOptionalBigInt decodes an optional big int, where an empty string
+// is decoded as a nil value.
+func unmarshalOptionalBigInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	return utils.UnmarshalBigInt(s)
+}
+
 // MarshalAmino defines custom encoding scheme for TxData
 func (td TxData) MarshalAmino() (string, error) {
 	e := EncodableTxData{
@@ -49,6 +80,12 @@ func (td TxData) MarshalAmino() (string, error) {
 		S: utils.MarshalBigInt(td.S),
 
 		Hash: td.Hash,
+
+		Type:                 td.Type,
+		ChainID:              marshalOptionalBigInt(td.ChainID),
+		MaxPriorityFeePerGas: marshalOptionalBigInt(td.MaxPriorityFeePerGas),
+		MaxFeePerGas:         marshalOptionalBigInt(td.MaxFeePerGas),
+		Accesses:             td.Accesses,
 	}
 
 	return marshalAmino(e)
@@ -67,6 +104,8 @@ func (td *TxData) UnmarshalAmino(text string) error {
 	td.Recipient = e.Recipient
 	td.Payload = e.Payload
 	td.Hash = e.Hash
+	td.Type = e.Type
+	td.Accesses = e.Accesses
 
 	price, err := utils.UnmarshalBigInt(e.Price)
 	if err != nil {
@@ -123,9 +162,164 @@ func (td *TxData) UnmarshalAmino(text string) error {
 		td.S = s
 	}
 
+	td.ChainID, err = unmarshalOptionalBigInt(e.ChainID)
+	if err != nil {
+		return err
+	}
+
+	td.MaxPriorityFeePerGas, err = unmarshalOptionalBigInt(e.MaxPriorityFeePerGas)
+	if err != nil {
+		return err
+	}
+
+	td.MaxFeePerGas, err = unmarshalOptionalBigInt(e.MaxFeePerGas)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // TODO: Implement JSON marshaling/ unmarshaling for this type
 
 // TODO: Implement YAML marshaling/ unmarshaling for this type
+
+// txDataJSON defines the Ethereum JSON representation of TxData, where the
+// numeric fields are hex quantities and the byte fields are hex data. The
+// required fields are pointers to detect their absence when decoding.
+type txDataJSON struct {
+	Type         hexutil.Uint64  `json:"type"`
+	AccountNonce *hexutil.Uint64 `json:"nonce"`
+	Price        *hexutil.Big    `json:"gasPrice"`
+	GasLimit     *hexutil.Uint64 `json:"gas"`
+	Recipient    *ethcmn.Address `json:"to"`
+	Amount       *hexutil.Big    `json:"value"`
+	Payload      *hexutil.Bytes  `json:"input"`
+
+	V *hexutil.Big `json:"v"`
+	R *hexutil.Big `json:"r"`
+	S *hexutil.Big `json:"s"`
+
+	Hash *ethcmn.Hash `json:"hash,omitempty"`
+
+	ChainID              *hexutil.Big `json:"chainId,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas,omitempty"`
+	Accesses             AccessList   `json:"accessList,omitempty"`
+}
+
+// unmarshalHexBigInt returns the big int of a decoded hex quantity, normalized
+// as the big ints set by the message constructors.
+func unmarshalHexBigInt(i *hexutil.Big) *big.Int {
+	if i == nil {
+		return nil
+	}
+	return new(big.Int).Set(i.ToInt())
+}
+
+// MarshalJSON encodes TxData as the canonical Ethereum transaction JSON
+func (td TxData) MarshalJSON() ([]byte, error) {
+	payload := hexutil.Bytes(td.Payload)
+	nonce := hexutil.Uint64(td.AccountNonce)
+	gasLimit := hexutil.Uint64(td.GasLimit)
+
+	return json.Marshal(txDataJSON{
+		Type:         hexutil.Uint64(td.Type),
+		AccountNonce: &nonce,
+		Price:        (*hexutil.Big)(td.Price),
+		GasLimit:     &gasLimit,
+		Recipient:    td.Recipient,
+		Amount:       (*hexutil.Big)(td.Amount),
+		Payload:      &payload,
+
+		V: (*hexutil.Big)(td.V),
+		R: (*hexutil.Big)(td.R),
+		S: (*hexutil.Big)(td.S),
+
+		Hash: td.Hash,
+
+		ChainID:              (*hexutil.Big)(td.ChainID),
+		MaxPriorityFeePerGas: (*hexutil.Big)(td.MaxPriorityFeePerGas),
+		MaxFeePerGas:         (*hexutil.Big)(td.MaxFeePerGas),
+		Accesses:             td.Accesses,
+	})
+}
+
+// UnmarshalJSON decodes TxData from the canonical Ethereum transaction JSON
+func (td *TxData) UnmarshalJSON(input []byte) error {
+	var dec txDataJSON
+	if err := json.Unmarshal(input, &dec); err != nil {
+		return err
+	}
+
+	switch {
+	case dec.AccountNonce == nil:
+		return errors.New("missing required field 'nonce' for TxData")
+	case dec.Price == nil:
+		return errors.New("missing required field 'gasPrice' for TxData")
+	case dec.GasLimit == nil:
+		return errors.New("missing required field 'gas' for TxData")
+	case dec.Amount == nil:
+		return errors.New("missing required field 'value' for TxData")
+	case dec.Payload == nil:
+		return errors.New("missing required field 'input' for TxData")
+	case dec.V == nil || dec.R == nil || dec.S == nil:
+		return errors.New("missing required signature fields 'v', 'r' and 's' for TxData")
+	}
+
+	switch uint8(dec.Type) {
+	case LegacyTxType, AccessListTxType, DynamicFeeTxType:
+	default:
+		return fmt.Errorf("unsupported transaction type %d", dec.Type)
+	}
+
+	*td = TxData{
+		AccountNonce: uint64(*dec.AccountNonce),
+		Price:        unmarshalHexBigInt(dec.Price),
+		GasLimit:     uint64(*dec.GasLimit),
+		Recipient:    dec.Recipient,
+		Amount:       unmarshalHexBigInt(dec.Amount),
+
+		V: unmarshalHexBigInt(dec.V),
+		R: unmarshalHexBigInt(dec.R),
+		S: unmarshalHexBigInt(dec.S),
+
+		Hash: dec.Hash,
+
+		Type:                 uint8(dec.Type),
+		ChainID:              unmarshalHexBigInt(dec.ChainID),
+		MaxPriorityFeePerGas: unmarshalHexBigInt(dec.MaxPriorityFeePerGas),
+		MaxFeePerGas:         unmarshalHexBigInt(dec.MaxFeePerGas),
+		Accesses:             dec.Accesses,
+	}
+
+	// an empty payload is nil as set by the message constructors
+	if len(*dec.Payload) > 0 {
+		td.Payload = *dec.Payload
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the message as the canonical Ethereum transaction JSON,
+// including the transaction hash.
+func (msg MsgEthereumTx) MarshalJSON() ([]byte, error) {
+	data := msg.Data
+	hash := msg.Hash()
+	data.Hash = &hash
+
+	return data.MarshalJSON()
+}
+
+// UnmarshalJSON decodes the message from the canonical Ethereum transaction
+// JSON. The hash isn't decoded as it is derived from the transaction data.
+func (msg *MsgEthereumTx) UnmarshalJSON(input []byte) error {
+	var data TxData
+	if err := data.UnmarshalJSON(input); err != nil {
+		return err
+	}
+
+	data.Hash = nil
+	*msg = MsgEthereumTx{Data: data}
+	return nil
+}
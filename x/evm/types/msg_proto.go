@@ -0,0 +1,362 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/gogo/protobuf/proto"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ proto.Message = &MsgEthereumTx{}
+	_ proto.Message = &TxData{}
+	_ proto.Message = &AccessTuple{}
+)
+
+func init() {
+	proto.RegisterType((*MsgEthereumTx)(nil), "ethermint.evm.v1.MsgEthereumTx")
+	proto.RegisterType((*TxData)(nil), "ethermint.evm.v1.TxData")
+	proto.RegisterType((*AccessTuple)(nil), "ethermint.evm.v1.AccessTuple")
+}
+
+// protobuf wire types
+const (
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+// Marshal encodes the message as the MsgEthereumTx protobuf message defined in
+// msg.proto. The signing bytes are unaffected as they are the RLP hash of the
+// transaction.
+func (msg *MsgEthereumTx) Marshal() ([]byte, error) {
+	data, err := msg.Data.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return appendProtoBytes(nil, 1, data), nil
+}
+
+// Unmarshal decodes the message from the MsgEthereumTx protobuf message
+// defined in msg.proto.
+func (msg *MsgEthereumTx) Unmarshal(bz []byte) error {
+	*msg = MsgEthereumTx{}
+
+	return decodeProtoFields(bz, func(field int, wireType uint64, _ uint64, value []byte) error {
+		if field != 1 {
+			return nil
+		}
+		if wireType != protoWireBytes {
+			return fmt.Errorf("invalid wire type %d for MsgEthereumTx field %d", wireType, field)
+		}
+		return msg.Data.Unmarshal(value)
+	})
+}
+
+// Reset implements proto.Message.
+func (msg *MsgEthereumTx) Reset() { *msg = MsgEthereumTx{} }
+
+// String implements proto.Message by returning the Ethereum JSON of the
+// transaction.
+func (msg *MsgEthereumTx) String() string {
+	bz, _ := json.Marshal(msg)
+	return string(bz)
+}
+
+// ProtoMessage implements proto.Message.
+func (*MsgEthereumTx) ProtoMessage() {}
+
+// Marshal encodes the transaction data as the TxData protobuf message defined
+// in msg.proto. The big ints are encoded losslessly as the bytes of their
+// absolute value, the nil ones being omitted, so negative ints are rejected.
+func (td *TxData) Marshal() ([]byte, error) {
+	for _, i := range []*big.Int{
+		td.Price, td.Amount, td.V, td.R, td.S, td.ChainID, td.MaxPriorityFeePerGas, td.MaxFeePerGas,
+	} {
+		if i != nil && i.Sign() < 0 {
+			return nil, fmt.Errorf("cannot encode negative integer %s", i)
+		}
+	}
+
+	var bz []byte
+	bz = appendProtoVarint(bz, 1, td.AccountNonce)
+	bz = appendProtoBigInt(bz, 2, td.Price)
+	bz = appendProtoVarint(bz, 3, td.GasLimit)
+	if td.Recipient != nil {
+		bz = appendProtoBytes(bz, 4, td.Recipient.Bytes())
+	}
+	bz = appendProtoBigInt(bz, 5, td.Amount)
+	if len(td.Payload) > 0 {
+		bz = appendProtoBytes(bz, 6, td.Payload)
+	}
+
+	bz = appendProtoBigInt(bz, 7, td.V)
+	bz = appendProtoBigInt(bz, 8, td.R)
+	bz = appendProtoBigInt(bz, 9, td.S)
+
+	if td.Hash != nil {
+		bz = appendProtoBytes(bz, 10, td.Hash.Bytes())
+	}
+
+	bz = appendProtoVarint(bz, 11, uint64(td.Type))
+	bz = appendProtoBigInt(bz, 12, td.ChainID)
+	bz = appendProtoBigInt(bz, 13, td.MaxPriorityFeePerGas)
+	bz = appendProtoBigInt(bz, 14, td.MaxFeePerGas)
+	for i := range td.Accesses {
+		tuple, err := td.Accesses[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		bz = appendProtoBytes(bz, 15, tuple)
+	}
+
+	return bz, nil
+}
+
+// Unmarshal decodes the transaction data from the TxData protobuf message
+// defined in msg.proto.
+func (td *TxData) Unmarshal(bz []byte) error {
+	*td = TxData{}
+
+	return decodeProtoFields(bz, func(field int, wireType uint64, varint uint64, value []byte) error {
+		switch field {
+		case 1, 3, 11:
+			if wireType != protoWireVarint {
+				return fmt.Errorf("invalid wire type %d for TxData field %d", wireType, field)
+			}
+		case 2, 4, 5, 6, 7, 8, 9, 10, 12, 13, 14, 15:
+			if wireType != protoWireBytes {
+				return fmt.Errorf("invalid wire type %d for TxData field %d", wireType, field)
+			}
+		}
+
+		switch field {
+		case 1:
+			td.AccountNonce = varint
+		case 2:
+			td.Price = decodeProtoBigInt(value)
+		case 3:
+			td.GasLimit = varint
+		case 4:
+			if len(value) != ethcmn.AddressLength {
+				return fmt.Errorf("invalid recipient length %d", len(value))
+			}
+			recipient := ethcmn.BytesToAddress(value)
+			td.Recipient = &recipient
+		case 5:
+			td.Amount = decodeProtoBigInt(value)
+		case 6:
+			// an empty payload is nil as set by the message constructors
+			if len(value) > 0 {
+				td.Payload = ethcmn.CopyBytes(value)
+			}
+		case 7:
+			td.V = decodeProtoBigInt(value)
+		case 8:
+			td.R = decodeProtoBigInt(value)
+		case 9:
+			td.S = decodeProtoBigInt(value)
+		case 10:
+			if len(value) != ethcmn.HashLength {
+				return fmt.Errorf("invalid hash length %d", len(value))
+			}
+			hash := ethcmn.BytesToHash(value)
+			td.Hash = &hash
+		case 11:
+			if varint > 0xff {
+				return fmt.Errorf("invalid transaction type %d", varint)
+			}
+			td.Type = uint8(varint)
+		case 12:
+			td.ChainID = decodeProtoBigInt(value)
+		case 13:
+			td.MaxPriorityFeePerGas = decodeProtoBigInt(value)
+		case 14:
+			td.MaxFeePerGas = decodeProtoBigInt(value)
+		case 15:
+			var tuple AccessTuple
+			if err := tuple.Unmarshal(value); err != nil {
+				return err
+			}
+			td.Accesses = append(td.Accesses, tuple)
+		}
+
+		return nil
+	})
+}
+
+// Reset implements proto.Message.
+func (td *TxData) Reset() { *td = TxData{} }
+
+// String implements proto.Message by returning the Ethereum JSON of the
+// transaction data.
+func (td *TxData) String() string {
+	bz, _ := td.MarshalJSON()
+	return string(bz)
+}
+
+// ProtoMessage implements proto.Message.
+func (*TxData) ProtoMessage() {}
+
+// Marshal encodes the tuple as the AccessTuple protobuf message defined in
+// msg.proto.
+func (at *AccessTuple) Marshal() ([]byte, error) {
+	bz := appendProtoBytes(nil, 1, at.Address.Bytes())
+	for _, key := range at.StorageKeys {
+		bz = appendProtoBytes(bz, 2, key.Bytes())
+	}
+
+	return bz, nil
+}
+
+// Unmarshal decodes the tuple from the AccessTuple protobuf message defined in
+// msg.proto.
+func (at *AccessTuple) Unmarshal(bz []byte) error {
+	*at = AccessTuple{}
+
+	return decodeProtoFields(bz, func(field int, wireType uint64, _ uint64, value []byte) error {
+		if field != 1 && field != 2 {
+			return nil
+		}
+		if wireType != protoWireBytes {
+			return fmt.Errorf("invalid wire type %d for AccessTuple field %d", wireType, field)
+		}
+
+		if field == 1 {
+			if len(value) != ethcmn.AddressLength {
+				return fmt.Errorf("invalid access list address length %d", len(value))
+			}
+			at.Address = ethcmn.BytesToAddress(value)
+			return nil
+		}
+
+		if len(value) != ethcmn.HashLength {
+			return fmt.Errorf("invalid access list storage key length %d", len(value))
+		}
+		at.StorageKeys = append(at.StorageKeys, ethcmn.BytesToHash(value))
+		return nil
+	})
+}
+
+// Reset implements proto.Message.
+func (at *AccessTuple) Reset() { *at = AccessTuple{} }
+
+// String implements proto.Message by returning the JSON of the tuple.
+func (at *AccessTuple) String() string {
+	bz, _ := json.Marshal(at)
+	return string(bz)
+}
+
+// ProtoMessage implements proto.Message.
+func (*AccessTuple) ProtoMessage() {}
+
+// appendUvarint appends the varint encoding of an unsigned integer.
+func appendUvarint(bz []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(bz, buf[:n]...)
+}
+
+// appendProtoVarint appends a varint field, omitted if zero as in proto3.
+func appendProtoVarint(bz []byte, field int, value uint64) []byte {
+	if value == 0 {
+		return bz
+	}
+
+	bz = appendUvarint(bz, uint64(field)<<3|protoWireVarint)
+	return appendUvarint(bz, value)
+}
+
+// appendProtoBytes appends a length-delimited field.
+func appendProtoBytes(bz []byte, field int, value []byte) []byte {
+	bz = appendUvarint(bz, uint64(field)<<3|protoWireBytes)
+	bz = appendUvarint(bz, uint64(len(value)))
+	return append(bz, value...)
+}
+
+// appendProtoBigInt appends a big int field as the big-endian bytes of its
+// absolute value. A nil big int is omitted while a zero one is encoded as empty
+// bytes, so that both are distinguished when decoding.
+func appendProtoBigInt(bz []byte, field int, i *big.Int) []byte {
+	if i == nil {
+		return bz
+	}
+	return appendProtoBytes(bz, field, i.Bytes())
+}
+
+// decodeProtoBigInt decodes a big int field, normalized as the big ints set by
+// the message constructors.
+func decodeProtoBigInt(value []byte) *big.Int {
+	if len(value) == 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(value)
+}
+
+// decodeProtoFields calls fn with the number, wire type and value of each field
+// of an encoded protobuf message, the value being passed as the varint of the
+// varint fields and as the bytes of the length-delimited ones. The fixed-size
+// fields are skipped as none are defined in msg.proto.
+func decodeProtoFields(bz []byte, fn func(field int, wireType, varint uint64, value []byte) error) error {
+	for len(bz) > 0 {
+		key, n := binary.Uvarint(bz)
+		if n <= 0 {
+			return errors.New("invalid protobuf field key")
+		}
+		bz = bz[n:]
+
+		field, wireType := key>>3, key&0x7
+		if field == 0 || field > 1<<29-1 {
+			return fmt.Errorf("invalid protobuf field number %d", field)
+		}
+
+		var (
+			varint uint64
+			value  []byte
+		)
+
+		switch wireType {
+		case protoWireVarint:
+			varint, n = binary.Uvarint(bz)
+			if n <= 0 {
+				return fmt.Errorf("invalid varint for protobuf field %d", field)
+			}
+			bz = bz[n:]
+
+		case protoWireBytes:
+			length, n := binary.Uvarint(bz)
+			if n <= 0 || length > uint64(len(bz)-n) {
+				return fmt.Errorf("invalid length for protobuf field %d", field)
+			}
+			value = bz[n : n+int(length)]
+			bz = bz[n+int(length):]
+
+		case protoWireFixed64, protoWireFixed32:
+			size := 8
+			if wireType == protoWireFixed32 {
+				size = 4
+			}
+			if len(bz) < size {
+				return fmt.Errorf("invalid fixed-size protobuf field %d", field)
+			}
+			bz = bz[size:]
+			continue
+
+		default:
+			return fmt.Errorf("unsupported wire type %d for protobuf field %d", wireType, field)
+		}
+
+		if err := fn(int(field), wireType, varint, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
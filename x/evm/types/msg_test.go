@@ -2,16 +2,24 @@ package types
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/ethermint/crypto"
+	"github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/utils"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -40,16 +48,22 @@ func TestMsgEthereumTxValidation(t *testing.T) {
 		gasPrice   *big.Int
 		gasLimit   uint64
 		nonce      uint64
-		to         ethcmn.Address
+		to         *ethcmn.Address
 		expectPass bool
 	}{
-		{amount: big.NewInt(100), gasPrice: big.NewInt(100000), expectPass: true},
-		{amount: big.NewInt(-1), gasPrice: big.NewInt(100000), expectPass: false},
-		{amount: big.NewInt(100), gasPrice: big.NewInt(-1), expectPass: false},
+		{amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 21000, to: &ethcmn.Address{}, expectPass: true},
+		{amount: big.NewInt(-1), gasPrice: big.NewInt(100000), gasLimit: 21000, to: &ethcmn.Address{}, expectPass: false},
+		{amount: big.NewInt(100), gasPrice: big.NewInt(-1), gasLimit: 21000, to: &ethcmn.Address{}, expectPass: false},
+		// intrinsic gas: 21000 base + 4 per zero byte + 16 per non-zero byte + 32000 for contract creation
+		{amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 20999, to: &ethcmn.Address{}, expectPass: false},
+		{payload: []byte{0, 1}, amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 21020, to: &ethcmn.Address{}, expectPass: true},
+		{payload: []byte{0, 1}, amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 21019, to: &ethcmn.Address{}, expectPass: false},
+		{payload: []byte{0, 1}, amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 53020, expectPass: true},
+		{payload: []byte{0, 1}, amount: big.NewInt(100), gasPrice: big.NewInt(100000), gasLimit: 53019, expectPass: false},
 	}
 
 	for i, tc := range testCases {
-		msg := NewMsgEthereumTx(tc.nonce, &tc.to, tc.amount, tc.gasLimit, tc.gasPrice, tc.payload)
+		msg := NewMsgEthereumTx(tc.nonce, tc.to, tc.amount, tc.gasLimit, tc.gasPrice, tc.payload)
 
 		if tc.expectPass {
 			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
@@ -59,6 +73,42 @@ func TestMsgEthereumTxValidation(t *testing.T) {
 	}
 }
 
+func TestMsgEthereumTxValidationGasPrice(t *testing.T) {
+	addr := GenerateEthAddress()
+
+	nilPrice := NewMsgEthereumTx(0, &addr, big.NewInt(100), 21000, nil, nil)
+	nilPrice.Data.Price = nil
+
+	dynamicFee := NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(0), big.NewInt(0), nil)
+	dynamicFee.Data.Price = nil
+
+	testCases := []struct {
+		name       string
+		msg        MsgEthereumTx
+		expectPass bool
+	}{
+		{"nil gas price", nilPrice, false},
+		{"zero gas price", NewMsgEthereumTx(0, &addr, big.NewInt(100), 21000, big.NewInt(0), nil), false},
+		{"positive gas price", NewMsgEthereumTx(0, &addr, big.NewInt(100), 21000, big.NewInt(1), nil), true},
+		{"zero access list gas price", NewMsgEthereumTxAccessList(0, &addr, big.NewInt(100), 21000, big.NewInt(0), nil, nil), false},
+		{"dynamic fee without gas price", dynamicFee, true},
+	}
+
+	for _, tc := range testCases {
+		if tc.expectPass {
+			require.Nil(t, tc.msg.ValidateBasic(), tc.name)
+		} else {
+			require.NotNil(t, tc.msg.ValidateBasic(), tc.name)
+		}
+	}
+
+	// require a nil gas price, eg. decoded from an omitted field, to be zero
+	require.Equal(t, new(big.Int), nilPrice.GasPrice())
+	require.Equal(t, new(big.Int), nilPrice.GasFeeCap())
+	require.Equal(t, new(big.Int), nilPrice.Fee(big.NewInt(10)))
+	require.Equal(t, big.NewInt(100), nilPrice.Cost(nil))
+}
+
 func TestMsgEthereumTxRLPSignBytes(t *testing.T) {
 	addr := ethcmn.BytesToAddress([]byte("test_address"))
 	chainID := big.NewInt(3)
@@ -68,6 +118,58 @@ func TestMsgEthereumTxRLPSignBytes(t *testing.T) {
 	require.Equal(t, "5BD30E35AD27449390B14C91E6BCFDCAADF8FE44EF33680E3BC200FC0DC083C7", fmt.Sprintf("%X", hash))
 }
 
+func TestMsgEthereumTxEIP712SignBytes(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+	chainID := big.NewInt(3)
+
+	msg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
+	hash, err := msg.EIP712SignBytes(chainID)
+	require.NoError(t, err)
+	require.Equal(t, "4F5780C6031F7CF5C06533B56693673795B0385E049D9E976F9914F2A527CE18", fmt.Sprintf("%X", hash))
+
+	// require the hash to commit to the chain ID of the domain
+	other, err := msg.EIP712SignBytes(big.NewInt(4))
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other)
+
+	_, err = msg.EIP712SignBytes(big.NewInt(0))
+	require.True(t, types.ErrInvalidChainID.Is(err))
+}
+
+func TestMsgEthereumTxEIP712Sig(t *testing.T) {
+	chainID := big.NewInt(3)
+
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+
+	msg := NewMsgEthereumTx(0, &addr, big.NewInt(10), 100000, big.NewInt(1), []byte("test"))
+	hash, err := msg.EIP712SignBytes(chainID)
+	require.NoError(t, err)
+
+	sig, err := ethcrypto.Sign(hash, priv.ToECDSA())
+	require.NoError(t, err)
+
+	// require valid signature passes validation
+	signer, err := msg.VerifyEIP712Sig(chainID, sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, signer)
+
+	// require wallet style V values of 27/28 to pass validation
+	sig[64] += 27
+	signer, err = msg.VerifyEIP712Sig(chainID, sig)
+	require.NoError(t, err)
+	require.Equal(t, addr, signer)
+
+	// require a different chain ID to recover a different signer
+	signer, _ = msg.VerifyEIP712Sig(big.NewInt(4), sig)
+	require.NotEqual(t, addr, signer)
+
+	// require malformed signature to fail validation
+	signer, err = msg.VerifyEIP712Sig(chainID, sig[:64])
+	require.True(t, types.ErrInvalidSignature.Is(err))
+	require.Equal(t, ethcmn.Address{}, signer)
+}
+
 func TestMsgEthereumTxRLPEncode(t *testing.T) {
 	addr := ethcmn.BytesToAddress([]byte("test_address"))
 	msg := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
@@ -89,6 +191,29 @@ func TestMsgEthereumTxRLPDecode(t *testing.T) {
 	require.Equal(t, expectedMsg.Data, msg.Data)
 }
 
+func TestMsgEthereumTxHash(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	msg := NewMsgEthereumTx(0, &addr, big.NewInt(10), 100000, big.NewInt(1), []byte("test"))
+	msg.Sign(chainID, priv.ToECDSA())
+
+	// require the hash to match the go-ethereum transaction hash
+	ethTx := ethtypes.NewTransaction(0, addr, big.NewInt(10), 100000, big.NewInt(1), []byte("test"))
+	ethTx, err := ethtypes.SignTx(ethTx, ethtypes.NewEIP155Signer(chainID), priv.ToECDSA())
+	require.NoError(t, err)
+	require.Equal(t, ethTx.Hash(), msg.Hash())
+
+	// require the hash of a typed transaction to be the hash of its envelope
+	msg = NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(10), 100000, big.NewInt(1), big.NewInt(2), []byte("test"))
+	msg.Sign(chainID, priv.ToECDSA())
+
+	bz, err := EncodeTx(&msg)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToHash(ethcrypto.Keccak256(bz)), msg.Hash())
+}
+
 func TestMsgEthereumTxSig(t *testing.T) {
 	chainID := big.NewInt(3)
 
@@ -112,9 +237,518 @@ func TestMsgEthereumTxSig(t *testing.T) {
 
 	signer, err = msg.VerifySig(big.NewInt(4))
 	require.Error(t, err)
+	require.True(t, errors.Is(err, types.ErrInvalidChainID))
+	require.Equal(t, ethcmn.Address{}, signer)
+
+	// require zero chain ID to fail validation
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(chainID, priv1.ToECDSA())
+
+	signer, err = msg.VerifySig(big.NewInt(0))
+	require.True(t, errors.Is(err, types.ErrInvalidChainID))
+	require.Equal(t, ethcmn.Address{}, signer)
+
+	// require malformed signature to fail validation
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(chainID, priv1.ToECDSA())
+	msg.Data.S = new(big.Int)
+
+	signer, err = msg.VerifySig(chainID)
+	require.True(t, errors.Is(err, types.ErrInvalidSignature))
+	require.Equal(t, ethcmn.Address{}, signer)
+
+	// require unsigned transaction to fail validation
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+
+	signer, err = msg.VerifySig(chainID)
+	require.True(t, errors.Is(err, types.ErrInvalidSignature))
 	require.Equal(t, ethcmn.Address{}, signer)
 }
 
+func TestMsgEthereumTxGetSender(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	signer := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	msg := NewMsgEthereumTx(0, &addr, big.NewInt(1), 21000, big.NewInt(1), nil)
+	msg.Sign(chainID, priv.ToECDSA())
+
+	sender, err := msg.GetSender(chainID)
+	require.NoError(t, err)
+	require.Equal(t, signer, sender)
+
+	// require the cached sender to be returned without recovering the
+	// signature again
+	msg.Data.R = new(big.Int)
+	sender, err = msg.GetSender(chainID)
+	require.NoError(t, err)
+	require.Equal(t, signer, sender)
+
+	// require a chain ID mismatch to return the zero address and an error
+	fresh := NewMsgEthereumTx(0, &addr, big.NewInt(1), 21000, big.NewInt(1), nil)
+	fresh.Sign(chainID, priv.ToECDSA())
+
+	for _, msg := range []*MsgEthereumTx{&msg, &fresh} {
+		sender, err = msg.GetSender(big.NewInt(4))
+		require.True(t, errors.Is(err, types.ErrInvalidChainID))
+		require.Equal(t, ethcmn.Address{}, sender)
+	}
+}
+
+func TestMsgEthereumTxRecoverPubKey(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	legacy := NewMsgEthereumTx(0, &addr, big.NewInt(1), 21000, big.NewInt(1), nil)
+	unprotected := NewMsgEthereumTx(0, &addr, big.NewInt(1), 21000, big.NewInt(1), nil)
+	dynamicFee := NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(1), 21000, big.NewInt(1), big.NewInt(2), nil)
+	legacy.Sign(chainID, priv.ToECDSA())
+	unprotected.Sign(big.NewInt(0), priv.ToECDSA())
+	dynamicFee.Sign(chainID, priv.ToECDSA())
+
+	for _, msg := range []*MsgEthereumTx{&legacy, &unprotected, &dynamicFee} {
+		pub, err := msg.RecoverPubKey(chainID)
+		require.NoError(t, err)
+		require.Len(t, pub, 65)
+		require.Equal(t, ethcrypto.FromECDSAPub(&priv.ToECDSA().PublicKey), pub)
+
+		// require the public key to derive the sender returned by VerifySig
+		pubKey, err := ethcrypto.UnmarshalPubkey(pub)
+		require.NoError(t, err)
+
+		sender, err := msg.VerifySig(chainID)
+		require.NoError(t, err)
+		require.Equal(t, sender, ethcrypto.PubkeyToAddress(*pubKey))
+	}
+
+	// require a chain ID mismatch to fail recovery
+	_, err := legacy.RecoverPubKey(big.NewInt(4))
+	require.True(t, errors.Is(err, types.ErrInvalidChainID))
+
+	// require an unsigned transaction to fail recovery
+	msg := NewMsgEthereumTx(0, &addr, big.NewInt(1), 21000, big.NewInt(1), nil)
+	_, err = msg.RecoverPubKey(chainID)
+	require.True(t, errors.Is(err, types.ErrInvalidSignature))
+
+	// require a public key not matching the cached sender to fail recovery
+	legacy.Data.Amount = big.NewInt(2)
+	_, err = legacy.RecoverPubKey(chainID)
+	require.True(t, errors.Is(err, types.ErrRecoveryFailed))
+}
+
+func TestMsgEthereumTxSigUnprotected(t *testing.T) {
+	chainID := big.NewInt(3)
+
+	priv1, _ := crypto.GenerateKey()
+	priv2, _ := crypto.GenerateKey()
+	addr1 := ethcmn.BytesToAddress(priv1.PubKey().Address().Bytes())
+	addr2 := ethcmn.BytesToAddress(priv2.PubKey().Address().Bytes())
+
+	// require valid unprotected signature passes validation for any chain ID
+	msg := NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(big.NewInt(0), priv1.ToECDSA())
+	require.True(t, msg.Data.V.Cmp(big.NewInt(27)) == 0 || msg.Data.V.Cmp(big.NewInt(28)) == 0)
+
+	signer, err := msg.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, addr1, signer)
+	require.NotEqual(t, addr2, signer)
+
+	signer, err = msg.VerifySig(big.NewInt(4))
+	require.NoError(t, err)
+	require.Equal(t, addr1, signer)
+
+	// require the signature of the go-ethereum homestead signer to pass validation
+	ethTx := ethtypes.NewTransaction(0, addr1, big.NewInt(10), 100000, big.NewInt(1), []byte("test"))
+	ethTx, err = ethtypes.SignTx(ethTx, ethtypes.HomesteadSigner{}, priv2.ToECDSA())
+	require.NoError(t, err)
+
+	bz, err := rlp.EncodeToBytes(ethTx)
+	require.NoError(t, err)
+
+	msg = MsgEthereumTx{}
+	require.NoError(t, rlp.DecodeBytes(bz, &msg))
+
+	signer, err = msg.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, addr2, signer)
+	require.Equal(t, new(big.Int), msg.ChainID())
+
+	// require zero chain ID to pass validation, as the signature isn't bound to
+	// a chain ID
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(big.NewInt(0), priv1.ToECDSA())
+
+	signer, err = msg.VerifySig(msg.ChainID())
+	require.NoError(t, err)
+	require.Equal(t, addr1, signer)
+
+	// require a nil chain ID to sign and verify with the homestead signer
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(nil, priv1.ToECDSA())
+	require.True(t, msg.Data.V.Cmp(big.NewInt(27)) == 0 || msg.Data.V.Cmp(big.NewInt(28)) == 0)
+
+	signer, err = msg.VerifySig(nil)
+	require.NoError(t, err)
+	require.Equal(t, addr1, signer)
+
+	// require a nil chain ID to not verify a replay protected signature
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(chainID, priv1.ToECDSA())
+
+	_, err = msg.VerifySig(nil)
+	require.True(t, errors.Is(err, types.ErrInvalidChainID))
+
+	// require malformed signature to fail validation
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(big.NewInt(0), priv1.ToECDSA())
+	msg.Data.S = new(big.Int)
+
+	signer, err = msg.VerifySig(chainID)
+	require.True(t, errors.Is(err, types.ErrInvalidSignature))
+	require.Equal(t, ethcmn.Address{}, signer)
+
+	// require a signature over the EIP-155 hash to not recover the signer
+	msg = NewMsgEthereumTx(0, &addr1, nil, 100000, nil, []byte("test"))
+	msg.Sign(chainID, priv1.ToECDSA())
+	msg.Data.V = new(big.Int).Sub(msg.Data.V, new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(8)))
+
+	signer, err = msg.VerifySig(chainID)
+	if err == nil {
+		require.NotEqual(t, addr1, signer)
+	}
+}
+
+func TestMsgEthereumTxDynamicFeeValidation(t *testing.T) {
+	addr := GenerateEthAddress()
+
+	testCases := []struct {
+		msg        MsgEthereumTx
+		expectPass bool
+	}{
+		{NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(1), big.NewInt(10), nil), true},
+		{NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(10), big.NewInt(10), nil), true},
+		{NewMsgEthereumTxDynamicFee(0, nil, big.NewInt(100), 100000, big.NewInt(0), big.NewInt(10), []byte("test")), true},
+		{NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(11), big.NewInt(10), nil), false},
+		{NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(-1), big.NewInt(10), nil), false},
+		{NewMsgEthereumTxDynamicFee(0, &addr, big.NewInt(100), 21000, big.NewInt(1), big.NewInt(0), nil), false},
+	}
+
+	for i, tc := range testCases {
+		if tc.expectPass {
+			require.Nil(t, tc.msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, tc.msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestMsgEthereumTxEffectiveGasPrice(t *testing.T) {
+	addr := GenerateEthAddress()
+	baseFee := big.NewInt(7)
+
+	testCases := []struct {
+		name     string
+		msg      MsgEthereumTx
+		baseFee  *big.Int
+		expPrice *big.Int
+		expTip   *big.Int
+	}{
+		{"legacy", NewMsgEthereumTx(0, &addr, nil, 21000, big.NewInt(10), nil), baseFee, big.NewInt(10), big.NewInt(3)},
+		{"legacy below base fee", NewMsgEthereumTx(0, &addr, nil, 21000, big.NewInt(5), nil), baseFee, big.NewInt(5), big.NewInt(-2)},
+		{"dynamic fee", NewMsgEthereumTxDynamicFee(0, &addr, nil, 21000, big.NewInt(2), big.NewInt(10), nil), baseFee, big.NewInt(9), big.NewInt(2)},
+		{"dynamic fee capped", NewMsgEthereumTxDynamicFee(0, &addr, nil, 21000, big.NewInt(5), big.NewInt(10), nil), baseFee, big.NewInt(10), big.NewInt(3)},
+		{"dynamic fee nil base fee", NewMsgEthereumTxDynamicFee(0, &addr, nil, 21000, big.NewInt(5), big.NewInt(10), nil), nil, big.NewInt(5), big.NewInt(5)},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expPrice, tc.msg.EffectiveGasPrice(tc.baseFee), tc.name)
+		require.Equal(t, tc.expTip, tc.msg.EffectiveGasTip(tc.baseFee), tc.name)
+		require.Equal(t, new(big.Int).Mul(tc.expPrice, big.NewInt(21000)), tc.msg.Fee(tc.baseFee), tc.name)
+	}
+
+	// require the gas fee cap to be the max fee per gas of the dynamic fee transactions
+	require.Equal(t, big.NewInt(10), testCases[0].msg.GasFeeCap())
+	require.Equal(t, big.NewInt(10), testCases[2].msg.GasFeeCap())
+
+	// require the gas tip cap to be the max priority fee per gas of the dynamic fee transactions
+	require.Equal(t, big.NewInt(10), testCases[0].msg.GasTipCap())
+	require.Equal(t, big.NewInt(2), testCases[2].msg.GasTipCap())
+}
+
+func TestMsgEthereumTxDynamicFeeRLP(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	msg := NewMsgEthereumTxDynamicFee(1, &addr, big.NewInt(10), 100000, big.NewInt(2), big.NewInt(20), []byte("test"))
+	legacy := NewMsgEthereumTx(1, &addr, big.NewInt(10), 100000, big.NewInt(20), []byte("test"))
+	require.NotEqual(t, legacy.RLPSignBytes(chainID), msg.RLPSignBytes(chainID))
+
+	msg.Sign(chainID, priv.ToECDSA())
+	require.Equal(t, chainID, msg.Data.ChainID)
+	require.Equal(t, chainID, msg.ChainID())
+
+	raw, err := rlp.EncodeToBytes(&msg)
+	require.NoError(t, err)
+
+	var msg2 MsgEthereumTx
+	err = rlp.DecodeBytes(raw, &msg2)
+	require.NoError(t, err)
+	require.Equal(t, DynamicFeeTxType, msg2.Data.Type)
+	require.Equal(t, msg.Data.MaxFeePerGas, msg2.Data.MaxFeePerGas)
+	require.Equal(t, msg.Data.MaxPriorityFeePerGas, msg2.Data.MaxPriorityFeePerGas)
+
+	raw2, err := rlp.EncodeToBytes(&msg2)
+	require.NoError(t, err)
+	require.Equal(t, raw, raw2)
+
+	signer, err := msg2.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToAddress(priv.PubKey().Address().Bytes()), signer)
+
+	// require a different chain ID fails verification
+	var msg3 MsgEthereumTx
+	err = rlp.DecodeBytes(raw, &msg3)
+	require.NoError(t, err)
+
+	_, err = msg3.VerifySig(big.NewInt(4))
+	require.Error(t, err)
+}
+
+func TestMsgEthereumTxAccessList(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	accesses := AccessList{
+		{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(2))}},
+	}
+
+	msg := NewMsgEthereumTxAccessList(1, &addr, big.NewInt(10), 100000, big.NewInt(20), []byte("test"), accesses)
+	require.Nil(t, msg.ValidateBasic())
+
+	// require the gas limit to cover the access list gas: 21000 base + 16 per
+	// non-zero byte + 2400 per address + 1900 per storage key
+	intrinsicGas := uint64(21000 + 4*16 + 2400 + 2*1900)
+	require.Nil(t, NewMsgEthereumTxAccessList(1, &addr, big.NewInt(10), intrinsicGas, big.NewInt(20), []byte("test"), accesses).ValidateBasic())
+	require.NotNil(t, NewMsgEthereumTxAccessList(1, &addr, big.NewInt(10), intrinsicGas-1, big.NewInt(20), []byte("test"), accesses).ValidateBasic())
+
+	msg.Sign(chainID, priv.ToECDSA())
+
+	raw, err := EncodeTx(&msg)
+	require.NoError(t, err)
+	require.Equal(t, AccessListTxType, raw[0])
+
+	msg2, err := DecodeTx(raw)
+	require.NoError(t, err)
+	require.Equal(t, AccessListTxType, msg2.Data.Type)
+	require.Equal(t, accesses, msg2.Data.Accesses)
+
+	signer, err := msg2.VerifySig(chainID)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToAddress(priv.PubKey().Address().Bytes()), signer)
+
+	// require legacy payloads to be decoded from their RLP list encoding
+	legacy := NewMsgEthereumTx(0, &addr, nil, 100000, nil, []byte("test"))
+	raw, err = EncodeTx(&legacy)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.FromHex("E48080830186A0940000000000000000746573745F61646472657373808474657374808080"), raw)
+
+	msg2, err = DecodeTx(raw)
+	require.NoError(t, err)
+	require.Equal(t, legacy.Data, msg2.Data)
+
+	// require duplicate storage keys to fail validation
+	accesses = append(accesses, AccessTuple{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1))}})
+	msg = NewMsgEthereumTxAccessList(1, &addr, big.NewInt(10), 100000, big.NewInt(20), []byte("test"), accesses)
+	require.NotNil(t, msg.ValidateBasic())
+
+	// require legacy transactions to not include an access list
+	legacy.Data.Accesses = accesses[:1]
+	require.NotNil(t, legacy.ValidateBasic())
+}
+
+func TestDecodeTxBytes(t *testing.T) {
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	legacy := NewMsgEthereumTx(1, &addr, big.NewInt(10), 100000, big.NewInt(20), []byte("test"))
+	legacy.Sign(chainID, priv.ToECDSA())
+	rawLegacy, err := EncodeTx(&legacy)
+	require.NoError(t, err)
+
+	dynamic := NewMsgEthereumTxDynamicFee(1, &addr, big.NewInt(10), 100000, big.NewInt(2), big.NewInt(20), []byte("test"))
+	dynamic.Sign(chainID, priv.ToECDSA())
+	rawDynamic, err := EncodeTx(&dynamic)
+	require.NoError(t, err)
+
+	oversized := NewMsgEthereumTx(1, &addr, big.NewInt(10), 100000, big.NewInt(20), make([]byte, MaxTxBytesSize))
+	rawOversized, err := EncodeTx(&oversized)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		raw      []byte
+		expected *MsgEthereumTx
+	}{
+		{"legacy", rawLegacy, &legacy},
+		{"dynamic fee", rawDynamic, &dynamic},
+		{"empty", nil, nil},
+		{"truncated legacy", rawLegacy[:len(rawLegacy)-1], nil},
+		{"truncated dynamic fee", rawDynamic[:len(rawDynamic)/2], nil},
+		{"type byte only", []byte{DynamicFeeTxType}, nil},
+		{"unknown type", append([]byte{0x7f}, rawDynamic[1:]...), nil},
+		{"legacy trailing garbage", append(append([]byte{}, rawLegacy...), 0x80), nil},
+		{"dynamic fee trailing garbage", append(append([]byte{}, rawDynamic...), 0x00, 0x01), nil},
+		{"oversized", rawOversized, nil},
+		{"invalid list header", []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, nil},
+	}
+
+	for _, tc := range testCases {
+		msg, err := DecodeTxBytes(tc.raw)
+		if tc.expected == nil {
+			require.Error(t, err, tc.name)
+			continue
+		}
+
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected.Data.Type, msg.Data.Type, tc.name)
+		require.Equal(t, tc.expected.Hash(), msg.Hash(), tc.name)
+	}
+
+	// require random mutations of valid transactions to never panic
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		raw := append([]byte{}, rawDynamic...)
+		if i%2 == 0 {
+			raw = append([]byte{}, rawLegacy...)
+		}
+		raw[rng.Intn(len(raw))] = byte(rng.Intn(256))
+		raw = raw[:rng.Intn(len(raw)+1)]
+
+		require.NotPanics(t, func() { _, _ = DecodeTxBytes(raw) })
+	}
+}
+
+func TestTxDecoder(t *testing.T) {
+	cdc := codec.New()
+	sdk.RegisterCodec(cdc)
+	auth.RegisterCodec(cdc)
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	decoder := TxDecoder(cdc)
+
+	chainID := big.NewInt(3)
+	priv, _ := crypto.GenerateKey()
+	addr := ethcmn.BytesToAddress([]byte("test_address"))
+
+	// an Amino encoded Cosmos transaction
+	from := sdk.AccAddress(priv.PubKey().Address())
+	stdTx := auth.NewStdTx(
+		[]sdk.Msg{NewMsgEthermint(0, &from, sdk.NewInt(10), 100000, sdk.NewInt(20), nil, from)},
+		auth.NewStdFee(100000, sdk.NewCoins(sdk.NewInt64Coin(types.DenomDefault, 10))), nil, "memo",
+	)
+	bz, err := cdc.MarshalBinaryLengthPrefixed(stdTx)
+	require.NoError(t, err)
+
+	tx, err := decoder(bz)
+	require.NoError(t, err)
+	require.Equal(t, stdTx, tx)
+
+	// an Amino encoded Ethereum transaction
+	msg := NewMsgEthereumTx(1, &addr, big.NewInt(10), 100000, big.NewInt(20), []byte("test"))
+	msg.Sign(chainID, priv.ToECDSA())
+	bz, err = cdc.MarshalBinaryLengthPrefixed(msg)
+	require.NoError(t, err)
+
+	tx, err = decoder(bz)
+	require.NoError(t, err)
+	require.IsType(t, MsgEthereumTx{}, tx)
+
+	decoded := tx.(MsgEthereumTx)
+	require.Equal(t, msg.Hash(), decoded.Hash())
+
+	// raw Ethereum transactions are decoded as MsgEthereumTx, to be routed to
+	// the Ethereum ante handler
+	dynamic := NewMsgEthereumTxDynamicFee(2, &addr, big.NewInt(10), 100000, big.NewInt(2), big.NewInt(20), []byte("test"))
+	dynamic.Sign(chainID, priv.ToECDSA())
+
+	for _, msg := range []MsgEthereumTx{msg, dynamic} {
+		raw, err := EncodeTx(&msg)
+		require.NoError(t, err)
+
+		tx, err := decoder(raw)
+		require.NoError(t, err)
+		require.IsType(t, MsgEthereumTx{}, tx)
+
+		decoded = tx.(MsgEthereumTx)
+		require.Equal(t, msg.Hash(), decoded.Hash())
+
+		signer, err := decoded.VerifySig(chainID)
+		require.NoError(t, err)
+		require.Equal(t, ethcmn.BytesToAddress(from), signer)
+
+		_, err = decoder(raw[:len(raw)-1])
+		require.Error(t, err)
+	}
+
+	_, err = decoder(nil)
+	require.Error(t, err)
+
+	_, err = decoder([]byte{0x01, 0x02, 0x03})
+	require.Error(t, err)
+}
+
+func TestVerifySigs(t *testing.T) {
+	chainID := big.NewInt(3)
+
+	msgs := make([]MsgEthereumTx, 20)
+	expected := make([]ethcmn.Address, len(msgs))
+
+	for i := range msgs {
+		priv, _ := crypto.GenerateKey()
+		addr := ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+
+		msgs[i] = NewMsgEthereumTx(uint64(i), &addr, nil, 100000, nil, []byte("test"))
+		msgs[i].Sign(chainID, priv.ToECDSA())
+		expected[i] = addr
+	}
+
+	signers, err := VerifySigs(chainID, msgs)
+	require.NoError(t, err)
+	require.Equal(t, expected, signers)
+
+	for i := range msgs {
+		signer, err := msgs[i].VerifySig(chainID)
+		require.NoError(t, err)
+		require.Equal(t, signer, signers[i])
+	}
+
+	signers, err = VerifySigs(chainID, nil)
+	require.NoError(t, err)
+	require.Empty(t, signers)
+
+	// require the lowest failed index to be reported
+	invalid := make([]MsgEthereumTx, len(msgs))
+	for i := range msgs {
+		invalid[i] = NewMsgEthereumTx(msgs[i].Data.AccountNonce, msgs[i].Data.Recipient, nil, 100000, nil, []byte("test"))
+		invalid[i].Data.V, invalid[i].Data.R, invalid[i].Data.S = msgs[i].Data.V, msgs[i].Data.R, msgs[i].Data.S
+	}
+	invalid[7].Data.S = new(big.Int)
+	invalid[12].Data.S = new(big.Int)
+
+	signers, err = VerifySigs(chainID, invalid)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, types.ErrInvalidSignature))
+	require.Contains(t, err.Error(), "transaction 7")
+	require.Nil(t, signers)
+}
+
 func TestMsgEthereumTxAmino(t *testing.T) {
 	addr := GenerateEthAddress()
 	msg := NewMsgEthereumTx(5, &addr, big.NewInt(1), 100000, big.NewInt(3), []byte("test"))
@@ -131,6 +765,129 @@ func TestMsgEthereumTxAmino(t *testing.T) {
 	err = ModuleCdc.UnmarshalBinaryBare(raw, &msg2)
 	require.NoError(t, err)
 	require.Equal(t, msg.Data, msg2.Data)
+
+	dynamicFeeMsg := NewMsgEthereumTxDynamicFee(5, &addr, big.NewInt(1), 100000, big.NewInt(2), big.NewInt(3), []byte("test"))
+	dynamicFeeMsg.Data.ChainID = big.NewInt(3)
+	dynamicFeeMsg.Data.Accesses = AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1))}}}
+
+	raw, err = ModuleCdc.MarshalBinaryBare(dynamicFeeMsg)
+	require.NoError(t, err)
+
+	var msg3 MsgEthereumTx
+
+	err = ModuleCdc.UnmarshalBinaryBare(raw, &msg3)
+	require.NoError(t, err)
+	require.Equal(t, dynamicFeeMsg.Data, msg3.Data)
+}
+
+func TestMsgEthereumTxProto(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	addr := GenerateEthAddress()
+	msg := NewMsgEthereumTx(5, &addr, big.NewInt(1), 100000, big.NewInt(3), []byte("test"))
+	msg.Sign(big.NewInt(3), priv.ToECDSA())
+
+	raw, err := proto.Marshal(&msg)
+	require.NoError(t, err)
+
+	var msg2 MsgEthereumTx
+
+	err = proto.Unmarshal(raw, &msg2)
+	require.NoError(t, err)
+	require.Equal(t, msg.Data, msg2.Data)
+
+	// the signing bytes are unaffected, still recovering the signer
+	require.Equal(t, msg.RLPSignBytes(big.NewInt(3)), msg2.RLPSignBytes(big.NewInt(3)))
+	sender, err := msg2.VerifySig(big.NewInt(3))
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.BytesToAddress(priv.PubKey().Address().Bytes()), sender)
+
+	dynamicFeeMsg := NewMsgEthereumTxDynamicFee(5, nil, big.NewInt(0), 100000, big.NewInt(2), big.NewInt(3), nil)
+	dynamicFeeMsg.Data.ChainID = big.NewInt(3)
+	dynamicFeeMsg.Data.Accesses = AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1))}}}
+	dynamicFeeMsg.Data.V = new(big.Int).Lsh(big.NewInt(1), 300)
+
+	raw, err = proto.Marshal(&dynamicFeeMsg)
+	require.NoError(t, err)
+
+	var msg3 MsgEthereumTx
+
+	err = proto.Unmarshal(raw, &msg3)
+	require.NoError(t, err)
+	require.Equal(t, dynamicFeeMsg.Data, msg3.Data)
+
+	// the negative ints can't be encoded losslessly
+	dynamicFeeMsg.Data.Amount = big.NewInt(-1)
+	_, err = proto.Marshal(&dynamicFeeMsg)
+	require.Error(t, err)
+}
+
+func TestMsgEthereumTxJSON(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	addr := GenerateEthAddress()
+	msg := NewMsgEthereumTx(5, &addr, big.NewInt(1), 100000, big.NewInt(3), []byte("test"))
+	msg.Sign(big.NewInt(3), priv.ToECDSA())
+
+	bz, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	// numeric fields are hex quantities and byte fields hex data
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(bz, &fields))
+	require.Equal(t, "0x5", fields["nonce"])
+	require.Equal(t, "0x186a0", fields["gas"])
+	require.Equal(t, "0x74657374", fields["input"])
+	require.Equal(t, msg.Hash().Hex(), fields["hash"])
+
+	var msg2 MsgEthereumTx
+	require.NoError(t, json.Unmarshal(bz, &msg2))
+	requireTxDataEqual(t, msg.Data, msg2.Data)
+	require.Equal(t, msg.Hash(), msg2.Hash())
+
+	// contract creation without payload
+	contractMsg := NewMsgEthereumTxContract(1, big.NewInt(0), 100000, big.NewInt(3), nil)
+	contractMsg.Sign(big.NewInt(3), priv.ToECDSA())
+
+	bz, err = json.Marshal(contractMsg)
+	require.NoError(t, err)
+
+	var msg3 MsgEthereumTx
+	require.NoError(t, json.Unmarshal(bz, &msg3))
+	requireTxDataEqual(t, contractMsg.Data, msg3.Data)
+
+	dynamicFeeMsg := NewMsgEthereumTxDynamicFee(5, &addr, big.NewInt(1), 100000, big.NewInt(2), big.NewInt(3), []byte("test"))
+	dynamicFeeMsg.Data.Accesses = AccessList{{Address: addr, StorageKeys: []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1))}}}
+	dynamicFeeMsg.Sign(big.NewInt(3), priv.ToECDSA())
+
+	// the message is amino JSON encoded with the Ethereum representation
+	bz, err = ModuleCdc.MarshalJSON(dynamicFeeMsg)
+	require.NoError(t, err)
+
+	var msg4 MsgEthereumTx
+	require.NoError(t, ModuleCdc.UnmarshalJSON(bz, &msg4))
+	requireTxDataEqual(t, dynamicFeeMsg.Data, msg4.Data)
+
+	// require an error for missing fields
+	var msg5 MsgEthereumTx
+	require.Error(t, json.Unmarshal([]byte(`{"nonce":"0x1"}`), &msg5))
+}
+
+// requireTxDataEqual compares the transaction data with their big ints compared
+// by value, as their internal representation depends on how they are set.
+func requireTxDataEqual(t *testing.T, expected, actual TxData) {
+	normalize := func(td TxData) TxData {
+		for _, i := range []**big.Int{&td.Price, &td.Amount, &td.V, &td.R, &td.S, &td.ChainID, &td.MaxPriorityFeePerGas, &td.MaxFeePerGas} {
+			if *i != nil {
+				*i = new(big.Int).Set(*i)
+			}
+		}
+		return td
+	}
+
+	require.Equal(t, normalize(expected), normalize(actual))
 }
 
 func TestMarshalAndUnmarshalInt(t *testing.T) {
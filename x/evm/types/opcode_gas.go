@@ -0,0 +1,24 @@
+package types
+
+import "errors"
+
+// OpcodeGasCost defines the constant gas of an EVM opcode, overriding its
+// default constant gas of the active hardfork.
+type OpcodeGasCost struct {
+	// Opcode is the name of the opcode, eg. SHA3
+	Opcode string `json:"opcode" yaml:"opcode"`
+	// Gas is the constant gas charged for each execution of the opcode
+	Gas uint64 `json:"gas" yaml:"gas"`
+}
+
+// ValidateOpcodeGasCosts returns an error if any opcode gas cost override is
+// set. The go-ethereum v1.9.0 EVM neither exports the operations of its jump
+// table nor builds custom jump tables, so the overrides can't be applied until
+// go-ethereum is upgraded.
+func ValidateOpcodeGasCosts(costs []OpcodeGasCost) error {
+	if len(costs) > 0 {
+		return errors.New("invalid opcode gas costs, the go-ethereum v1.9.0 EVM doesn't support custom jump tables")
+	}
+
+	return nil
+}
@@ -0,0 +1,480 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	emint "github.com/cosmos/ethermint/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// DefaultParamspace for params keeper
+	DefaultParamspace = ModuleName
+)
+
+// Parameter keys
+var (
+	KeyEvmDenom      = []byte("EvmDenom")
+	KeyMinGasPrice   = []byte("MinGasPrice")
+	KeyMaxGasPrice   = []byte("MaxGasPrice")
+	KeyCoinDecimals  = []byte("CoinDecimals")
+	KeyBlockGasLimit = []byte("BlockGasLimit")
+	KeyHardfork      = []byte("Hardfork")
+	KeyChainConfig   = []byte("ChainConfig")
+	KeyMaxTxSize     = []byte("MaxTxSize")
+	KeyMaxCodeSize   = []byte("MaxCodeSize")
+	KeyExtraEIPs     = []byte("ExtraEIPs")
+
+	KeyRejectZeroAddressRecipient = []byte("RejectZeroAddressRecipient")
+	KeyBurnBaseFee                = []byte("BurnBaseFee")
+	KeyEnableCreate               = []byte("EnableCreate")
+	KeyEnableCall                 = []byte("EnableCall")
+	KeyAllowedDeployers           = []byte("AllowedDeployers")
+	KeyInitialBaseFee             = []byte("InitialBaseFee")
+	KeyMinBaseFee                 = []byte("MinBaseFee")
+	KeyOpcodeGasCosts             = []byte("OpcodeGasCosts")
+)
+
+const (
+	// DefaultMaxTxSize is the default maximum size of the payload of an Ethereum
+	// transaction, which is the transaction size limit of the go-ethereum pool
+	DefaultMaxTxSize = 128 * 1024
+	// DefaultMaxCodeSize is the default maximum size of the code of a contract,
+	// as defined by EIP-170
+	DefaultMaxCodeSize = ethparams.MaxCodeSize
+)
+
+var _ params.ParamSet = &Params{}
+
+// Params defines the EVM module parameters
+type Params struct {
+	// EvmDenom defines the coin denomination holding the account balances, in
+	// which the transferred values and the gas fees are settled
+	EvmDenom string `json:"evm_denom" yaml:"evm_denom"`
+	// MinGasPrice defines the minimum gas price accepted for an Ethereum transaction
+	MinGasPrice sdk.Int `json:"min_gas_price" yaml:"min_gas_price"`
+	// MaxGasPrice defines the maximum gas price accepted for an Ethereum
+	// transaction. A zero value disables the upper bound.
+	MaxGasPrice sdk.Int `json:"max_gas_price" yaml:"max_gas_price"`
+	// CoinDecimals defines the number of decimals of the coin denomination
+	// holding the account balances, which are converted to wei for the EVM
+	CoinDecimals uint32 `json:"coin_decimals" yaml:"coin_decimals"`
+	// BlockGasLimit defines the total gas that can be used by the EVM
+	// transactions of a block. A zero value disables the limit.
+	BlockGasLimit uint64 `json:"block_gas_limit" yaml:"block_gas_limit"`
+	// Hardfork defines the hardfork selecting the set of precompiled contracts
+	// available to the EVM: homestead or byzantium. The go-ethereum EVM ties
+	// the byzantium contracts to the Byzantium fork, hence the homestead ones
+	// run the EVM without the Byzantium and later forks.
+	Hardfork string `json:"hardfork" yaml:"hardfork"`
+	// ChainConfig defines the block heights at which the Ethereum hardforks are
+	// activated. The precompiled contracts of the Hardfork param are only
+	// available from the height of their hardfork.
+	ChainConfig ChainConfig `json:"chain_config" yaml:"chain_config"`
+	// MaxTxSize defines the maximum size in bytes of the payload of an Ethereum
+	// transaction. A zero value disables the limit.
+	MaxTxSize uint64 `json:"max_tx_size" yaml:"max_tx_size"`
+	// MaxCodeSize defines the maximum size in bytes of the code deployed by a
+	// contract creation. It cannot exceed the EIP-170 limit, which the EVM
+	// enforces from the EIP158 hardfork, and a zero value disables the limit.
+	MaxCodeSize uint64 `json:"max_code_size" yaml:"max_code_size"`
+	// ExtraEIPs defines the EIPs enforced on top of the ones of the active
+	// hardforks, eg. 3541 rejecting the contract code starting with 0xEF
+	ExtraEIPs []int `json:"extra_eips" yaml:"extra_eips"`
+	// RejectZeroAddressRecipient defines if the Ethereum transactions sent to
+	// the zero address are rejected. A nil recipient, creating a contract, is
+	// always accepted.
+	RejectZeroAddressRecipient bool `json:"reject_zero_address_recipient" yaml:"reject_zero_address_recipient"`
+	// BurnBaseFee defines if the EIP-1559 base fee of the gas used by the
+	// Ethereum transactions is burned. Otherwise, it is left in the fee
+	// collector and distributed along with the priority fee.
+	BurnBaseFee bool `json:"burn_base_fee" yaml:"burn_base_fee"`
+	// EnableCreate defines if the Ethereum transactions can deploy contracts,
+	// ie. if the transactions with a nil recipient are accepted
+	EnableCreate bool `json:"enable_create" yaml:"enable_create"`
+	// EnableCall defines if the Ethereum transactions can call the existing
+	// contracts. The value transfers to the externally-owned accounts are
+	// always accepted.
+	EnableCall bool `json:"enable_call" yaml:"enable_call"`
+	// AllowedDeployers defines the hex addresses of the accounts allowed to
+	// deploy contracts. An empty list allows every account to deploy contracts.
+	AllowedDeployers []string `json:"allowed_deployers" yaml:"allowed_deployers"`
+	// InitialBaseFee defines the EIP-1559 base fee, in wei, of the first block
+	// with a base fee, ie. the genesis block or the block upgrading a chain
+	// without base fee. It cannot be below the MinBaseFee.
+	InitialBaseFee sdk.Int `json:"initial_base_fee" yaml:"initial_base_fee"`
+	// MinBaseFee defines the floor, in wei, below which the EIP-1559 base fee
+	// never drops when adjusted from the gas used by a block
+	MinBaseFee sdk.Int `json:"min_base_fee" yaml:"min_base_fee"`
+	// OpcodeGasCosts overrides the constant gas of the given opcodes. It must be
+	// empty, the go-ethereum v1.9.0 EVM having no custom jump tables.
+	OpcodeGasCosts []OpcodeGasCost `json:"opcode_gas_costs" yaml:"opcode_gas_costs"`
+}
+
+// ParamKeyTable returns the parameter key table for the EVM module
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// NewParams returns the default params with the given gas price bounds. The
+// other params are set on the returned value.
+func NewParams(minGasPrice, maxGasPrice sdk.Int) Params {
+	params := DefaultParams()
+	params.MinGasPrice = minGasPrice
+	params.MaxGasPrice = maxGasPrice
+	return params
+}
+
+// DefaultParams returns the default EVM module parameters. The gas price and the
+// gas of the blocks are unbounded, all the hardforks are activated from genesis
+// and the base fee is burned.
+func DefaultParams() Params {
+	return Params{
+		EvmDenom:      emint.DenomDefault,
+		MinGasPrice:   sdk.ZeroInt(),
+		MaxGasPrice:   sdk.ZeroInt(),
+		CoinDecimals:  DefaultCoinDecimals,
+		BlockGasLimit: 0,
+		Hardfork:      DefaultHardfork,
+		ChainConfig:   DefaultChainConfig(),
+		MaxTxSize:     DefaultMaxTxSize,
+		MaxCodeSize:   DefaultMaxCodeSize,
+		BurnBaseFee:   true,
+		EnableCreate:  true,
+		EnableCall:    true,
+
+		InitialBaseFee: sdk.ZeroInt(),
+		MinBaseFee:     sdk.ZeroInt(),
+	}
+}
+
+// String implements the fmt.Stringer interface
+func (p Params) String() string {
+	return fmt.Sprintf(`EVM Params:
+  EVM Denom:       %s
+  Min Gas Price:   %s
+  Max Gas Price:   %s
+  Coin Decimals:   %d
+  Block Gas Limit: %d
+  Hardfork:        %s
+  Chain Config:    %+v
+  Max Tx Size:     %d
+  Max Code Size:   %d
+  Extra EIPs:      %v
+  Reject Zero Address Recipient: %t
+  Burn Base Fee:   %t
+  Enable Create:   %t
+  Enable Call:     %t
+  Allowed Deployers: %v
+  Initial Base Fee: %s
+  Min Base Fee:    %s
+  Opcode Gas Costs: %v
+`,
+		p.EvmDenom, p.MinGasPrice, p.MaxGasPrice, p.CoinDecimals, p.BlockGasLimit, p.Hardfork, p.ChainConfig,
+		p.MaxTxSize, p.MaxCodeSize, p.ExtraEIPs, p.RejectZeroAddressRecipient, p.BurnBaseFee,
+		p.EnableCreate, p.EnableCall, p.AllowedDeployers, p.InitialBaseFee, p.MinBaseFee,
+		p.OpcodeGasCosts,
+	)
+}
+
+// ParamSetPairs returns the parameter set pairs.
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(KeyEvmDenom, &p.EvmDenom, validateEvmDenom),
+		params.NewParamSetPair(KeyMinGasPrice, &p.MinGasPrice, validateGasPrice),
+		params.NewParamSetPair(KeyMaxGasPrice, &p.MaxGasPrice, validateGasPrice),
+		params.NewParamSetPair(KeyCoinDecimals, &p.CoinDecimals, validateCoinDecimals),
+		params.NewParamSetPair(KeyBlockGasLimit, &p.BlockGasLimit, validateBlockGasLimit),
+		params.NewParamSetPair(KeyHardfork, &p.Hardfork, validateHardfork),
+		params.NewParamSetPair(KeyChainConfig, &p.ChainConfig, validateChainConfig),
+		params.NewParamSetPair(KeyMaxTxSize, &p.MaxTxSize, validateMaxTxSize),
+		params.NewParamSetPair(KeyMaxCodeSize, &p.MaxCodeSize, validateMaxCodeSize),
+		params.NewParamSetPair(KeyExtraEIPs, &p.ExtraEIPs, validateExtraEIPs),
+		params.NewParamSetPair(KeyRejectZeroAddressRecipient, &p.RejectZeroAddressRecipient, validateBool),
+		params.NewParamSetPair(KeyBurnBaseFee, &p.BurnBaseFee, validateBool),
+		params.NewParamSetPair(KeyEnableCreate, &p.EnableCreate, validateBool),
+		params.NewParamSetPair(KeyEnableCall, &p.EnableCall, validateBool),
+		params.NewParamSetPair(KeyAllowedDeployers, &p.AllowedDeployers, validateAllowedDeployers),
+		params.NewParamSetPair(KeyInitialBaseFee, &p.InitialBaseFee, validateBaseFee),
+		params.NewParamSetPair(KeyMinBaseFee, &p.MinBaseFee, validateBaseFee),
+		params.NewParamSetPair(KeyOpcodeGasCosts, &p.OpcodeGasCosts, validateOpcodeGasCosts),
+	}
+}
+
+// Validate performs a stateless validation of the parameters
+func (p Params) Validate() error {
+	if err := validateEvmDenom(p.EvmDenom); err != nil {
+		return err
+	}
+	if err := validateGasPrice(p.MinGasPrice); err != nil {
+		return err
+	}
+	if err := validateGasPrice(p.MaxGasPrice); err != nil {
+		return err
+	}
+	if err := validateCoinDecimals(p.CoinDecimals); err != nil {
+		return err
+	}
+	if err := validateHardfork(p.Hardfork); err != nil {
+		return err
+	}
+	if err := validateChainConfig(p.ChainConfig); err != nil {
+		return err
+	}
+	if err := validateMaxCodeSize(p.MaxCodeSize); err != nil {
+		return err
+	}
+	if err := validateExtraEIPs(p.ExtraEIPs); err != nil {
+		return err
+	}
+	if err := validateAllowedDeployers(p.AllowedDeployers); err != nil {
+		return err
+	}
+	if err := validateBaseFee(p.InitialBaseFee); err != nil {
+		return err
+	}
+	if err := validateBaseFee(p.MinBaseFee); err != nil {
+		return err
+	}
+	if err := validateOpcodeGasCosts(p.OpcodeGasCosts); err != nil {
+		return err
+	}
+
+	if !p.MaxGasPrice.IsZero() && p.MaxGasPrice.LT(p.MinGasPrice) {
+		return fmt.Errorf(
+			"max gas price (%s) must be greater than or equal to min gas price (%s)",
+			p.MaxGasPrice, p.MinGasPrice,
+		)
+	}
+
+	if p.InitialBaseFee.LT(p.MinBaseFee) {
+		return fmt.Errorf(
+			"initial base fee (%s) must be greater than or equal to min base fee (%s)",
+			p.InitialBaseFee, p.MinBaseFee,
+		)
+	}
+
+	return nil
+}
+
+// ValidateTxSize checks that the size of a transaction payload doesn't exceed
+// the MaxTxSize parameter.
+func (p Params) ValidateTxSize(payload []byte) error {
+	if p.MaxTxSize != 0 && uint64(len(payload)) > p.MaxTxSize {
+		return sdkerrors.Wrapf(
+			emint.ErrTxSizeExceeded, "payload size %d exceeds the max tx size %d", len(payload), p.MaxTxSize,
+		)
+	}
+
+	return nil
+}
+
+// ValidateRecipient checks that a transaction recipient isn't the zero address
+// if the RejectZeroAddressRecipient parameter is set. A nil recipient, creating
+// a contract, is always valid.
+func (p Params) ValidateRecipient(recipient *common.Address) error {
+	if p.RejectZeroAddressRecipient && recipient != nil && *recipient == (common.Address{}) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "recipient cannot be the zero address")
+	}
+
+	return nil
+}
+
+// ValidateCreateCall checks that a contract creation, with a nil recipient, is
+// enabled by the EnableCreate parameter and that a call to a contract, which
+// recipient has code, is enabled by the EnableCall parameter.
+func (p Params) ValidateCreateCall(recipient *common.Address, recipientIsContract bool) error {
+	if recipient == nil && !p.EnableCreate {
+		return sdkerrors.Wrap(emint.ErrCreateDisabled, "contract creation is disabled by the EVM params")
+	}
+
+	if recipient != nil && recipientIsContract && !p.EnableCall {
+		return sdkerrors.Wrapf(emint.ErrCallDisabled, "call to contract %s is disabled by the EVM params", recipient.Hex())
+	}
+
+	return nil
+}
+
+// ValidateDeployer checks that the sender of a contract creation, with a nil
+// recipient, is one of the AllowedDeployers if the parameter isn't empty.
+func (p Params) ValidateDeployer(sender common.Address, recipient *common.Address) error {
+	if recipient != nil || len(p.AllowedDeployers) == 0 {
+		return nil
+	}
+
+	for _, deployer := range p.AllowedDeployers {
+		if common.HexToAddress(deployer) == sender {
+			return nil
+		}
+	}
+
+	return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to deploy contracts", sender.Hex())
+}
+
+// ValidateGasPrice checks that a transaction gas price is within the bounds
+// defined by the parameters.
+func (p Params) ValidateGasPrice(gasPrice sdk.Int) error {
+	if gasPrice.LT(p.MinGasPrice) {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrInsufficientFee, "gas price %s is below the minimum gas price %s", gasPrice, p.MinGasPrice,
+		)
+	}
+
+	if !p.MaxGasPrice.IsZero() && gasPrice.GT(p.MaxGasPrice) {
+		return sdkerrors.Wrapf(
+			emint.ErrInvalidValue, "gas price %s is above the maximum gas price %s", gasPrice, p.MaxGasPrice,
+		)
+	}
+
+	return nil
+}
+
+func validateEvmDenom(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return sdk.ValidateDenom(v)
+}
+
+func validateGasPrice(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == (sdk.Int{}) {
+		return errors.New("gas price cannot be empty")
+	}
+
+	if v.IsNegative() {
+		return fmt.Errorf("gas price cannot be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateBaseFee(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v == (sdk.Int{}) {
+		return errors.New("base fee cannot be empty")
+	}
+
+	if v.IsNegative() {
+		return fmt.Errorf("base fee cannot be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateCoinDecimals(i interface{}) error {
+	v, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v > WeiDecimals {
+		return fmt.Errorf("coin decimals cannot be greater than %d: %d", WeiDecimals, v)
+	}
+
+	return nil
+}
+
+func validateBlockGasLimit(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateMaxTxSize(i interface{}) error {
+	if _, ok := i.(uint64); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateBool(i interface{}) error {
+	if _, ok := i.(bool); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateMaxCodeSize(i interface{}) error {
+	v, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v > ethparams.MaxCodeSize {
+		return fmt.Errorf("max code size cannot be greater than the EIP-170 limit %d: %d", ethparams.MaxCodeSize, v)
+	}
+
+	return nil
+}
+
+func validateExtraEIPs(i interface{}) error {
+	v, ok := i.([]int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return ValidateExtraEIPs(v)
+}
+
+func validateOpcodeGasCosts(i interface{}) error {
+	v, ok := i.([]OpcodeGasCost)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return ValidateOpcodeGasCosts(v)
+}
+
+func validateAllowedDeployers(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	for _, deployer := range v {
+		if !common.IsHexAddress(deployer) {
+			return fmt.Errorf("invalid allowed deployer address: %s", deployer)
+		}
+	}
+
+	return nil
+}
+
+func validateHardfork(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return ValidateHardfork(v)
+}
+
+func validateChainConfig(i interface{}) error {
+	v, ok := i.(ChainConfig)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return v.Validate()
+}
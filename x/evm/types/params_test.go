@@ -0,0 +1,172 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	emint "github.com/cosmos/ethermint/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParamsValidate(t *testing.T) {
+	// params returns the default params with the fields set by the function
+	params := func(set func(p *Params)) Params {
+		p := DefaultParams()
+		set(&p)
+		return p
+	}
+
+	testCases := []struct {
+		name     string
+		params   Params
+		expError bool
+	}{
+		{"default", DefaultParams(), false},
+		{"bounded", NewParams(sdk.NewInt(1), sdk.NewInt(10)), false},
+		{"no max", NewParams(sdk.NewInt(10), sdk.ZeroInt()), false},
+		{"negative min", NewParams(sdk.NewInt(-1), sdk.ZeroInt()), true},
+		{"negative max", NewParams(sdk.ZeroInt(), sdk.NewInt(-1)), true},
+		{"max below min", NewParams(sdk.NewInt(10), sdk.NewInt(1)), true},
+		{"no decimals", params(func(p *Params) { p.CoinDecimals = 0 }), false},
+		{"decimals above wei", params(func(p *Params) { p.CoinDecimals = 19 }), true},
+		{"block gas limit", params(func(p *Params) { p.BlockGasLimit = 10000000 }), false},
+		{"homestead", params(func(p *Params) { p.Hardfork = HardforkHomestead }), false},
+		{"unknown hardfork", params(func(p *Params) { p.Hardfork = "istanbul" }), true},
+		{"invalid chain config", params(func(p *Params) { p.ChainConfig = ChainConfig{} }), true},
+		{"custom denom", params(func(p *Params) { p.EvmDenom = "aevmos" }), false},
+		{"empty denom", params(func(p *Params) { p.EvmDenom = "" }), true},
+		{"invalid denom", params(func(p *Params) { p.EvmDenom = "1photon" }), true},
+		{"unbounded sizes", params(func(p *Params) { p.MaxTxSize, p.MaxCodeSize = 0, 0 }), false},
+		{"code size above eip170", params(func(p *Params) { p.MaxCodeSize = DefaultMaxCodeSize + 1 }), true},
+		{"extra eip", params(func(p *Params) { p.ExtraEIPs = []int{EIP3541} }), false},
+		{"unknown extra eip", params(func(p *Params) { p.ExtraEIPs = []int{EIP3541, 3855} }), true},
+		{"reject zero address recipient", params(func(p *Params) { p.RejectZeroAddressRecipient = true }), false},
+		{"base fee not burned", params(func(p *Params) { p.BurnBaseFee = false }), false},
+		{"allowed deployers", params(func(p *Params) { p.AllowedDeployers = []string{"0x1122334455667788990011223344556677889900"} }), false},
+		{"invalid allowed deployer", params(func(p *Params) { p.AllowedDeployers = []string{"cosmos1"} }), true},
+		{"initial and min base fee", params(func(p *Params) { p.InitialBaseFee, p.MinBaseFee = sdk.NewInt(10), sdk.NewInt(10) }), false},
+		{"initial base fee below min", params(func(p *Params) { p.InitialBaseFee, p.MinBaseFee = sdk.NewInt(9), sdk.NewInt(10) }), true},
+		{"negative min base fee", params(func(p *Params) { p.MinBaseFee = sdk.NewInt(-1) }), true},
+		{"opcode gas costs", params(func(p *Params) { p.OpcodeGasCosts = []OpcodeGasCost{{Opcode: "MUL", Gas: 100}} }), true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.params.Validate()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestValidateExtraEIPs(t *testing.T) {
+	require.NoError(t, ValidateExtraEIPs(nil))
+	require.NoError(t, ValidateExtraEIPs([]int{EIP3541, EIP3860}))
+
+	// require the error to list all the invalid EIPs
+	err := ValidateExtraEIPs([]int{0, EIP3541, 3855})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "[0 3855]")
+}
+
+func TestValidateOpcodeGasCosts(t *testing.T) {
+	require.NoError(t, ValidateOpcodeGasCosts(nil))
+	require.NoError(t, ValidateOpcodeGasCosts([]OpcodeGasCost{}))
+
+	// require the overrides to be rejected by the go-ethereum v1.9.0 EVM
+	err := ValidateOpcodeGasCosts([]OpcodeGasCost{{Opcode: "ADD", Gas: 1000}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "custom jump tables")
+}
+
+func TestParamsValidateGasPrice(t *testing.T) {
+	params := NewParams(sdk.NewInt(10), sdk.NewInt(100))
+
+	require.NoError(t, params.ValidateGasPrice(sdk.NewInt(10)))
+	require.NoError(t, params.ValidateGasPrice(sdk.NewInt(100)))
+
+	err := params.ValidateGasPrice(sdk.NewInt(9))
+	require.True(t, sdkerrors.ErrInsufficientFee.Is(err))
+	require.Error(t, params.ValidateGasPrice(sdk.NewInt(101)))
+
+	// a zero max gas price doesn't bound the gas price
+	require.NoError(t, DefaultParams().ValidateGasPrice(sdk.NewInt(1000000000000)))
+}
+
+func TestParamsValidateRecipient(t *testing.T) {
+	zero := common.Address{}
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	// the zero address is a valid recipient by default
+	params := DefaultParams()
+	require.NoError(t, params.ValidateRecipient(nil))
+	require.NoError(t, params.ValidateRecipient(&zero))
+	require.NoError(t, params.ValidateRecipient(&recipient))
+
+	// a nil recipient creating a contract isn't rejected
+	params.RejectZeroAddressRecipient = true
+	require.NoError(t, params.ValidateRecipient(nil))
+	require.NoError(t, params.ValidateRecipient(&recipient))
+
+	err := params.ValidateRecipient(&zero)
+	require.True(t, sdkerrors.ErrInvalidAddress.Is(err))
+}
+
+func TestParamsValidateCreateCall(t *testing.T) {
+	recipient := common.BytesToAddress([]byte("recipient"))
+
+	// the contract creations and calls are enabled by default
+	params := DefaultParams()
+	require.NoError(t, params.ValidateCreateCall(nil, false))
+	require.NoError(t, params.ValidateCreateCall(&recipient, true))
+
+	params.EnableCreate = false
+	err := params.ValidateCreateCall(nil, false)
+	require.True(t, emint.ErrCreateDisabled.Is(err))
+	require.NoError(t, params.ValidateCreateCall(&recipient, true))
+
+	// the transfers to the externally-owned accounts are always accepted
+	params.EnableCreate = true
+	params.EnableCall = false
+	err = params.ValidateCreateCall(&recipient, true)
+	require.True(t, emint.ErrCallDisabled.Is(err))
+	require.NoError(t, params.ValidateCreateCall(&recipient, false))
+	require.NoError(t, params.ValidateCreateCall(nil, false))
+}
+
+func TestParamsValidateDeployer(t *testing.T) {
+	deployer := common.BytesToAddress([]byte("deployer"))
+	sender := common.BytesToAddress([]byte("sender"))
+
+	// every account can deploy contracts by default
+	params := DefaultParams()
+	require.NoError(t, params.ValidateDeployer(sender, nil))
+
+	// the calls aren't restricted by the allowed deployers
+	params.AllowedDeployers = []string{deployer.Hex()}
+	require.NoError(t, params.ValidateDeployer(deployer, nil))
+	require.NoError(t, params.ValidateDeployer(sender, &deployer))
+
+	err := params.ValidateDeployer(sender, nil)
+	require.True(t, sdkerrors.ErrUnauthorized.Is(err))
+}
+
+func TestParamsValidateTxSize(t *testing.T) {
+	params := DefaultParams()
+	params.MaxTxSize = 10
+
+	require.NoError(t, params.ValidateTxSize(nil))
+	require.NoError(t, params.ValidateTxSize(make([]byte, 10)))
+
+	err := params.ValidateTxSize(make([]byte, 11))
+	require.True(t, emint.ErrTxSizeExceeded.Is(err))
+
+	// a zero max tx size doesn't bound the payload
+	params.MaxTxSize = 0
+	require.NoError(t, params.ValidateTxSize(make([]byte, 2*DefaultMaxTxSize)))
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Hardforks selecting the set of precompiled contracts available to the EVM
+const (
+	// HardforkHomestead defines the precompiled contracts of the Frontier and
+	// Homestead releases: ecrecover, sha256, ripemd160 and identity
+	HardforkHomestead = "homestead"
+	// HardforkByzantium adds the modexp and bn256 precompiled contracts
+	HardforkByzantium = "byzantium"
+
+	// DefaultHardfork defines the hardfork of the precompiled contracts used by
+	// default, which is the one of the EVM chain config
+	DefaultHardfork = HardforkByzantium
+)
+
+// PrecompiledContracts maps the addresses of precompiled contracts to their
+// implementation.
+type PrecompiledContracts map[ethcmn.Address]vm.PrecompiledContract
+
+// precompiles is the registry of the precompiled contracts of each hardfork,
+// which are the sets implemented by the go-ethereum EVM
+var precompiles = map[string]map[ethcmn.Address]vm.PrecompiledContract{
+	HardforkHomestead: vm.PrecompiledContractsHomestead,
+	HardforkByzantium: vm.PrecompiledContractsByzantium,
+}
+
+// GetPrecompiledContracts returns a copy of the precompiled contracts of the
+// hardfork.
+func GetPrecompiledContracts(hardfork string) (PrecompiledContracts, error) {
+	contracts, ok := precompiles[hardfork]
+	if !ok {
+		return nil, fmt.Errorf("unknown hardfork %s", hardfork)
+	}
+
+	cpy := make(PrecompiledContracts, len(contracts))
+	for addr, contract := range contracts {
+		cpy[addr] = contract
+	}
+	return cpy, nil
+}
+
+// ValidateHardfork returns an error if the hardfork has no registered set of
+// precompiled contracts.
+func ValidateHardfork(hardfork string) error {
+	_, err := GetPrecompiledContracts(hardfork)
+	return err
+}
+
+// applyHardfork restricts the go-ethereum chain config to the precompiled
+// contracts of the hardfork. The go-ethereum EVM selects its precompiled
+// contracts from the Byzantium activation of the chain config, hence the
+// homestead contracts disable the Byzantium fork and the following ones.
+func applyHardfork(config *params.ChainConfig, hardfork string) {
+	if hardfork != HardforkHomestead {
+		return
+	}
+
+	config.ByzantiumBlock = nil
+	config.ConstantinopleBlock = nil
+	config.PetersburgBlock = nil
+}
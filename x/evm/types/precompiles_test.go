@@ -0,0 +1,95 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestGetPrecompiledContracts(t *testing.T) {
+	testCases := []struct {
+		hardfork string
+		expLen   int
+		expError bool
+	}{
+		{HardforkHomestead, 4, false},
+		{HardforkByzantium, 8, false},
+		{"istanbul", 0, true},
+	}
+
+	for _, tc := range testCases {
+		contracts, err := GetPrecompiledContracts(tc.hardfork)
+		if tc.expError {
+			require.Error(t, err, tc.hardfork)
+			continue
+		}
+
+		require.NoError(t, err, tc.hardfork)
+		require.Len(t, contracts, tc.expLen, tc.hardfork)
+		for i := 1; i <= tc.expLen; i++ {
+			require.NotNil(t, contracts[ethcmn.BytesToAddress([]byte{byte(i)})], tc.hardfork)
+		}
+	}
+}
+
+func TestApplyHardfork(t *testing.T) {
+	height := big.NewInt(10)
+
+	config := DefaultChainConfig().EthereumConfig(big.NewInt(3))
+	applyHardfork(config, HardforkByzantium)
+	require.True(t, config.IsByzantium(height))
+	require.True(t, config.IsPetersburg(height))
+
+	// require the homestead contracts to disable the Byzantium fork, which
+	// selects the precompiled contracts of the go-ethereum EVM
+	applyHardfork(config, HardforkHomestead)
+	require.False(t, config.IsByzantium(height))
+	require.False(t, config.IsConstantinople(height))
+	require.False(t, config.IsPetersburg(height))
+	require.True(t, config.IsEIP158(height))
+}
+
+func TestPrecompiledContractEcrecover(t *testing.T) {
+	priv, err := ethcrypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+
+	hash := ethcrypto.Keccak256([]byte("ethermint"))
+	sig, err := ethcrypto.Sign(hash, priv)
+	require.NoError(t, err)
+
+	// input: hash || v || r || s, with v = 27 + recovery id
+	input := make([]byte, 128)
+	copy(input, hash)
+	input[63] = sig[64] + 27
+	copy(input[64:], sig[:64])
+
+	contracts, err := GetPrecompiledContracts(HardforkHomestead)
+	require.NoError(t, err)
+
+	ecrecover := contracts[ethcmn.BytesToAddress([]byte{1})]
+	require.Equal(t, uint64(3000), ecrecover.RequiredGas(input))
+
+	output, err := ecrecover.Run(input)
+	require.NoError(t, err)
+	require.Equal(t, ethcmn.LeftPadBytes(ethcrypto.PubkeyToAddress(priv.PublicKey).Bytes(), 32), output)
+}
+
+func TestPrecompiledContractIdentity(t *testing.T) {
+	contracts, err := GetPrecompiledContracts(HardforkHomestead)
+	require.NoError(t, err)
+
+	identity := contracts[ethcmn.BytesToAddress([]byte{4})]
+
+	for _, input := range [][]byte{nil, []byte("arbitrary input"), make([]byte, 33)} {
+		output, err := identity.Run(input)
+		require.NoError(t, err)
+		require.Equal(t, input, output)
+	}
+
+	// base gas + gas per word
+	require.Equal(t, uint64(15+3*2), identity.RequiredGas(make([]byte, 33)))
+}
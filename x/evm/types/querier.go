@@ -2,6 +2,9 @@ package types
 
 import (
 	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
@@ -14,11 +17,22 @@ const (
 	QueryStorage         = "storage"
 	QueryCode            = "code"
 	QueryNonce           = "nonce"
+	QueryPendingNonce    = "pendingNonce"
 	QueryHashToHeight    = "hashToHeight"
 	QueryTxLogs          = "txLogs"
 	QueryLogsBloom       = "logsBloom"
 	QueryLogs            = "logs"
 	QueryAccount         = "account"
+	QueryFilterLogs      = "filterLogs"
+	QueryTxHash          = "txHash"
+	QueryTraceTx         = "traceTx"
+	QueryParams          = "params"
+	QueryTxReceipt       = "txReceipt"
+	QueryBaseFee         = "baseFee"
+	QueryStorageDump     = "storageDump"
+	QueryAccountSummary  = "accountSummary"
+	QueryStateRoot       = "stateRoot"
+	QueryTxsByRecipient  = "txsByRecipient"
 )
 
 // QueryResProtocolVersion is response type for protocol version query
@@ -39,13 +53,22 @@ func (q QueryResBalance) String() string {
 	return q.Balance
 }
 
+// QueryResBaseFee is response type for the base fee query
+type QueryResBaseFee struct {
+	BaseFee string `json:"baseFee"`
+}
+
+func (q QueryResBaseFee) String() string {
+	return q.BaseFee
+}
+
 // QueryResBlockNumber is response type for block number query
 type QueryResBlockNumber struct {
 	Number int64 `json:"blockNumber"`
 }
 
 func (q QueryResBlockNumber) String() string {
-	return string(q.Number)
+	return fmt.Sprintf("%d", q.Number)
 }
 
 // QueryResStorage is response type for storage query
@@ -57,6 +80,19 @@ func (q QueryResStorage) String() string {
 	return string(q.Value)
 }
 
+// QueryResStorageDump is response type for the storage dump query
+type QueryResStorageDump struct {
+	Storage Storage `json:"storage"`
+}
+
+func (q QueryResStorageDump) String() string {
+	var b strings.Builder
+	for _, state := range q.Storage {
+		fmt.Fprintf(&b, "%s: %s\n", state.Key, state.Value)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
 // QueryResCode is response type for code query
 type QueryResCode struct {
 	Code []byte
@@ -72,7 +108,16 @@ type QueryResNonce struct {
 }
 
 func (q QueryResNonce) String() string {
-	return string(q.Nonce)
+	return fmt.Sprintf("%d", q.Nonce)
+}
+
+// QueryResTxHash is response type for the Ethereum transaction hash query
+type QueryResTxHash struct {
+	Hash []byte `json:"hash"`
+}
+
+func (q QueryResTxHash) String() string {
+	return fmt.Sprintf("%X", q.Hash)
 }
 
 // QueryETHLogs is response type for tx logs query
@@ -93,9 +138,51 @@ func (q QueryBloomFilter) String() string {
 	return string(q.Bloom.Bytes())
 }
 
+// QueryResStateRoot is response type for the state root query
+type QueryResStateRoot struct {
+	Root string `json:"root"`
+}
+
+func (q QueryResStateRoot) String() string {
+	return q.Root
+}
+
+// QueryResTxReceipts is response type for the transactions by recipient query
+type QueryResTxReceipts struct {
+	Receipts []TxReceipt `json:"receipts"`
+}
+
 // QueryAccount is response type for querying Ethereum state objects
 type QueryResAccount struct {
 	Balance  string `json:"balance"`
 	CodeHash []byte `json:"codeHash"`
 	Nonce    uint64 `json:"nonce"`
 }
+
+// QueryResAccountSummary is response type for the account summary query
+type QueryResAccountSummary struct {
+	Address       string         `json:"address"`
+	CosmosAddress sdk.AccAddress `json:"cosmosAddress"`
+	Contract      bool           `json:"contract"`
+	Balance       sdk.Coin       `json:"balance"`
+	Nonce         uint64         `json:"nonce"`
+	CodeSize      int            `json:"codeSize"`
+	StorageSlots  int            `json:"storageSlots"`
+}
+
+func (q QueryResAccountSummary) String() string {
+	accountType := "EOA"
+	if q.Contract {
+		accountType = "contract"
+	}
+
+	return fmt.Sprintf(`Address:        %s
+Cosmos Address: %s
+Type:           %s
+Balance:        %s
+Nonce:          %d
+Code Size:      %d
+Storage Slots:  %d`,
+		q.Address, q.CosmosAddress, accountType, q.Balance, q.Nonce, q.CodeSize, q.StorageSlots,
+	)
+}
@@ -0,0 +1,46 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// ReceiptStatusFailed is the status code of a transaction if execution failed.
+	ReceiptStatusFailed = uint64(0)
+
+	// ReceiptStatusSuccessful is the status code of a transaction if execution
+	// succeeded.
+	ReceiptStatusSuccessful = uint64(1)
+)
+
+// TxReceipt defines the Ethereum compatible receipt of an EVM transaction
+// as it is stored by the keeper.
+type TxReceipt struct {
+	Status            uint64          `json:"status"`
+	CumulativeGasUsed uint64          `json:"cumulativeGasUsed"`
+	GasUsed           uint64          `json:"gasUsed"`
+	Bloom             ethtypes.Bloom  `json:"logsBloom"`
+	Logs              []*ethtypes.Log `json:"logs"`
+	TxHash            ethcmn.Hash     `json:"transactionHash"`
+	ContractAddress   *ethcmn.Address `json:"contractAddress"`
+	From              ethcmn.Address  `json:"from"`
+	To                *ethcmn.Address `json:"to"`
+	BlockHeight       uint64          `json:"blockNumber"`
+	TxIndex           uint64          `json:"transactionIndex"`
+}
+
+// EncodeTxReceipt encodes a transaction receipt using amino
+func EncodeTxReceipt(receipt TxReceipt) ([]byte, error) {
+	return ModuleCdc.MarshalBinaryLengthPrefixed(receipt)
+}
+
+// DecodeTxReceipt decodes an amino-encoded byte slice into a transaction receipt
+func DecodeTxReceipt(in []byte) (TxReceipt, error) {
+	var receipt TxReceipt
+	err := ModuleCdc.UnmarshalBinaryLengthPrefixed(in, &receipt)
+	if err != nil {
+		return TxReceipt{}, err
+	}
+	return receipt, nil
+}
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"math/big"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// DefaultSigCacheSize is the default number of recovered signers kept by the
+// signature cache
+const DefaultSigCacheSize = 4096
+
+// SigCache is a bounded LRU cache of the signers recovered from the Ethereum
+// transaction signatures. It saves the ECDSA recovery of the transactions
+// verified more than once, eg. during CheckTx and then DeliverTx. The entries
+// are keyed by the chain ID and the transaction hash, which covers the
+// signature values, so that a signer is never returned for another chain ID.
+// A SigCache is safe for concurrent use.
+type SigCache struct {
+	cache *lru.Cache
+}
+
+// sigCacheKey identifies a signature verified for a given chain ID
+type sigCacheKey struct {
+	chainID string
+	hash    ethcmn.Hash
+}
+
+// NewSigCache creates a new signature cache holding at most size signers. It
+// panics if the size isn't positive.
+func NewSigCache(size int) *SigCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+
+	return &SigCache{cache: cache}
+}
+
+// VerifySig returns the signer of the transaction for the given chain ID. The
+// signer is looked up in the cache and only recovered from the signature on a
+// miss, as done by MsgEthereumTx.VerifySig. The failed verifications aren't
+// cached.
+func (c *SigCache) VerifySig(msg *MsgEthereumTx, chainID *big.Int) (ethcmn.Address, error) {
+	key := sigCacheKey{chainID: chainID.String(), hash: msg.Hash()}
+
+	if entry, ok := c.cache.Get(key); ok {
+		sc := entry.(sigCache)
+		// cache the signer on the transaction as well for the later calls
+		msg.from.Store(sc)
+		return sc.from, nil
+	}
+
+	sender, err := msg.VerifySig(chainID)
+	if err != nil {
+		return ethcmn.Address{}, err
+	}
+
+	c.cache.Add(key, msg.from.Load())
+	return sender, nil
+}
+
+// Len returns the number of signers in the cache.
+func (c *SigCache) Len() int {
+	return c.cache.Len()
+}
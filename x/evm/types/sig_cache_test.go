@@ -0,0 +1,99 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/ethermint/crypto"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func newSignedTestTx(tb testing.TB, chainID *big.Int, nonce uint64) (MsgEthereumTx, ethcmn.Address) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(tb, err)
+
+	to := ethcmn.BytesToAddress([]byte("recipient"))
+	msg := NewMsgEthereumTx(nonce, &to, big.NewInt(1), 21000, big.NewInt(1), nil)
+	msg.Sign(chainID, priv.ToECDSA())
+
+	return msg, ethcmn.BytesToAddress(priv.PubKey().Address().Bytes())
+}
+
+func TestSigCacheVerifySig(t *testing.T) {
+	chainID := big.NewInt(3)
+	cache := NewSigCache(2)
+
+	msg, signer := newSignedTestTx(t, chainID, 0)
+
+	sender, err := cache.VerifySig(&msg, chainID)
+	require.NoError(t, err)
+	require.Equal(t, signer, sender)
+	require.Equal(t, 1, cache.Len())
+
+	// require a fresh decoding of the transaction to hit the cache and have
+	// its sender set
+	fresh := MsgEthereumTx{Data: msg.Data}
+	sender, err = cache.VerifySig(&fresh, chainID)
+	require.NoError(t, err)
+	require.Equal(t, signer, sender)
+	require.Equal(t, signer.Bytes(), fresh.From().Bytes())
+	require.Equal(t, 1, cache.Len())
+
+	// require the cached signer not to be returned for another chain ID
+	fresh = MsgEthereumTx{Data: msg.Data}
+	_, err = cache.VerifySig(&fresh, big.NewInt(4))
+	require.Error(t, err)
+	require.Equal(t, 1, cache.Len())
+
+	// require the failed verifications not to be cached
+	invalid := MsgEthereumTx{Data: msg.Data}
+	invalid.Data.S = new(big.Int)
+	_, err = cache.VerifySig(&invalid, chainID)
+	require.Error(t, err)
+	require.Equal(t, 1, cache.Len())
+
+	// require the cache to be bounded
+	for nonce := uint64(1); nonce <= 3; nonce++ {
+		other, _ := newSignedTestTx(t, chainID, nonce)
+		_, err = cache.VerifySig(&other, chainID)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, cache.Len())
+}
+
+func TestNewSigCacheInvalidSize(t *testing.T) {
+	require.Panics(t, func() { NewSigCache(0) })
+}
+
+// The benchmarks verify a freshly decoded copy of the transaction at each
+// iteration, as CheckTx and DeliverTx do, since the transaction caches its own
+// sender after the first verification.
+
+func BenchmarkVerifySig(b *testing.B) {
+	chainID := big.NewInt(3)
+	msg, _ := newSignedTestTx(b, chainID, 0)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fresh := MsgEthereumTx{Data: msg.Data}
+		if _, err := fresh.VerifySig(chainID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSigCacheVerifySig(b *testing.B) {
+	chainID := big.NewInt(3)
+	msg, _ := newSignedTestTx(b, chainID, 0)
+	cache := NewSigCache(DefaultSigCacheSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fresh := MsgEthereumTx{Data: msg.Data}
+		if _, err := cache.VerifySig(&fresh, chainID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -97,8 +97,7 @@ func newStateObject(db *CommitStateDB, accProto authexported.Account) *stateObje
 // Setters
 // ----------------------------------------------------------------------------
 
-// SetState updates a value in account storage. Note, the key will be prefixed
-// with the address of the state object.
+// SetState updates a value in account storage.
 func (so *stateObject) SetState(db ethstate.Database, key, value ethcmn.Hash) {
 	// if the new value is the same as old, don't set
 	prev := so.GetState(db, key)
@@ -106,16 +105,14 @@ func (so *stateObject) SetState(db ethstate.Database, key, value ethcmn.Hash) {
 		return
 	}
 
-	prefixKey := so.GetStorageByAddressKey(key.Bytes())
-
 	// since the new value is different, update and journal the change
 	so.stateDB.journal.append(storageChange{
 		account:   &so.address,
-		key:       prefixKey,
+		key:       key,
 		prevValue: prev,
 	})
 
-	so.setState(prefixKey, value)
+	so.setState(key, value)
 }
 
 func (so *stateObject) setState(key, value ethcmn.Hash) {
@@ -144,11 +141,9 @@ func (so *stateObject) setCode(codeHash ethcmn.Hash, code []byte) {
 // AddBalance adds an amount to a state object's balance. It is used to add
 // funds to the destination account of a transfer.
 func (so *stateObject) AddBalance(amount *big.Int) {
-	amt := sdk.NewIntFromBigInt(amount)
-
 	// EIP158: We must check emptiness for the objects such that the account
 	// clearing (0,0,0 objects) can take effect.
-	if amt.Sign() == 0 {
+	if amount.Sign() == 0 {
 		if so.empty() {
 			so.touch()
 		}
@@ -156,37 +151,56 @@ func (so *stateObject) AddBalance(amount *big.Int) {
 		return
 	}
 
-	newBalance := so.account.Balance().Add(amt)
-	so.SetBalance(newBalance.BigInt())
+	amt, err := WeiToCoin(amount, so.stateDB.coinDecimals)
+	if err != nil {
+		so.setError(err)
+		return
+	}
+
+	so.setBalanceWithJournal(so.account.Balance(so.stateDB.evmDenom).Add(amt))
 }
 
 // SubBalance removes an amount from the stateObject's balance. It is used to
 // remove funds from the origin account of a transfer.
 func (so *stateObject) SubBalance(amount *big.Int) {
-	amt := sdk.NewIntFromBigInt(amount)
+	if amount.Sign() == 0 {
+		return
+	}
 
-	if amt.Sign() == 0 {
+	amt, err := WeiToCoin(amount, so.stateDB.coinDecimals)
+	if err != nil {
+		so.setError(err)
 		return
 	}
 
-	newBalance := so.account.Balance().Sub(amt)
-	so.SetBalance(newBalance.BigInt())
+	so.setBalanceWithJournal(so.account.Balance(so.stateDB.evmDenom).Sub(amt))
 }
 
-// SetBalance sets the state object's balance.
+// SetBalance sets the state object's balance. The amount is denominated in wei
+// and must be representable in the coin denomination.
 func (so *stateObject) SetBalance(amount *big.Int) {
-	amt := sdk.NewIntFromBigInt(amount)
+	amt, err := WeiToCoin(amount, so.stateDB.coinDecimals)
+	if err != nil {
+		so.setError(err)
+		return
+	}
 
+	so.setBalanceWithJournal(amt)
+}
+
+// setBalanceWithJournal sets the state object's balance, denominated in the coin
+// denomination, and journals the previous balance.
+func (so *stateObject) setBalanceWithJournal(amount sdk.Int) {
 	so.stateDB.journal.append(balanceChange{
 		account: &so.address,
-		prev:    so.account.Balance(),
+		prev:    so.account.Balance(so.stateDB.evmDenom),
 	})
 
-	so.setBalance(amt)
+	so.setBalance(amount)
 }
 
 func (so *stateObject) setBalance(amount sdk.Int) {
-	so.account.SetBalance(amount)
+	so.account.SetBalance(so.stateDB.evmDenom, amount)
 }
 
 // SetNonce sets the state object's nonce (i.e sequence number of the account).
@@ -234,11 +248,11 @@ func (so *stateObject) commitState() {
 
 		// delete empty values
 		if (value == ethcmn.Hash{}) {
-			store.Delete(key.Bytes())
+			store.Delete(StorageKey(so.address, key))
 			continue
 		}
 
-		store.Set(key.Bytes(), value.Bytes())
+		store.Set(StorageKey(so.address, key), value.Bytes())
 	}
 
 	// TODO: Set the account (storage) root (but we probably don't need this)
@@ -260,13 +274,13 @@ func (so stateObject) Address() ethcmn.Address {
 	return so.address
 }
 
-// Balance returns the state object's current balance.
+// Balance returns the state object's current balance, denominated in wei.
 func (so *stateObject) Balance() *big.Int {
-	balance := so.account.Balance().BigInt()
-	if balance == nil {
+	balance := so.account.Balance(so.stateDB.evmDenom)
+	if balance.BigInt() == nil {
 		return zeroBalance
 	}
-	return balance
+	return CoinToWei(balance, so.stateDB.coinDecimals)
 }
 
 // CodeHash returns the state object's code hash.
@@ -306,13 +320,10 @@ func (so *stateObject) Code(_ ethstate.Database) []byte {
 	return code
 }
 
-// GetState retrieves a value from the account storage trie. Note, the key will
-// be prefixed with the address of the state object.
+// GetState retrieves a value from the account storage trie.
 func (so *stateObject) GetState(db ethstate.Database, key ethcmn.Hash) ethcmn.Hash {
-	prefixKey := so.GetStorageByAddressKey(key.Bytes())
-
 	// if we have a dirty value for this state entry, return it
-	value, dirty := so.dirtyStorage[prefixKey]
+	value, dirty := so.dirtyStorage[key]
 	if dirty {
 		return value
 	}
@@ -322,12 +333,10 @@ func (so *stateObject) GetState(db ethstate.Database, key ethcmn.Hash) ethcmn.Ha
 }
 
 // GetCommittedState retrieves a value from the committed account storage trie.
-// Note, the key will be prefixed with the address of the state object.
+// Note, the store key is prefixed with the address of the state object.
 func (so *stateObject) GetCommittedState(_ ethstate.Database, key ethcmn.Hash) ethcmn.Hash {
-	prefixKey := so.GetStorageByAddressKey(key.Bytes())
-
 	// if we have the original value cached, return that
-	value, cached := so.originStorage[prefixKey]
+	value, cached := so.originStorage[key]
 	if cached {
 		return value
 	}
@@ -335,13 +344,13 @@ func (so *stateObject) GetCommittedState(_ ethstate.Database, key ethcmn.Hash) e
 	// otherwise load the value from the KVStore
 	ctx := so.stateDB.ctx
 	store := ctx.KVStore(so.stateDB.storeKey)
-	rawValue := store.Get(prefixKey.Bytes())
+	rawValue := store.Get(StorageKey(so.address, key))
 
 	if len(rawValue) > 0 {
 		value.SetBytes(rawValue)
 	}
 
-	so.originStorage[prefixKey] = value
+	so.originStorage[key] = value
 	return value
 }
 
@@ -371,8 +380,8 @@ func (so *stateObject) empty() bool {
 	return so.account == nil ||
 		(so.account != nil &&
 			so.account.Sequence == 0 &&
-			so.account.Balance().Sign() == 0 &&
-			bytes.Equal(so.account.CodeHash, emptyCodeHash))
+			so.account.Balance(so.stateDB.evmDenom).Sign() == 0 &&
+			bytes.Equal(so.CodeHash(), emptyCodeHash))
 }
 
 // EncodeRLP implements rlp.Encoder.
@@ -391,15 +400,3 @@ func (so *stateObject) touch() {
 		so.stateDB.journal.dirty(so.address)
 	}
 }
-
-// GetStorageByAddressKey returns a hash of the composite key for a state
-// object's storage prefixed with it's address.
-func (so stateObject) GetStorageByAddressKey(key []byte) ethcmn.Hash {
-	prefix := so.Address().Bytes()
-	compositeKey := make([]byte, len(prefix)+len(key))
-
-	copy(compositeKey, prefix)
-	copy(compositeKey[len(prefix):], key)
-
-	return ethcrypto.Keccak256Hash(compositeKey)
-}
@@ -3,11 +3,14 @@ package types
 import (
 	"errors"
 	"math/big"
+	"runtime/debug"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -17,6 +20,7 @@ import (
 // StateTransition defines data to transitionDB in evm
 type StateTransition struct {
 	Payload      []byte
+	AccessList   AccessList
 	Recipient    *common.Address
 	AccountNonce uint64
 	GasLimit     uint64
@@ -27,22 +31,56 @@ type StateTransition struct {
 	THash        *common.Hash
 	Sender       common.Address
 	Simulate     bool
+	// Tracer is an optional EVM tracer capturing the execution steps
+	Tracer vm.Tracer
+	// Hardfork selects the precompiled contracts available to the EVM, the
+	// default hardfork being used if empty
+	Hardfork string
+	// ChainConfig defines the activation heights of the hardforks, the default
+	// chain config being used if nil
+	ChainConfig *ChainConfig
+	// MaxCodeSize bounds the size of the code deployed by a contract creation,
+	// a zero value leaving the EIP-170 limit enforced by the EVM
+	MaxCodeSize uint64
+	// ExtraEIPs defines the EIPs enforced on top of the ones of the hardforks
+	ExtraEIPs []int
+	// GetHashFn returns the block hashes read by the BLOCKHASH opcode, the zero
+	// hash being returned for every block if nil
+	GetHashFn vm.GetHashFunc
 }
 
+// errExecutionReverted is the message of the unexported go-ethereum error
+// returned when the execution is reverted by the REVERT opcode
+const errExecutionReverted = "evm: execution reverted"
+
+// errMaxCodeSizeExceeded is the message of the unexported go-ethereum error
+// returned when the deployed code exceeds the EIP-170 limit
+const errMaxCodeSizeExceeded = "evm: max code size exceeded"
+
 // ReturnData represents what's returned from a transition
 type ReturnData struct {
 	Logs   []*ethtypes.Log
 	Bloom  *big.Int
 	Result *sdk.Result
+	// Reverted is true if the execution was reverted by the REVERT opcode, its
+	// state changes being discarded while its gas is used
+	Reverted bool
+	// Err wraps ErrExecutionReverted with the revert reason of a reverted
+	// execution, if any
+	Err error
 }
 
 // TODO: move to keeper
 // TransitionCSDB performs an evm state transition from a transaction
 // TODO: update godoc, it doesn't explain what it does in depth.
+//
+// A reverted execution is completed without error, the returned data being
+// marked as reverted and holding the revert payload in the result data, so that
+// its receipt is stored with a failed status.
 func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 	contractCreation := st.Recipient == nil
 
-	cost, err := core.IntrinsicGas(st.Payload, contractCreation, true)
+	cost, err := IntrinsicGas(st.Payload, st.AccessList, contractCreation)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction")
 	}
@@ -51,6 +89,12 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 	gasLimit := st.GasLimit - ctx.GasMeter().GasConsumed()
 
 	csdb := st.Csdb.WithContext(ctx)
+
+	// the transferred amount is settled in the coin denomination of the balances
+	if _, err := WeiToCoin(st.Amount, csdb.CoinDecimals()); err != nil {
+		return nil, sdkerrors.Wrap(err, "invalid transaction amount")
+	}
+
 	if st.Simulate {
 		// gasLimit is set here because stdTxs incur gaskv charges in the ante handler, but for eth_call
 		// the cost needs to be the same as an Ethereum transaction sent through the web3 API
@@ -73,17 +117,24 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 	// Clear cache of accounts to handle changes outside of the EVM
 	csdb.UpdateAccounts()
 
-	gasPrice := ctx.MinGasPrices().AmountOf(emint.DenomDefault)
+	gasPrice := ctx.MinGasPrices().AmountOf(csdb.EvmDenom())
 	if gasPrice.IsNil() {
 		return nil, errors.New("gas price cannot be nil")
 	}
 
-	// Create context for evm
+	// Create context for evm. The coinbase is the address of the block proposer,
+	// as the miner of the RPC blocks.
+	getHash := st.GetHashFn
+	if getHash == nil {
+		getHash = func(uint64) common.Hash { return common.Hash{} }
+	}
+
 	context := vm.Context{
 		CanTransfer: core.CanTransfer,
 		Transfer:    core.Transfer,
+		GetHash:     getHash,
 		Origin:      st.Sender,
-		Coinbase:    common.Address{}, // TODO: explain why this is empty
+		Coinbase:    common.BytesToAddress(ctx.BlockHeader().ProposerAddress),
 		BlockNumber: big.NewInt(ctx.BlockHeight()),
 		Time:        big.NewInt(ctx.BlockHeader().Time.Unix()),
 		Difficulty:  big.NewInt(0), // unused. Only required in PoW context
@@ -91,15 +142,52 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 		GasPrice:    gasPrice.Int,
 	}
 
-	evm := vm.NewEVM(context, csdb, GenerateChainConfig(st.ChainID), vm.Config{})
+	vmConfig := vm.Config{}
+	if st.Tracer != nil {
+		vmConfig = vm.Config{Debug: true, Tracer: st.Tracer}
+	}
+
+	chainConfig := DefaultChainConfig()
+	if st.ChainConfig != nil {
+		chainConfig = *st.ChainConfig
+	}
+
+	hardfork := st.Hardfork
+	if hardfork == "" {
+		hardfork = DefaultHardfork
+	}
+
+	if err := ValidateHardfork(hardfork); err != nil {
+		return nil, err
+	}
+
+	// the precompiled contracts of the hardfork are only available once it is
+	// activated by the chain config
+	ethChainConfig := chainConfig.EthereumConfig(st.ChainID)
+	applyHardfork(ethChainConfig, hardfork)
+
+	evm := vm.NewEVM(context, csdb, ethChainConfig, vmConfig)
 
 	var (
 		ret         []byte
 		leftOverGas uint64
 		addr        common.Address
-		senderRef   = vm.AccountRef(st.Sender)
 	)
 
+	// from EIP-3860, the init code of a contract creation is bounded and charged
+	// per word on top of the intrinsic gas
+	var initCodeGas uint64
+	if contractCreation && isEIPActivated(st.ExtraEIPs, EIP3860) {
+		if initCodeGas, err = InitCodeGas(uint64(len(st.Payload))); err != nil {
+			return nil, err
+		}
+	}
+
+	// Take a snapshot of the state before the transition so that all the state
+	// changes (including the sender nonce updates) are reverted if the execution
+	// fails
+	snapshot := st.Csdb.Snapshot()
+
 	// Get nonce of account outside of the EVM
 	currentNonce := st.Csdb.GetNonce(st.Sender)
 	// Set nonce of sender account before evm state transition for usage in generating Create address
@@ -107,18 +195,71 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 
 	switch contractCreation {
 	case true:
-		ret, addr, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
+		if initCodeGas > gasLimit {
+			err = vm.ErrOutOfGas
+		} else {
+			ret, addr, leftOverGas, err = st.execute(ctx, evm, gasLimit-initCodeGas)
+		}
+		// the returned data of a successful creation is the deployed code, the
+		// creations rejected after the execution using all their gas as the ones
+		// rejected by the EVM
+		if err == nil && st.MaxCodeSize != 0 && uint64(len(ret)) > st.MaxCodeSize {
+			err = sdkerrors.Wrapf(
+				emint.ErrCodeSizeExceeded, "code size %d exceeds the max code size %d", len(ret), st.MaxCodeSize,
+			)
+			leftOverGas = 0
+		}
+		if err == nil && isEIPActivated(st.ExtraEIPs, EIP3541) && len(ret) > 0 && ret[0] == 0xEF {
+			err = sdkerrors.Wrap(emint.ErrVMExecution, "invalid code: must not begin with 0xef")
+			leftOverGas = 0
+		}
 	default:
 		// Increment the nonce for the next transaction	(just for evm state transition)
 		csdb.SetNonce(st.Sender, csdb.GetNonce(st.Sender)+1)
-		ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
+		ret, _, leftOverGas, err = st.execute(ctx, evm, gasLimit)
 	}
 
 	if err != nil {
+		st.Csdb.RevertToSnapshot(snapshot)
+
+		// the gas used by a failed execution is consumed as well, so that the
+		// gas meter reflects it. The EVM leaves no gas to the failed executions
+		// but the reverted ones.
+		ctx.WithGasMeter(currentGasMeter).GasMeter().ConsumeGas(gasLimit-leftOverGas, "EVM execution consumption")
+
+		switch {
+		case err.Error() == errExecutionReverted:
+			// the reverted execution is completed, so the state is finalised as
+			// for a successful one
+			if !st.Simulate {
+				if err := st.Csdb.Finalise(evm.ChainConfig().IsEIP158(context.BlockNumber)); err != nil {
+					return nil, err
+				}
+			}
+			return st.revertedData(ret)
+		case err == vm.ErrOutOfGas || err == vm.ErrCodeStoreOutOfGas:
+			// the out of gas errors are distinguished from the reverted executions
+			// so that the gas estimation can tell them apart
+			return nil, sdkerrors.Wrap(sdkerrors.ErrOutOfGas, "evm execution went out of gas")
+		case err.Error() == errMaxCodeSizeExceeded:
+			return nil, sdkerrors.Wrapf(
+				emint.ErrCodeSizeExceeded, "code size exceeds the EIP-170 max code size %d", ethparams.MaxCodeSize,
+			)
+		case err == vm.ErrContractAddressCollision:
+			// the EVM aborts the creation if the address already has a nonce or code
+			return nil, sdkerrors.Wrapf(
+				emint.ErrContractAddressCollision, "contract address %s already in use",
+				crypto.CreateAddress(st.Sender, st.AccountNonce).Hex(),
+			)
+		}
 		return nil, err
 	}
 
+	// Apply the refund counter accumulated during the execution (eg. from
+	// SSTORE clears and SELFDESTRUCT), capped to half of the gas used as
+	// defined in the yellow paper
 	gasConsumed := gasLimit - leftOverGas
+	gasConsumed -= refundGas(csdb.GetRefund(), gasConsumed)
 
 	// Resets nonce to value pre state transition
 	st.Csdb.SetNonce(st.Sender, currentNonce)
@@ -134,8 +275,8 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 			return nil, err
 		}
 
-		bloomInt = ethtypes.LogsBloom(logs)
-		bloomFilter = ethtypes.BytesToBloom(bloomInt.Bytes())
+		bloomFilter = LogsToBloom(logs)
+		bloomInt = bloomFilter.Big()
 	}
 
 	// Encode all necessary data into slice of bytes to return in sdk result
@@ -152,23 +293,11 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 		return nil, err
 	}
 
-	// handle errors
-	if err != nil {
-		if err == vm.ErrOutOfGas || err == vm.ErrCodeStoreOutOfGas {
-			return nil, sdkerrors.Wrap(err, "evm execution went out of gas")
-		}
-
-		// Consume gas before returning
-		ctx.GasMeter().ConsumeGas(gasConsumed, "EVM execution consumption")
-		return nil, err
-	}
-
-	// TODO: Refund unused gas here, if intended in future
-
 	if !st.Simulate {
-		// Finalise state if not a simulated transaction
-		// TODO: change to depend on config
-		if err := st.Csdb.Finalise(true); err != nil {
+		// Finalise state if not a simulated transaction. The empty accounts
+		// touched by the transaction, such as the recipient of a zero value
+		// call, are deleted from the EIP-158 hardfork, as per EIP-161.
+		if err := st.Csdb.Finalise(evm.ChainConfig().IsEIP158(context.BlockNumber)); err != nil {
 			return nil, err
 		}
 	}
@@ -190,3 +319,64 @@ func (st StateTransition) TransitionCSDB(ctx sdk.Context) (*ReturnData, error) {
 
 	return returnData, nil
 }
+
+// execute runs the contract creation or the call of the transition on the EVM.
+// A panic of the EVM is recovered and returned as an error wrapping
+// ErrVMExecution so that the transaction fails instead of the node. The whole
+// gas limit is then used and the stack is logged for diagnostics.
+func (st StateTransition) execute(
+	ctx sdk.Context, evm *vm.EVM, gasLimit uint64,
+) (ret []byte, addr common.Address, leftOverGas uint64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctx.Logger().Error(
+				"EVM execution panicked", "sender", st.Sender.Hex(), "panic", r, "stack", string(debug.Stack()),
+			)
+			ret, leftOverGas = nil, 0
+			err = sdkerrors.Wrapf(emint.ErrVMExecution, "evm execution panicked: %v", r)
+		}
+	}()
+
+	senderRef := vm.AccountRef(st.Sender)
+	if st.Recipient == nil {
+		return evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
+	}
+
+	ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
+	return ret, common.Address{}, leftOverGas, err
+}
+
+// revertedData returns the data of a reverted execution, holding the revert
+// payload and the error wrapping the revert reason if any.
+func (st StateTransition) revertedData(ret []byte) (*ReturnData, error) {
+	err := sdkerrors.Wrap(emint.ErrExecutionReverted, "evm execution reverted")
+	if reason, unpackErr := UnpackRevertReason(ret); unpackErr == nil {
+		err = sdkerrors.Wrap(emint.ErrExecutionReverted, reason)
+	}
+
+	res := &ResultData{Ret: ret}
+	if st.THash != nil {
+		res.TxHash = *st.THash
+	}
+
+	resultData, encErr := EncodeResultData(res)
+	if encErr != nil {
+		return nil, encErr
+	}
+
+	return &ReturnData{
+		Bloom:    big.NewInt(0),
+		Result:   &sdk.Result{Data: resultData},
+		Reverted: true,
+		Err:      err,
+	}, nil
+}
+
+// refundGas returns the amount of gas to be refunded for the given refund
+// counter, which is capped to half of the gas used.
+func refundGas(refund, gasUsed uint64) uint64 {
+	if maxRefund := gasUsed / 2; refund > maxRefund {
+		return maxRefund
+	}
+	return refund
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundGas(t *testing.T) {
+	testCases := []struct {
+		refund   uint64
+		gasUsed  uint64
+		expected uint64
+	}{
+		{0, 100, 0},
+		{20, 100, 20},
+		{50, 100, 50},
+		{80, 100, 50},
+		{80, 101, 50},
+		{10, 0, 0},
+	}
+
+	for i, tc := range testCases {
+		require.Equal(t, tc.expected, refundGas(tc.refund, tc.gasUsed), "test: %v", i)
+	}
+}
@@ -1,20 +1,25 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"sort"
 	"sync"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 
 	emint "github.com/cosmos/ethermint/types"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	ethstate "github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethvm "github.com/ethereum/go-ethereum/core/vm"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/tendermint/tendermint/crypto/merkle"
 )
 
 var (
@@ -44,6 +49,11 @@ type CommitStateDB struct {
 	storeKey      sdk.StoreKey // i.e storage key
 	accountKeeper AccountKeeper
 
+	// coin denomination holding the account balances and its number of
+	// decimals, the balances being converted to wei when read by the EVM
+	evmDenom     string
+	coinDecimals uint32
+
 	// maps that hold 'live' objects, which will get modified while processing a
 	// state transition
 	stateObjects      map[ethcmn.Address]*stateObject
@@ -91,6 +101,8 @@ func NewCommitStateDB(
 		codeKey:           codeKey,
 		storeKey:          storeKey,
 		accountKeeper:     ak,
+		evmDenom:          emint.DenomDefault,
+		coinDecimals:      DefaultCoinDecimals,
 		stateObjects:      make(map[ethcmn.Address]*stateObject),
 		stateObjectsDirty: make(map[ethcmn.Address]struct{}),
 		logs:              make(map[ethcmn.Hash][]*ethtypes.Log),
@@ -105,6 +117,31 @@ func (csdb *CommitStateDB) WithContext(ctx sdk.Context) *CommitStateDB {
 	return csdb
 }
 
+// WithCoinDecimals returns a Database with an updated number of decimals of the
+// coin denomination holding the account balances.
+func (csdb *CommitStateDB) WithCoinDecimals(decimals uint32) *CommitStateDB {
+	csdb.coinDecimals = decimals
+	return csdb
+}
+
+// CoinDecimals returns the number of decimals of the coin denomination holding
+// the account balances.
+func (csdb *CommitStateDB) CoinDecimals() uint32 {
+	return csdb.coinDecimals
+}
+
+// WithEvmDenom returns a Database with an updated coin denomination holding the
+// account balances.
+func (csdb *CommitStateDB) WithEvmDenom(denom string) *CommitStateDB {
+	csdb.evmDenom = denom
+	return csdb
+}
+
+// EvmDenom returns the coin denomination holding the account balances.
+func (csdb *CommitStateDB) EvmDenom() string {
+	return csdb.evmDenom
+}
+
 // ----------------------------------------------------------------------------
 // Setters
 // ----------------------------------------------------------------------------
@@ -157,7 +194,9 @@ func (csdb *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 	}
 }
 
-// SetLogs sets the logs for a transaction in the KVStore.
+// SetLogs sets the logs for a transaction in the KVStore. The transaction hash
+// is also indexed by the current block height so that the logs can be
+// retrieved by block.
 func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error {
 	store := csdb.ctx.KVStore(csdb.storeKey)
 	enc, err := EncodeLogs(logs)
@@ -170,6 +209,7 @@ func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error
 	}
 
 	store.Set(LogsKey(hash[:]), enc)
+	store.Set(BlockLogsKey(csdb.ctx.BlockHeight(), hash[:]), hash.Bytes())
 	return nil
 }
 
@@ -179,6 +219,7 @@ func (csdb *CommitStateDB) AddLog(log *ethtypes.Log) {
 
 	log.TxHash = csdb.thash
 	log.BlockHash = csdb.bhash
+	log.BlockNumber = uint64(csdb.ctx.BlockHeight())
 	log.TxIndex = uint(csdb.txIndex)
 	log.Index = csdb.logSize
 	csdb.logs[csdb.thash] = append(csdb.logs[csdb.thash], log)
@@ -283,7 +324,8 @@ func (csdb *CommitStateDB) GetCodeHash(addr ethcmn.Address) ethcmn.Hash {
 	return ethcmn.BytesToHash(so.CodeHash())
 }
 
-// GetState retrieves a value from the given account's storage store.
+// GetState retrieves the current value from the given account's storage,
+// including the changes made by the transaction in progress.
 func (csdb *CommitStateDB) GetState(addr ethcmn.Address, hash ethcmn.Hash) ethcmn.Hash {
 	so := csdb.getStateObject(addr)
 	if so != nil {
@@ -294,7 +336,9 @@ func (csdb *CommitStateDB) GetState(addr ethcmn.Address, hash ethcmn.Hash) ethcm
 }
 
 // GetCommittedState retrieves a value from the given account's committed
-// storage.
+// storage, ie. the value as of the start of the transaction in progress, which
+// ignores its uncommitted writes. It is used by the EVM to meter the SSTORE gas
+// of the net gas metering (EIP-1283) with the original value of the slot.
 func (csdb *CommitStateDB) GetCommittedState(addr ethcmn.Address, hash ethcmn.Hash) ethcmn.Hash {
 	so := csdb.getStateObject(addr)
 	if so != nil {
@@ -321,6 +365,84 @@ func (csdb *CommitStateDB) GetLogs(hash ethcmn.Hash) ([]*ethtypes.Log, error) {
 	return DecodeLogs(encLogs)
 }
 
+// GetBlockLogs returns the logs emitted at the given block height from the
+// KVStore, in transaction hash order.
+func (csdb *CommitStateDB) GetBlockLogs(height int64) ([]*ethtypes.Log, error) {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, BlockLogsPrefix(height))
+	defer iter.Close()
+
+	logs := []*ethtypes.Log{}
+	for ; iter.Valid(); iter.Next() {
+		txLogs, err := csdb.GetLogs(ethcmn.BytesToHash(iter.Value()))
+		if err != nil {
+			return nil, err
+		}
+
+		logs = append(logs, txLogs...)
+	}
+
+	return logs, nil
+}
+
+// DeleteBlockLogs deletes the logs emitted at the given block height from the
+// KVStore and returns them, in transaction hash order.
+func (csdb *CommitStateDB) DeleteBlockLogs(height int64) ([]*ethtypes.Log, error) {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, BlockLogsPrefix(height))
+
+	var hashes []ethcmn.Hash
+	for ; iter.Valid(); iter.Next() {
+		hashes = append(hashes, ethcmn.BytesToHash(iter.Value()))
+	}
+	iter.Close()
+
+	logs := []*ethtypes.Log{}
+	for _, hash := range hashes {
+		encLogs := store.Get(LogsKey(hash[:]))
+		if len(encLogs) > 0 {
+			txLogs, err := DecodeLogs(encLogs)
+			if err != nil {
+				return nil, err
+			}
+			logs = append(logs, txLogs...)
+		}
+
+		delete(csdb.logs, hash)
+		store.Delete(LogsKey(hash[:]))
+		store.Delete(BlockLogsKey(height, hash[:]))
+	}
+
+	return logs, nil
+}
+
+// IterateLogs iterates over all the transaction logs persisted in the KVStore
+// and calls the given callback with the transaction hash and its logs. The
+// iteration stops when the callback returns true.
+func (csdb *CommitStateDB) IterateLogs(cb func(hash ethcmn.Hash, logs []*ethtypes.Log) (stop bool)) error {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, logsPrefix)
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		// skip any other entry sharing the prefix (eg. a storage key)
+		if len(iter.Key()) != len(logsPrefix)+ethcmn.HashLength {
+			continue
+		}
+
+		logs, err := DecodeLogs(iter.Value())
+		if err != nil {
+			return err
+		}
+
+		if cb(ethcmn.BytesToHash(iter.Key()[len(logsPrefix):]), logs) {
+			break
+		}
+	}
+
+	return nil
+}
+
 // AllLogs returns all the current logs in the state.
 func (csdb *CommitStateDB) AllLogs() []*ethtypes.Log {
 	// nolint: prealloc
@@ -392,6 +514,10 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 				so.dirtyCode = false
 			}
 
+			// write the dirty storage of the state object, which is only set if
+			// the state object hasn't been finalised
+			so.commitState()
+
 			// update the object in the KVStore
 			if err := csdb.updateStateObject(so); err != nil {
 				return ethcmn.Hash{}, err
@@ -528,7 +654,8 @@ func (csdb *CommitStateDB) Empty(addr ethcmn.Address) bool {
 }
 
 // Exist reports whether the given account address exists in the state. Notably,
-// this also returns true for suicided accounts.
+// this also returns true for suicided accounts and for the empty accounts which
+// are not yet deleted.
 func (csdb *CommitStateDB) Exist(addr ethcmn.Address) bool {
 	return csdb.getStateObject(addr) != nil
 }
@@ -539,9 +666,12 @@ func (csdb *CommitStateDB) Error() error {
 }
 
 // Suicide marks the given account as suicided and clears the account balance.
+// The balance is transferred to the beneficiary by the EVM before the call.
 //
 // The account's state object is still available until the state is committed,
-// getStateObject will return a non-nil account after Suicide.
+// getStateObject will return a non-nil account after Suicide. The account is
+// removed from the store by Finalise or Commit, and the change is journaled so
+// that reverting to a previous snapshot un-marks the account.
 func (csdb *CommitStateDB) Suicide(addr ethcmn.Address) bool {
 	so := csdb.getStateObject(addr)
 	if so == nil {
@@ -551,7 +681,7 @@ func (csdb *CommitStateDB) Suicide(addr ethcmn.Address) bool {
 	csdb.journal.append(suicideChange{
 		account:     &addr,
 		prev:        so.suicided,
-		prevBalance: sdk.NewIntFromBigInt(so.Balance()),
+		prevBalance: so.account.Balance(csdb.evmDenom),
 	})
 
 	so.markSuicided()
@@ -583,7 +713,7 @@ func (csdb *CommitStateDB) UpdateAccounts() {
 		currAcc := csdb.accountKeeper.GetAccount(csdb.ctx, sdk.AccAddress(addr.Bytes()))
 		emintAcc, ok := currAcc.(*emint.Account)
 		if ok {
-			if so.Balance() != emintAcc.Balance().BigInt() || so.Nonce() != emintAcc.GetSequence() {
+			if so.Balance() != emintAcc.Balance(csdb.evmDenom).BigInt() || so.Nonce() != emintAcc.GetSequence() {
 				// If queried account's balance or nonce are invalid, update the account pointer
 				so.account = emintAcc
 			}
@@ -592,6 +722,47 @@ func (csdb *CommitStateDB) UpdateAccounts() {
 	}
 }
 
+// ResetLogIndex resets the index of the next log emitted in the block, so that
+// the log indexes are relative to the block.
+func (csdb *CommitStateDB) ResetLogIndex() {
+	csdb.logSize = 0
+}
+
+// StateRoot returns the commitment root of the EVM state committed to the
+// store. It is the simple Merkle root of the Ethereum accounts in address
+// order, each leaf holding the address, nonce, balance and code hash of an
+// account along with the simple Merkle root of its storage slots in key order.
+func (csdb *CommitStateDB) StateRoot() ethcmn.Hash {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+
+	var leaves [][]byte
+	csdb.accountKeeper.IterateAccounts(csdb.ctx, func(account authexported.Account) bool {
+		emintAccount, ok := account.(*emint.Account)
+		if !ok {
+			return false
+		}
+
+		addr := ethcmn.BytesToAddress(emintAccount.GetAddress().Bytes())
+		prefix := AddressStoragePrefix(addr)
+
+		var slots [][]byte
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			slots = append(slots, append(ethcmn.CopyBytes(iter.Key()[len(prefix):]), iter.Value()...))
+		}
+		iter.Close()
+
+		leaf := append(addr.Bytes(), sdk.Uint64ToBigEndian(emintAccount.GetSequence())...)
+		leaf = append(leaf, math.PaddedBigBytes(emintAccount.Balance(csdb.evmDenom).BigInt(), 32)...)
+		leaf = append(leaf, ethcmn.BytesToHash(emintAccount.CodeHash).Bytes()...)
+		leaf = append(leaf, ethcmn.BytesToHash(merkle.SimpleHashFromByteSlices(slots)).Bytes()...)
+		leaves = append(leaves, leaf)
+		return false
+	})
+
+	return ethcmn.BytesToHash(merkle.SimpleHashFromByteSlices(leaves))
+}
+
 // ClearStateObjects clears cache of state objects to handle account changes outside of the EVM
 func (csdb *CommitStateDB) ClearStateObjects() {
 	csdb.stateObjects = make(map[ethcmn.Address]*stateObject)
@@ -642,6 +813,8 @@ func (csdb *CommitStateDB) Copy() *CommitStateDB {
 		codeKey:           csdb.codeKey,
 		storeKey:          csdb.storeKey,
 		accountKeeper:     csdb.accountKeeper,
+		evmDenom:          csdb.evmDenom,
+		coinDecimals:      csdb.coinDecimals,
 		stateObjects:      make(map[ethcmn.Address]*stateObject, len(csdb.journal.dirties)),
 		stateObjectsDirty: make(map[ethcmn.Address]struct{}, len(csdb.journal.dirties)),
 		refund:            csdb.refund,
@@ -693,27 +866,65 @@ func (csdb *CommitStateDB) Copy() *CommitStateDB {
 }
 
 // ForEachStorage iterates over each storage items, all invokes the provided
-// callback on each key, value pair .
+// callback on each key, value pair. The iteration stops when the callback
+// returns false.
+//
+// The dirty storage of the current transaction is layered over the committed
+// one: the written slots are visited with their dirty value, along with the
+// committed slots in key order, while the cleared slots are skipped.
 func (csdb *CommitStateDB) ForEachStorage(addr ethcmn.Address, cb func(key, value ethcmn.Hash) bool) error {
 	so := csdb.getStateObject(addr)
 	if so == nil {
 		return nil
 	}
 
+	dirtyKeys := make([]ethcmn.Hash, 0, len(so.dirtyStorage))
+	for key := range so.dirtyStorage {
+		dirtyKeys = append(dirtyKeys, key)
+	}
+	sort.Slice(dirtyKeys, func(i, j int) bool {
+		return bytes.Compare(dirtyKeys[i].Bytes(), dirtyKeys[j].Bytes()) < 0
+	})
+
+	visit := func(key, value ethcmn.Hash) bool {
+		// the cleared slots are deleted on commit
+		if (value == ethcmn.Hash{}) {
+			return true
+		}
+		return cb(key, value)
+	}
+
 	store := csdb.ctx.KVStore(csdb.storeKey)
-	iter := sdk.KVStorePrefixIterator(store, so.Address().Bytes())
+	prefix := AddressStoragePrefix(so.Address())
+	iter := sdk.KVStorePrefixIterator(store, prefix)
 	defer iter.Close()
 
+	i := 0
 	for ; iter.Valid(); iter.Next() {
-		key := ethcmn.BytesToHash(iter.Key())
-		value := iter.Value()
+		key := ethcmn.BytesToHash(iter.Key()[len(prefix):])
+		value := ethcmn.BytesToHash(iter.Value())
 
-		if value, dirty := so.dirtyStorage[key]; dirty {
-			cb(key, value)
-			continue
+		// visit the dirty slots preceding the committed one
+		for ; i < len(dirtyKeys) && bytes.Compare(dirtyKeys[i].Bytes(), key.Bytes()) < 0; i++ {
+			if !visit(dirtyKeys[i], so.dirtyStorage[dirtyKeys[i]]) {
+				return nil
+			}
+		}
+
+		if i < len(dirtyKeys) && dirtyKeys[i] == key {
+			value = so.dirtyStorage[key]
+			i++
+		}
+
+		if !visit(key, value) {
+			return nil
 		}
+	}
 
-		cb(key, ethcmn.BytesToHash(value))
+	for ; i < len(dirtyKeys); i++ {
+		if !visit(dirtyKeys[i], so.dirtyStorage[dirtyKeys[i]]) {
+			return nil
+		}
 	}
 
 	return nil
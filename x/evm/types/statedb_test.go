@@ -2,16 +2,24 @@ package types_test
 
 import (
 	"math/big"
+	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
 
 	"github.com/cosmos/ethermint/app"
+	emint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/keeper"
+	"github.com/cosmos/ethermint/x/evm/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -25,6 +33,10 @@ type StateDBTestSuite struct {
 	app     *app.EthermintApp
 }
 
+func TestStateDBTestSuite(t *testing.T) {
+	suite.Run(t, new(StateDBTestSuite))
+}
+
 func (suite *StateDBTestSuite) SetupTest() {
 	checkTx := false
 
@@ -59,3 +71,756 @@ func (suite *StateDBTestSuite) TestBloomFilter() {
 	suite.Require().True(ethtypes.BloomLookup(bloomFilter, contractAddress))
 	suite.Require().False(ethtypes.BloomLookup(bloomFilter, ethcmn.BigToAddress(big.NewInt(2))))
 }
+
+func (suite *StateDBTestSuite) TestSnapshotRevert() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	newAddr := ethcmn.BytesToAddress([]byte("new_address"))
+	key := ethcmn.BytesToHash([]byte("key"))
+	value := ethcmn.BytesToHash([]byte("value"))
+
+	stateDB.CreateAccount(addr)
+	stateDB.AddBalance(addr, big.NewInt(100))
+	stateDB.SetNonce(addr, 1)
+	stateDB.SetState(addr, key, value)
+
+	id := stateDB.Snapshot()
+
+	stateDB.AddBalance(addr, big.NewInt(50))
+	stateDB.SubBalance(addr, big.NewInt(20))
+	stateDB.SetNonce(addr, 2)
+	stateDB.SetState(addr, key, ethcmn.BytesToHash([]byte("new_value")))
+	stateDB.CreateAccount(newAddr)
+	stateDB.AddBalance(newAddr, big.NewInt(10))
+
+	suite.Require().Equal(big.NewInt(130), stateDB.GetBalance(addr))
+	suite.Require().Equal(uint64(2), stateDB.GetNonce(addr))
+	suite.Require().True(stateDB.Exist(newAddr))
+
+	stateDB.RevertToSnapshot(id)
+
+	suite.Require().Equal(big.NewInt(100), stateDB.GetBalance(addr))
+	suite.Require().Equal(uint64(1), stateDB.GetNonce(addr))
+	suite.Require().Equal(value, stateDB.GetState(addr, key))
+	suite.Require().False(stateDB.Exist(newAddr))
+	suite.Require().Equal(big.NewInt(0), stateDB.GetBalance(newAddr))
+
+	// require an invalidated revision to panic
+	suite.Require().Panics(func() { stateDB.RevertToSnapshot(id) })
+}
+
+func (suite *StateDBTestSuite) TestCommittedState() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	key := ethcmn.BytesToHash([]byte("key"))
+	value1 := ethcmn.BytesToHash([]byte("value_1"))
+	value2 := ethcmn.BytesToHash([]byte("value_2"))
+
+	// a non empty account is kept when finalising
+	stateDB.SetNonce(addr, 1)
+
+	// require the committed value to ignore the writes of the transaction
+	stateDB.SetState(addr, key, value1)
+	stateDB.SetState(addr, key, value2)
+	suite.Require().Equal(value2, stateDB.GetState(addr, key))
+	suite.Require().Equal(ethcmn.Hash{}, stateDB.GetCommittedState(addr, key))
+
+	suite.Require().NoError(stateDB.Finalise(true))
+	suite.Require().Equal(value2, stateDB.GetCommittedState(addr, key))
+
+	// require the committed value of the next transaction to be the one
+	// finalised by the previous transaction
+	stateDB.SetState(addr, key, value1)
+	suite.Require().Equal(value1, stateDB.GetState(addr, key))
+	suite.Require().Equal(value2, stateDB.GetCommittedState(addr, key))
+}
+
+func (suite *StateDBTestSuite) TestForEachStorage() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	key1 := ethcmn.BytesToHash([]byte{1})
+	key2 := ethcmn.BytesToHash([]byte{2})
+	key3 := ethcmn.BytesToHash([]byte{3})
+	key4 := ethcmn.BytesToHash([]byte{4})
+	value1 := ethcmn.BytesToHash([]byte("value_1"))
+	value2 := ethcmn.BytesToHash([]byte("value_2"))
+
+	// a non empty account is kept when finalising
+	stateDB.SetNonce(addr, 1)
+	stateDB.SetState(addr, key1, value1)
+	stateDB.SetState(addr, key3, value1)
+	stateDB.SetState(addr, key4, value1)
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	// the dirty writes override, add and clear the committed slots
+	stateDB.SetState(addr, key2, value2)
+	stateDB.SetState(addr, key3, value2)
+	stateDB.SetState(addr, key4, ethcmn.Hash{})
+
+	storage := make(map[ethcmn.Hash]ethcmn.Hash)
+	var keys []ethcmn.Hash
+	err := stateDB.ForEachStorage(addr, func(key, value ethcmn.Hash) bool {
+		storage[key] = value
+		keys = append(keys, key)
+		return true
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]ethcmn.Hash{key1, key2, key3}, keys)
+	suite.Require().Equal(value1, storage[key1])
+	suite.Require().Equal(value2, storage[key2])
+	suite.Require().Equal(value2, storage[key3])
+
+	// require the iteration to stop when the callback returns false
+	keys = nil
+	err = stateDB.ForEachStorage(addr, func(key, value ethcmn.Hash) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal([]ethcmn.Hash{key1, key2}, keys)
+
+	// an unknown account has no storage
+	err = stateDB.ForEachStorage(ethcmn.BytesToAddress([]byte("unknown")), func(key, value ethcmn.Hash) bool {
+		suite.Fail("unexpected storage slot")
+		return true
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *StateDBTestSuite) TestNestedSnapshotRevert() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	key := ethcmn.BytesToHash([]byte("key"))
+
+	stateDB.CreateAccount(addr)
+
+	values := make([]ethcmn.Hash, 3)
+	ids := make([]int, len(values))
+
+	for i := range values {
+		ids[i] = stateDB.Snapshot()
+
+		values[i] = ethcmn.BigToHash(big.NewInt(int64(i + 1)))
+		stateDB.SetState(addr, key, values[i])
+		stateDB.AddBalance(addr, big.NewInt(10))
+		stateDB.SetNonce(addr, uint64(i+1))
+	}
+
+	// revert the inner most snapshot first
+	stateDB.RevertToSnapshot(ids[2])
+	suite.Require().Equal(values[1], stateDB.GetState(addr, key))
+	suite.Require().Equal(big.NewInt(20), stateDB.GetBalance(addr))
+	suite.Require().Equal(uint64(2), stateDB.GetNonce(addr))
+
+	// reverting an outer snapshot also reverts the inner ones
+	stateDB.RevertToSnapshot(ids[0])
+	suite.Require().Equal(ethcmn.Hash{}, stateDB.GetState(addr, key))
+	suite.Require().Equal(big.NewInt(0), stateDB.GetBalance(addr))
+	suite.Require().Equal(uint64(0), stateDB.GetNonce(addr))
+	suite.Require().Panics(func() { stateDB.RevertToSnapshot(ids[1]) })
+}
+
+func (suite *StateDBTestSuite) TestRefund() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	stateDB.AddRefund(100)
+	suite.Require().Equal(uint64(100), stateDB.GetRefund())
+
+	id1 := stateDB.Snapshot()
+	stateDB.AddRefund(50)
+	stateDB.SubRefund(20)
+	suite.Require().Equal(uint64(130), stateDB.GetRefund())
+
+	id2 := stateDB.Snapshot()
+	stateDB.AddRefund(70)
+	suite.Require().Equal(uint64(200), stateDB.GetRefund())
+
+	stateDB.RevertToSnapshot(id2)
+	suite.Require().Equal(uint64(130), stateDB.GetRefund())
+
+	stateDB.AddRefund(10)
+	suite.Require().Equal(uint64(140), stateDB.GetRefund())
+
+	stateDB.RevertToSnapshot(id1)
+	suite.Require().Equal(uint64(100), stateDB.GetRefund())
+
+	// require the refund counter to not go below zero
+	suite.Require().Panics(func() { stateDB.SubRefund(101) })
+
+	// require refund to be cleared once the state is finalised
+	suite.Require().NoError(stateDB.Finalise(true))
+	suite.Require().Equal(uint64(0), stateDB.GetRefund())
+}
+
+func (suite *StateDBTestSuite) TestSuicide() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	beneficiary := ethcmn.BytesToAddress([]byte("beneficiary"))
+
+	stateDB.CreateAccount(addr)
+	stateDB.AddBalance(addr, big.NewInt(100))
+	stateDB.SetNonce(addr, 1)
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	// require a non existing account to not be suicided
+	suite.Require().False(stateDB.Suicide(ethcmn.BytesToAddress([]byte("unknown"))))
+
+	// transfer the balance to the beneficiary as the SELFDESTRUCT opcode does
+	id := stateDB.Snapshot()
+	stateDB.AddBalance(beneficiary, stateDB.GetBalance(addr))
+	suite.Require().True(stateDB.Suicide(addr))
+
+	suite.Require().True(stateDB.HasSuicided(addr))
+	suite.Require().True(stateDB.Exist(addr))
+	suite.Require().Equal(big.NewInt(0), stateDB.GetBalance(addr))
+	suite.Require().Equal(big.NewInt(100), stateDB.GetBalance(beneficiary))
+
+	// require a reverted suicide to be un-marked and the account to survive the commit
+	stateDB.RevertToSnapshot(id)
+	suite.Require().False(stateDB.HasSuicided(addr))
+	suite.Require().Equal(big.NewInt(100), stateDB.GetBalance(addr))
+	suite.Require().Equal(big.NewInt(0), stateDB.GetBalance(beneficiary))
+
+	suite.Require().NoError(stateDB.Finalise(true))
+	suite.Require().True(stateDB.Exist(addr))
+	suite.Require().NotNil(suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes()))
+
+	// require a suicided account to be kept until it is committed
+	stateDB.AddBalance(beneficiary, stateDB.GetBalance(addr))
+	suite.Require().True(stateDB.Suicide(addr))
+	suite.Require().NotNil(suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes()))
+
+	suite.Require().NoError(stateDB.Finalise(true))
+	_, err := stateDB.Commit(true)
+	suite.Require().NoError(err)
+
+	suite.Require().False(stateDB.Exist(addr))
+	suite.Require().Nil(suite.app.AccountKeeper.GetAccount(suite.ctx, addr.Bytes()))
+	suite.Require().Equal(big.NewInt(100), stateDB.GetBalance(beneficiary))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_PrecompileGas() {
+	// identity requires 15 gas plus 3 gas per word
+	input := []byte("arbitrary input")
+	identity := ethcmn.BytesToAddress([]byte{4})
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	transition := func(gasLimit uint64) (*types.ReturnData, error) {
+		// use a fresh gas meter so that the whole gas limit is given to the EVM
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			GasLimit:  gasLimit,
+			Recipient: &identity,
+			Amount:    big.NewInt(0),
+			Payload:   input,
+			Csdb:      suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+			ChainID:   big.NewInt(3),
+			THash:     &txHash,
+			Hardfork:  types.HardforkHomestead,
+		}
+		return st.TransitionCSDB(ctx)
+	}
+
+	returnData, err := transition(18)
+	suite.Require().NoError(err)
+
+	resultData, err := types.DecodeResultData(returnData.Result.Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(input, resultData.Ret)
+
+	_, err = transition(17)
+	suite.Require().Error(err)
+	suite.Require().True(sdkerrors.ErrOutOfGas.Is(err), err.Error())
+}
+
+// panicTracer is an EVM tracer panicking at the start of the execution
+type panicTracer struct{}
+
+func (panicTracer) CaptureStart(ethcmn.Address, ethcmn.Address, bool, []byte, uint64, *big.Int) error {
+	panic("tracer panic")
+}
+
+func (panicTracer) CaptureState(*vm.EVM, uint64, vm.OpCode, uint64, uint64, *vm.Memory, *vm.Stack, *vm.Contract, int, error) error {
+	return nil
+}
+
+func (panicTracer) CaptureFault(*vm.EVM, uint64, vm.OpCode, uint64, uint64, *vm.Memory, *vm.Stack, *vm.Contract, int, error) error {
+	return nil
+}
+
+func (panicTracer) CaptureEnd([]byte, uint64, time.Duration, error) error { return nil }
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_EVMPanic() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+	gasLimit := uint64(100000)
+
+	transition := func(ctx sdk.Context, tracer vm.Tracer) (*types.ReturnData, error) {
+		st := types.StateTransition{
+			GasLimit:  gasLimit,
+			Recipient: &recipient,
+			Amount:    big.NewInt(0),
+			Csdb:      suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+			ChainID:   big.NewInt(3),
+			THash:     &txHash,
+			Sender:    sender,
+			Tracer:    tracer,
+		}
+		return st.TransitionCSDB(ctx)
+	}
+
+	// require the panic to fail the transition, consuming the whole gas limit
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	var err error
+	suite.Require().NotPanics(func() { _, err = transition(ctx, panicTracer{}) })
+	suite.Require().Error(err)
+	suite.Require().True(emint.ErrVMExecution.Is(err), err.Error())
+	suite.Require().Contains(err.Error(), "tracer panic")
+	suite.Require().Equal(gasLimit, ctx.GasMeter().GasConsumed())
+	suite.Require().Zero(suite.app.EvmKeeper.CommitStateDB.WithContext(ctx).GetNonce(sender))
+
+	// require the following transitions to be executed
+	_, err = transition(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), nil)
+	suite.Require().NoError(err)
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_NetGasMetering() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// PUSH1 1 PUSH1 0 SSTORE PUSH1 2 PUSH1 0 SSTORE STOP, writing the slot 0 twice
+	code := ethcmn.FromHex("0x6001600055600260005500")
+
+	// Constantinople enables the net gas metering, which Petersburg disables.
+	// A disabled Petersburg fork follows Constantinople in go-ethereum, hence it
+	// is scheduled after the current height.
+	constantinople := types.DefaultChainConfig()
+	constantinople.PetersburgBlock = sdk.NewInt(100)
+	constantinople.IstanbulBlock = sdk.NewInt(100)
+	petersburg := types.DefaultChainConfig()
+
+	transition := func(chainConfig types.ChainConfig) uint64 {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+		stateDB.SetCode(contract, code)
+		suite.Require().NoError(stateDB.Finalise(true))
+
+		// meter the transition only
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			GasLimit:    100000,
+			Recipient:   &contract,
+			Amount:      big.NewInt(0),
+			Csdb:        stateDB.WithContext(ctx),
+			ChainID:     big.NewInt(3),
+			THash:       &txHash,
+			Sender:      sender,
+			ChainConfig: &chainConfig,
+		}
+		_, err := st.TransitionCSDB(ctx)
+		suite.Require().NoError(err)
+
+		gasUsed := ctx.GasMeter().GasConsumed()
+
+		// reset the slot for the next transition
+		stateDB.SetState(contract, ethcmn.Hash{}, ethcmn.Hash{})
+		suite.Require().NoError(stateDB.Finalise(true))
+
+		return gasUsed
+	}
+
+	// the second write of a dirty slot costs 200 gas with the net gas
+	// metering, against 5000 gas otherwise, the original value being the one
+	// committed before the transaction
+	suite.Require().Equal(uint64(5000-200), transition(petersburg)-transition(constantinople))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_EmptyRecipient() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	preEIP158 := types.DefaultChainConfig()
+	preEIP158.EIP158Block = sdk.NewInt(100)
+
+	transition := func(recipient ethcmn.Address, chainConfig types.ChainConfig) bool {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+		st := types.StateTransition{
+			GasLimit:    100000,
+			Recipient:   &recipient,
+			Amount:      big.NewInt(0),
+			Csdb:        stateDB,
+			ChainID:     big.NewInt(3),
+			THash:       &txHash,
+			Sender:      sender,
+			ChainConfig: &chainConfig,
+		}
+		_, err := st.TransitionCSDB(ctx)
+		suite.Require().NoError(err)
+
+		return suite.app.AccountKeeper.GetAccount(ctx, recipient.Bytes()) != nil
+	}
+
+	// require a zero value call not to leave an empty recipient account from
+	// the EIP-158 hardfork
+	suite.Require().False(transition(ethcmn.BytesToAddress([]byte("recipient_1")), types.DefaultChainConfig()))
+	suite.Require().True(transition(ethcmn.BytesToAddress([]byte("recipient_2")), preEIP158))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_RefundGas() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// PUSH1 0 PUSH1 0 SSTORE STOP, clearing the slot 0 for 5006 gas
+	code := ethcmn.FromHex("0x600060005500")
+
+	transition := func(slot ethcmn.Hash) uint64 {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+		stateDB.SetCode(contract, code)
+		stateDB.SetState(contract, ethcmn.Hash{}, slot)
+		suite.Require().NoError(stateDB.Finalise(true))
+
+		// meter the transition only
+		ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			GasLimit:  100000,
+			Recipient: &contract,
+			Amount:    big.NewInt(0),
+			Csdb:      stateDB.WithContext(ctx),
+			ChainID:   big.NewInt(3),
+			THash:     &txHash,
+			Sender:    sender,
+		}
+		_, err := st.TransitionCSDB(ctx)
+		suite.Require().NoError(err)
+
+		return ctx.GasMeter().GasConsumed()
+	}
+
+	// require the gas meter to reflect the gas used without refund when the
+	// slot is already empty
+	suite.Require().Equal(uint64(5006), transition(ethcmn.Hash{}))
+
+	// require the 15000 gas refund of the cleared slot to be capped to half of
+	// the gas used
+	suite.Require().Equal(uint64(5006-2503), transition(ethcmn.BytesToHash([]byte{1})))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_CallDepth() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// Increments the slot 0 and calls itself with all the gas. The slot 1 is set
+	// if the call fails:
+	// PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE
+	// PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 ADDRESS GAS CALL
+	// ISZERO PUSH1 0x1b JUMPI STOP JUMPDEST PUSH1 1 PUSH1 1 SSTORE STOP
+	code := ethcmn.FromHex("0x60005460010160005560006000600060006000305af115601b57005b600160015500")
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+	stateDB.SetCode(contract, code)
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	// the gas limit is high enough for the 63/64 gas forwarded to each call to
+	// reach the max depth
+	st := types.StateTransition{
+		GasLimit:  1 << 50,
+		Recipient: &contract,
+		Amount:    big.NewInt(0),
+		Csdb:      stateDB,
+		ChainID:   big.NewInt(3),
+		THash:     &txHash,
+		Sender:    sender,
+	}
+	_, err := st.TransitionCSDB(ctx)
+	suite.Require().NoError(err)
+
+	// require the recursion to stop at the 1024 call depth limit, the calls of
+	// the last frame failing without reverting the transaction
+	depth := stateDB.GetState(contract, ethcmn.Hash{}).Big()
+	suite.Require().Equal(int64(ethparams.CallCreateDepth+1), depth.Int64())
+	suite.Require().Equal(ethcmn.BytesToHash([]byte{1}), stateDB.GetState(contract, ethcmn.BytesToHash([]byte{1})))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_ExtraEIPs() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+	// PUSH1 0xef PUSH1 0 MSTORE8 PUSH1 1 PUSH1 0 RETURN, deploying the 0xef code
+	initCode := ethcmn.FromHex("0x60ef60005360016000f3")
+
+	transition := func(nonce uint64, extraEIPs []int) error {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			AccountNonce: nonce,
+			GasLimit:     100000,
+			Amount:       big.NewInt(0),
+			Payload:      initCode,
+			Csdb:         suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+			ChainID:      big.NewInt(3),
+			THash:        &txHash,
+			Sender:       sender,
+			ExtraEIPs:    extraEIPs,
+		}
+		_, err := st.TransitionCSDB(ctx)
+		return err
+	}
+
+	// require the code to be deployed unless EIP-3541 is activated
+	suite.Require().NoError(transition(0, nil))
+
+	err := transition(1, []int{types.EIP3541})
+	suite.Require().Error(err)
+	suite.Require().True(emint.ErrVMExecution.Is(err), err.Error())
+	suite.Require().False(suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx).Exist(ethcrypto.CreateAddress(sender, 1)))
+}
+
+func (suite *StateDBTestSuite) TestExistEmpty() {
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	suite.Require().False(stateDB.Exist(addr))
+	suite.Require().True(stateDB.Empty(addr))
+
+	// require a created account to exist while being empty
+	stateDB.CreateAccount(addr)
+	suite.Require().True(stateDB.Exist(addr))
+	suite.Require().True(stateDB.Empty(addr))
+
+	// require an account with a nonce, balance or code to be non empty
+	stateDB.SetNonce(addr, 1)
+	suite.Require().False(stateDB.Empty(addr))
+	stateDB.SetNonce(addr, 0)
+	suite.Require().True(stateDB.Empty(addr))
+
+	stateDB.AddBalance(addr, big.NewInt(1))
+	suite.Require().False(stateDB.Empty(addr))
+	stateDB.SubBalance(addr, big.NewInt(1))
+	suite.Require().True(stateDB.Empty(addr))
+
+	stateDB.SetCode(addr, []byte("code"))
+	suite.Require().False(stateDB.Empty(addr))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_CreateCollision() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+	// PUSH1 0 PUSH1 0 RETURN, deploying an empty contract
+	initCode := ethcmn.FromHex("0x60006000f3")
+
+	transition := func(nonce uint64) (*types.ReturnData, error) {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			AccountNonce: nonce,
+			GasLimit:     100000,
+			Amount:       big.NewInt(0),
+			Payload:      initCode,
+			Csdb:         suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+			ChainID:      big.NewInt(3),
+			THash:        &txHash,
+			Sender:       sender,
+		}
+		return st.TransitionCSDB(ctx)
+	}
+
+	// require the creation at a fresh address to succeed
+	returnData, err := transition(0)
+	suite.Require().NoError(err)
+
+	resultData, err := types.DecodeResultData(returnData.Result.Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(ethcrypto.CreateAddress(sender, 0), resultData.Address)
+	suite.Require().True(suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx).Exist(resultData.Address))
+
+	// require the creation at an address with code to abort
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+	collision := ethcrypto.CreateAddress(sender, 1)
+	stateDB.SetCode(collision, []byte("code"))
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	_, err = transition(1)
+	suite.Require().Error(err)
+	suite.Require().True(emint.ErrContractAddressCollision.Is(err), err.Error())
+	suite.Require().Equal([]byte("code"), stateDB.GetCode(collision))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_Create2() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// PUSH1 0x2a PUSH1 0 MSTORE8 PUSH1 1 PUSH1 0 RETURN, deploying the 0x2a code
+	childInitCode := ethcmn.FromHex("0x602a60005360016000f3")
+	// PUSH10 <child init code> PUSH1 0 MSTORE, then twice PUSH1 7 PUSH1 10
+	// PUSH1 22 PUSH1 0 CREATE2 PUSH1 <slot> SSTORE, storing the created addresses
+	// in the slots 0 and 1
+	initCode := ethcmn.FromHex(
+		"0x69602a60005360016000f3600052" +
+			"6007600a60166000f5600055" +
+			"6007600a60166000f5600155" +
+			"00",
+	)
+
+	factory := ethcrypto.CreateAddress(sender, 0)
+	salt := [32]byte{31: 7}
+	predicted := types.GetContractAddress2(factory, salt, ethcrypto.Keccak256Hash(childInitCode))
+
+	// require an existing account without nonce nor code not to collide
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+	stateDB.AddBalance(predicted, big.NewInt(1))
+	suite.Require().NoError(stateDB.Finalise(true))
+	suite.Require().True(stateDB.Exist(predicted))
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	st := types.StateTransition{
+		GasLimit: 1000000,
+		Amount:   big.NewInt(0),
+		Payload:  initCode,
+		Csdb:     suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+		ChainID:  big.NewInt(3),
+		THash:    &txHash,
+		Sender:   sender,
+	}
+	_, err := st.TransitionCSDB(ctx)
+	suite.Require().NoError(err)
+
+	// require the child to be deployed at the predicted address, the second
+	// creation at the same address failing with a collision
+	suite.Require().Equal([]byte{0x2a}, stateDB.GetCode(predicted))
+	suite.Require().Equal(uint64(1), stateDB.GetNonce(predicted))
+	suite.Require().Equal(ethcmn.BytesToHash(predicted.Bytes()), stateDB.GetState(factory, ethcmn.Hash{}))
+	suite.Require().Equal(ethcmn.Hash{}, stateDB.GetState(factory, ethcmn.BytesToHash([]byte{1})))
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_EIP3860() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	transition := func(nonce uint64, initCode []byte, extraEIPs []int) (uint64, error) {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		st := types.StateTransition{
+			AccountNonce: nonce,
+			GasLimit:     1000000,
+			Amount:       big.NewInt(0),
+			Payload:      initCode,
+			Csdb:         suite.app.EvmKeeper.CommitStateDB.WithContext(ctx),
+			ChainID:      big.NewInt(3),
+			THash:        &txHash,
+			Sender:       sender,
+			ExtraEIPs:    extraEIPs,
+		}
+		_, err := st.TransitionCSDB(ctx)
+		return ctx.GasMeter().GasConsumed(), err
+	}
+
+	// PUSH10 <init code deploying 0x2a> PUSH1 0 MSTORE PUSH1 7 PUSH1 10 PUSH1 22
+	// PUSH1 0 CREATE2 STOP, a 24 bytes init code creating a 10 bytes one
+	initCode := ethcmn.FromHex("0x69602a60005360016000f36000526007600a60166000f500")
+
+	// require the init code gas of the transaction to be charged, one word, the
+	// init code of CREATE2 not being charged by the EVM
+	gas, err := transition(0, initCode, nil)
+	suite.Require().NoError(err)
+
+	eipGas, err := transition(1, initCode, []int{types.EIP3860})
+	suite.Require().NoError(err)
+	suite.Require().Equal(gas+types.InitCodeWordGas, eipGas)
+
+	// require the CREATE2 deployment at the predicted address
+	factory := ethcrypto.CreateAddress(sender, 1)
+	initCodeHash := ethcrypto.Keccak256Hash(ethcmn.FromHex("0x602a60005360016000f3"))
+	created := types.GetContractAddress2(factory, [32]byte{31: 7}, initCodeHash)
+	suite.Require().Equal([]byte{0x2a}, suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx).GetCode(created))
+
+	// require the transactions with an init code above the max size to fail
+	largeInitCode := make([]byte, types.MaxInitCodeSize+1)
+
+	_, err = transition(2, largeInitCode, nil)
+	suite.Require().NoError(err)
+
+	_, err = transition(3, largeInitCode, []int{types.EIP3860})
+	suite.Require().Error(err)
+	suite.Require().True(emint.ErrCodeSizeExceeded.Is(err), err.Error())
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_BaseFeeOpcode() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// BASEFEE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN, returning block.basefee
+	code := ethcmn.FromHex("0x4860005260206000f3")
+
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+	stateDB.SetCode(contract, code)
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	st := types.StateTransition{
+		GasLimit:  100000,
+		Recipient: &contract,
+		Amount:    big.NewInt(0),
+		Csdb:      stateDB,
+		ChainID:   big.NewInt(3),
+		THash:     &txHash,
+		Sender:    sender,
+	}
+
+	// the go-ethereum v1.9.0 EVM predates London and doesn't define the EIP-3198
+	// BASEFEE opcode, which is an invalid opcode at every height
+	_, err := st.TransitionCSDB(ctx)
+	suite.Require().Error(err)
+	suite.Require().Contains(err.Error(), "invalid opcode 0x48")
+}
+
+func (suite *StateDBTestSuite) TestTransitionCSDB_BlockContext() {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	txHash := ethcmn.BytesToHash([]byte("tx_hash"))
+
+	// COINBASE, TIMESTAMP, NUMBER and DIFFICULTY stored at 0x00, 0x20, 0x40 and
+	// 0x60, then PUSH1 0x80 PUSH1 0 RETURN
+	code := ethcmn.FromHex("0x4160005242602052436040524460605260806000f3")
+
+	header := abci.Header{
+		Height:          42,
+		Time:            time.Unix(1600000000, 0).UTC(),
+		ProposerAddress: ethcmn.FromHex("0x1122334455667788990011223344556677889900"),
+	}
+	ctx := suite.ctx.WithBlockHeader(header).WithGasMeter(sdk.NewInfiniteGasMeter())
+	stateDB := suite.app.EvmKeeper.CommitStateDB.WithContext(ctx)
+	stateDB.SetCode(contract, code)
+	suite.Require().NoError(stateDB.Finalise(true))
+
+	st := types.StateTransition{
+		GasLimit:  100000,
+		Recipient: &contract,
+		Amount:    big.NewInt(0),
+		Csdb:      stateDB,
+		ChainID:   big.NewInt(3),
+		THash:     &txHash,
+		Sender:    sender,
+	}
+	returnData, err := st.TransitionCSDB(ctx)
+	suite.Require().NoError(err)
+
+	resultData, err := types.DecodeResultData(returnData.Result.Data)
+	suite.Require().NoError(err)
+	suite.Require().Len(resultData.Ret, 128)
+
+	// require the block context to match the header
+	ret := resultData.Ret
+	suite.Require().Equal(ethcmn.BytesToAddress(header.ProposerAddress), ethcmn.BytesToAddress(ret[:32]))
+	suite.Require().Equal(header.Time.Unix(), new(big.Int).SetBytes(ret[32:64]).Int64())
+	suite.Require().Equal(header.Height, new(big.Int).SetBytes(ret[64:96]).Int64())
+	suite.Require().Equal(int64(0), new(big.Int).SetBytes(ret[96:128]).Int64())
+}
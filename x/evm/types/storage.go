@@ -0,0 +1,54 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// State defines a storage slot of an account, ie. a key and its value, as hex
+// encoded hashes.
+type State struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// NewState creates a new State instance
+func NewState(key, value ethcmn.Hash) State {
+	return State{
+		Key:   key.Hex(),
+		Value: value.Hex(),
+	}
+}
+
+// Validate performs a basic validation of the State fields.
+func (s State) Validate() error {
+	if strings.TrimSpace(s.Key) == "" {
+		return errors.New("state key hash cannot be blank")
+	}
+	return nil
+}
+
+// Storage defines the storage slots of an account. It is a slice rather than a
+// map so that it can be encoded by amino and iterated deterministically.
+type Storage []State
+
+// Validate returns an error if a state is invalid or if the storage holds
+// duplicated keys.
+func (s Storage) Validate() error {
+	seenKeys := make(map[ethcmn.Hash]bool)
+	for _, state := range s {
+		if err := state.Validate(); err != nil {
+			return err
+		}
+
+		key := ethcmn.HexToHash(state.Key)
+		if seenKeys[key] {
+			return fmt.Errorf("duplicated state key %s", key.Hex())
+		}
+		seenKeys[key] = true
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// TraceConfig defines the options of a transaction trace. The disabled fields
+// are not captured on each step to bound the size of the output. The tracer
+// selects the call tracer instead of the struct logger if set to callTracer.
+type TraceConfig struct {
+	DisableStack   bool   `json:"disableStack"`
+	DisableMemory  bool   `json:"disableMemory"`
+	DisableStorage bool   `json:"disableStorage"`
+	Tracer         string `json:"tracer,omitempty"`
+}
+
+// TraceTxRequest defines the data of a traceTx query. The predecessors are the
+// raw transactions executed before the traced one in its block, which are
+// replayed to reconstruct the state the transaction was executed against.
+type TraceTxRequest struct {
+	Predecessors [][]byte    `json:"predecessors"`
+	Tx           []byte      `json:"tx"`
+	BlockHeight  int64       `json:"block_height"`
+	BlockTime    time.Time   `json:"block_time"`
+	Config       TraceConfig `json:"config"`
+}
+
+// ExecutionResult defines the go-ethereum compatible result of a transaction
+// traced with the struct logger.
+type ExecutionResult struct {
+	Gas         uint64         `json:"gas"`
+	Failed      bool           `json:"failed"`
+	ReturnValue string         `json:"returnValue"`
+	StructLogs  []StructLogRes `json:"structLogs"`
+}
+
+// StructLogRes defines the go-ethereum compatible format of a step captured by
+// the struct logger.
+type StructLogRes struct {
+	Pc      uint64            `json:"pc"`
+	Op      string            `json:"op"`
+	Gas     uint64            `json:"gas"`
+	GasCost uint64            `json:"gasCost"`
+	Depth   int               `json:"depth"`
+	Error   string            `json:"error,omitempty"`
+	Stack   []string          `json:"stack,omitempty"`
+	Memory  []string          `json:"memory,omitempty"`
+	Storage map[string]string `json:"storage,omitempty"`
+}
+
+// NewStructLogger returns a go-ethereum struct logger for the given trace
+// config.
+func NewStructLogger(config TraceConfig) *vm.StructLogger {
+	return vm.NewStructLogger(&vm.LogConfig{
+		DisableStack:   config.DisableStack,
+		DisableMemory:  config.DisableMemory,
+		DisableStorage: config.DisableStorage,
+	})
+}
+
+// FormatLogs formats the EVM steps captured by the struct logger. The stack
+// values and memory are returned as 32 byte words.
+func FormatLogs(logs []vm.StructLog) []StructLogRes {
+	formatted := make([]StructLogRes, len(logs))
+	for i, trace := range logs {
+		formatted[i] = StructLogRes{
+			Pc:      trace.Pc,
+			Op:      trace.Op.String(),
+			Gas:     trace.Gas,
+			GasCost: trace.GasCost,
+			Depth:   trace.Depth,
+		}
+
+		if trace.Err != nil {
+			formatted[i].Error = trace.Err.Error()
+		}
+
+		if trace.Stack != nil {
+			stack := make([]string, len(trace.Stack))
+			for j, value := range trace.Stack {
+				stack[j] = fmt.Sprintf("%x", math.PaddedBigBytes(value, 32))
+			}
+			formatted[i].Stack = stack
+		}
+
+		if trace.Memory != nil {
+			memory := make([]string, 0, (len(trace.Memory)+31)/32)
+			for j := 0; j+32 <= len(trace.Memory); j += 32 {
+				memory = append(memory, fmt.Sprintf("%x", trace.Memory[j:j+32]))
+			}
+			formatted[i].Memory = memory
+		}
+
+		if trace.Storage != nil {
+			storage := make(map[string]string, len(trace.Storage))
+			for key, value := range trace.Storage {
+				storage[fmt.Sprintf("%x", key)] = fmt.Sprintf("%x", value)
+			}
+			formatted[i].Storage = storage
+		}
+	}
+
+	return formatted
+}
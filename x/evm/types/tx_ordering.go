@@ -0,0 +1,108 @@
+package types
+
+import (
+	"bytes"
+	"container/heap"
+	"fmt"
+	"math/big"
+	"sort"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// Orderings of the pending Ethereum transactions
+const (
+	// TxOrderingFIFO keeps the transactions in the order they were received
+	TxOrderingFIFO = "fifo"
+	// TxOrderingPriceNonce orders the transactions of each sender by nonce and
+	// the senders by gas price, as the Ethereum transaction pools do
+	TxOrderingPriceNonce = "price-nonce"
+)
+
+// ValidateTxOrdering returns an error if the transaction ordering is unknown.
+func ValidateTxOrdering(ordering string) error {
+	switch ordering {
+	case TxOrderingFIFO, TxOrderingPriceNonce:
+		return nil
+	default:
+		return fmt.Errorf("unknown transaction ordering %s, must be one of %s or %s", ordering, TxOrderingFIFO, TxOrderingPriceNonce)
+	}
+}
+
+// SortTxsByPriceAndNonce returns the transactions grouped by sender and ordered
+// by nonce within each group. Across the groups, the next transaction of the
+// sender offering the highest effective gas tip for the given base fee comes
+// first, ties being broken by the sender address so that the ordering is
+// deterministic. The senders are recovered from the signatures for the given
+// chain ID.
+func SortTxsByPriceAndNonce(chainID, baseFee *big.Int, msgs []MsgEthereumTx) ([]MsgEthereumTx, error) {
+	senders, err := VerifySigs(chainID, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[ethcmn.Address][]MsgEthereumTx)
+	for i, sender := range senders {
+		groups[sender] = append(groups[sender], msgs[i])
+	}
+
+	heads := txsByPrice{baseFee: baseFee, heads: make([]*senderTxs, 0, len(groups))}
+	for sender, txs := range groups {
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Data.AccountNonce < txs[j].Data.AccountNonce
+		})
+		heads.heads = append(heads.heads, &senderTxs{sender: sender, txs: txs})
+	}
+	heap.Init(&heads)
+
+	sorted := make([]MsgEthereumTx, 0, len(msgs))
+	for heads.Len() > 0 {
+		head := heads.heads[0]
+		sorted = append(sorted, head.txs[0])
+
+		if head.txs = head.txs[1:]; len(head.txs) > 0 {
+			heap.Fix(&heads, 0)
+		} else {
+			heap.Pop(&heads)
+		}
+	}
+
+	return sorted, nil
+}
+
+// senderTxs holds the remaining transactions of a sender ordered by nonce
+type senderTxs struct {
+	sender ethcmn.Address
+	txs    []MsgEthereumTx
+}
+
+// txsByPrice implements heap.Interface over the next transaction of each
+// sender, ordered by effective gas tip for the base fee and sender address.
+type txsByPrice struct {
+	baseFee *big.Int
+	heads   []*senderTxs
+}
+
+func (s txsByPrice) Len() int { return len(s.heads) }
+
+func (s txsByPrice) Less(i, j int) bool {
+	tipI, tipJ := s.heads[i].txs[0].EffectiveGasTip(s.baseFee), s.heads[j].txs[0].EffectiveGasTip(s.baseFee)
+	if cmp := tipI.Cmp(tipJ); cmp != 0 {
+		return cmp > 0
+	}
+	return bytes.Compare(s.heads[i].sender.Bytes(), s.heads[j].sender.Bytes()) < 0
+}
+
+func (s txsByPrice) Swap(i, j int) { s.heads[i], s.heads[j] = s.heads[j], s.heads[i] }
+
+func (s *txsByPrice) Push(x interface{}) {
+	s.heads = append(s.heads, x.(*senderTxs))
+}
+
+func (s *txsByPrice) Pop() interface{} {
+	old := s.heads
+	n := len(old)
+	x := old[n-1]
+	s.heads = old[:n-1]
+	return x
+}
@@ -0,0 +1,95 @@
+package types
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/ethermint/crypto"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestSortTxsByPriceAndNonce(t *testing.T) {
+	chainID := big.NewInt(3)
+
+	privA, _ := crypto.GenerateKey()
+	privB, _ := crypto.GenerateKey()
+	to := ethcmn.BytesToAddress([]byte("recipient"))
+
+	newTx := func(priv crypto.PrivKeySecp256k1, nonce uint64, price int64) MsgEthereumTx {
+		msg := NewMsgEthereumTx(nonce, &to, big.NewInt(0), 21000, big.NewInt(price), nil)
+		msg.Sign(chainID, priv.ToECDSA())
+		return msg
+	}
+
+	// require the nonce order of a sender to prevail over the gas prices
+	a0, a1, b0 := newTx(privA, 0, 1), newTx(privA, 1, 10), newTx(privB, 0, 5)
+
+	sorted, err := SortTxsByPriceAndNonce(chainID, nil, []MsgEthereumTx{a1, a0, b0})
+	require.NoError(t, err)
+	require.Equal(t, []ethcmn.Hash{b0.Hash(), a0.Hash(), a1.Hash()}, txHashes(sorted))
+
+	sorted, err = SortTxsByPriceAndNonce(chainID, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, sorted)
+
+	// require an error for an invalid signature
+	invalid := newTx(privA, 2, 1)
+	invalid.Data.S = new(big.Int)
+	_, err = SortTxsByPriceAndNonce(chainID, nil, []MsgEthereumTx{a0, invalid})
+	require.Error(t, err)
+}
+
+func TestSortTxsByPriceAndNonceRandom(t *testing.T) {
+	chainID := big.NewInt(3)
+	to := ethcmn.BytesToAddress([]byte("recipient"))
+	rng := rand.New(rand.NewSource(1))
+
+	var msgs []MsgEthereumTx
+	for i := 0; i < 10; i++ {
+		priv, _ := crypto.GenerateKey()
+		for nonce := uint64(0); nonce < 5; nonce++ {
+			msg := NewMsgEthereumTx(nonce, &to, big.NewInt(0), 21000, big.NewInt(rng.Int63n(4)), nil)
+			msg.Sign(chainID, priv.ToECDSA())
+			msgs = append(msgs, msg)
+		}
+	}
+
+	shuffle := func() []MsgEthereumTx {
+		shuffled := make([]MsgEthereumTx, len(msgs))
+		for i, j := range rng.Perm(len(msgs)) {
+			shuffled[i] = msgs[j]
+		}
+		return shuffled
+	}
+
+	sorted, err := SortTxsByPriceAndNonce(chainID, nil, shuffle())
+	require.NoError(t, err)
+	require.Len(t, sorted, len(msgs))
+
+	// require the nonces of each sender to be monotonic, starting from zero
+	nonces := make(map[ethcmn.Address]uint64)
+	for i := range sorted {
+		sender, err := sorted[i].VerifySig(chainID)
+		require.NoError(t, err)
+		require.Equal(t, nonces[sender], sorted[i].Data.AccountNonce)
+		nonces[sender]++
+	}
+
+	// require the ordering to not depend on the order of the given transactions
+	for i := 0; i < 5; i++ {
+		resorted, err := SortTxsByPriceAndNonce(chainID, nil, shuffle())
+		require.NoError(t, err)
+		require.Equal(t, txHashes(sorted), txHashes(resorted))
+	}
+}
+
+func txHashes(msgs []MsgEthereumTx) []ethcmn.Hash {
+	hashes := make([]ethcmn.Hash, len(msgs))
+	for i := range msgs {
+		hashes[i] = msgs[i].Hash()
+	}
+	return hashes
+}
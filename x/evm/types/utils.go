@@ -1,12 +1,16 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"math/big"
 
 	"github.com/cosmos/ethermint/crypto"
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/pkg/errors"
@@ -38,6 +42,77 @@ func ValidateSigner(signBytes, sig []byte, signer ethcmn.Address) error {
 	return nil
 }
 
+// TxDataNonZeroGasEIP2028 is the gas cost of a non-zero byte of transaction
+// data as reduced by EIP-2028.
+const TxDataNonZeroGasEIP2028 uint64 = 16
+
+const (
+	// TxAccessListAddressGas is the gas cost of an address of the EIP-2930
+	// access list of a transaction.
+	TxAccessListAddressGas uint64 = 2400
+	// TxAccessListStorageKeyGas is the gas cost of a storage key of the EIP-2930
+	// access list of a transaction.
+	TxAccessListStorageKeyGas uint64 = 1900
+)
+
+// IntrinsicGas computes the intrinsic gas of a transaction from its data
+// payload, its access list and whether it is a contract creation, ie. the base
+// transaction cost plus 4 gas per zero byte and 16 gas per non-zero byte of
+// data, and 2400 gas per address and 1900 gas per storage key of the access
+// list.
+func IntrinsicGas(data []byte, accessList AccessList, contractCreation bool) (uint64, error) {
+	gas := ethparams.TxGas
+	if contractCreation {
+		gas = ethparams.TxGasContractCreation
+	}
+
+	var nz uint64
+	for _, b := range data {
+		if b != 0 {
+			nz++
+		}
+	}
+	z := uint64(len(data)) - nz
+
+	// make sure the data cost doesn't overflow the uint64 gas
+	if (math.MaxUint64-gas)/TxDataNonZeroGasEIP2028 < nz {
+		return 0, errors.New("intrinsic gas overflow")
+	}
+	gas += nz * TxDataNonZeroGasEIP2028
+
+	if (math.MaxUint64-gas)/ethparams.TxDataZeroGas < z {
+		return 0, errors.New("intrinsic gas overflow")
+	}
+	gas += z * ethparams.TxDataZeroGas
+
+	addresses, keys := uint64(len(accessList)), uint64(accessList.StorageKeys())
+	if (math.MaxUint64-gas)/TxAccessListAddressGas < addresses {
+		return 0, errors.New("intrinsic gas overflow")
+	}
+	gas += addresses * TxAccessListAddressGas
+
+	if (math.MaxUint64-gas)/TxAccessListStorageKeyGas < keys {
+		return 0, errors.New("intrinsic gas overflow")
+	}
+	gas += keys * TxAccessListStorageKeyGas
+
+	return gas, nil
+}
+
+// GetContractAddress returns the address of the contract created by the CREATE
+// opcode or a contract creation transaction, ie. the last 20 bytes of
+// keccak256(rlp([from, nonce])).
+func GetContractAddress(from ethcmn.Address, nonce uint64) ethcmn.Address {
+	return ethcrypto.CreateAddress(from, nonce)
+}
+
+// GetContractAddress2 returns the address of the contract created by the
+// CREATE2 opcode as defined in EIP-1014, ie. the last 20 bytes of
+// keccak256(0xff || from || salt || initCodeHash).
+func GetContractAddress2(from ethcmn.Address, salt [32]byte, initCodeHash ethcmn.Hash) ethcmn.Address {
+	return ethcrypto.CreateAddress2(from, salt, initCodeHash.Bytes())
+}
+
 func rlpHash(x interface{}) (hash ethcmn.Hash) {
 	hasher := sha3.NewLegacyKeccak256()
 	//nolint:gosec,errcheck
@@ -47,6 +122,19 @@ func rlpHash(x interface{}) (hash ethcmn.Hash) {
 	return hash
 }
 
+// prefixedRlpHash writes the prefix into the hasher before rlp-encoding x. It
+// is used to compute the hash of EIP-2718 typed transactions.
+func prefixedRlpHash(prefix byte, x interface{}) (hash ethcmn.Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	//nolint:errcheck
+	hasher.Write([]byte{prefix})
+	//nolint:gosec,errcheck
+	rlp.Encode(hasher, x)
+	hasher.Sum(hash[:0])
+
+	return hash
+}
+
 // ResultData represents the data returned in an sdk.Result
 type ResultData struct {
 	Address ethcmn.Address
@@ -84,5 +172,56 @@ func DecodeLogs(in []byte) ([]*ethtypes.Log, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	// amino decodes an empty slice as nil
+	if logs == nil {
+		logs = []*ethtypes.Log{}
+	}
 	return logs, nil
 }
+
+// RemovedLogs returns copies of the logs with the Removed flag set, as
+// notified to the log subscribers when the block that emitted them is rolled
+// back. The given logs are left unchanged.
+func RemovedLogs(logs []*ethtypes.Log) []*ethtypes.Log {
+	removed := make([]*ethtypes.Log, len(logs))
+	for i, log := range logs {
+		cpy := *log
+		cpy.Removed = true
+		removed[i] = &cpy
+	}
+	return removed
+}
+
+// revertSelector is the selector of the ABI encoded Error(string) revert reason
+var revertSelector = ethcrypto.Keccak256([]byte("Error(string)"))[:4]
+
+// UnpackRevertReason decodes the human-readable reason of an ABI encoded
+// Error(string) revert payload.
+func UnpackRevertReason(data []byte) (string, error) {
+	if len(data) < 4 || !bytes.Equal(data[:4], revertSelector) {
+		return "", errors.New("invalid revert payload selector")
+	}
+
+	data = data[4:]
+	if len(data) < 64 {
+		return "", errors.New("revert payload too short")
+	}
+
+	// the bounds are checked against the size left, so that the offset and the
+	// length chosen by the reverted contract can't overflow
+	size := uint64(len(data))
+
+	offset := new(big.Int).SetBytes(data[:32])
+	if !offset.IsUint64() || offset.Uint64() > size-32 {
+		return "", errors.New("invalid revert reason offset")
+	}
+
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[offset.Uint64():start])
+	if !length.IsUint64() || length.Uint64() > size-start {
+		return "", errors.New("invalid revert reason length")
+	}
+
+	return string(data[start : start+length.Uint64()]), nil
+}
@@ -1,10 +1,13 @@
 package types
 
 import (
+	"math"
+	"math/big"
 	"testing"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +36,200 @@ func TestEvmDataEncoding(t *testing.T) {
 	require.Equal(t, data.Logs, res.Logs)
 	require.Equal(t, ret, res.Ret)
 }
+
+func TestLogsToBloom(t *testing.T) {
+	addr1 := ethcmn.BytesToAddress([]byte{0x11})
+	addr2 := ethcmn.BytesToAddress([]byte{0x01, 0x11})
+	topic1 := ethcmn.BytesToHash([]byte("topic1"))
+	topic2 := ethcmn.BytesToHash([]byte("topic2"))
+
+	testCases := [][]*ethtypes.Log{
+		nil,
+		{},
+		{{Address: addr1}},
+		{{Address: addr1, Topics: []ethcmn.Hash{}}},
+		{{Address: addr1, Topics: []ethcmn.Hash{topic1}}},
+		{{Address: addr1, Topics: []ethcmn.Hash{topic1, topic2}}, {Address: addr2, Topics: []ethcmn.Hash{topic2}}},
+		{{Address: addr2, Topics: []ethcmn.Hash{topic1, topic1, topic2}, Data: []byte{1, 2, 3}}},
+	}
+
+	for i, logs := range testCases {
+		expected := ethtypes.CreateBloom(ethtypes.Receipts{{Logs: logs}})
+		require.Equal(t, expected, LogsToBloom(logs), "test: %v", i)
+	}
+
+	bloom := LogsToBloom(testCases[5])
+	require.True(t, ethtypes.BloomLookup(bloom, addr1))
+	require.True(t, ethtypes.BloomLookup(bloom, topic2))
+	require.False(t, ethtypes.BloomLookup(bloom, ethcmn.BytesToAddress([]byte{0x22})))
+}
+
+func TestUnpackRevertReason(t *testing.T) {
+	// abi.encodeWithSignature("Error(string)", "insufficient balance")
+	payload := ethcmn.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"696e73756666696369656e742062616c616e6365000000000000000000000000")
+
+	reason, err := UnpackRevertReason(payload)
+	require.NoError(t, err)
+	require.Equal(t, "insufficient balance", reason)
+
+	testCases := []struct {
+		name    string
+		payload []byte
+	}{
+		{"empty payload", nil},
+		{"invalid selector", append([]byte{0x1, 0x2, 0x3, 0x4}, payload[4:]...)},
+		{"truncated payload", payload[:40]},
+		{"invalid length", append(payload[:36:36], ethcmn.LeftPadBytes([]byte{0xff}, 32)...)},
+	}
+
+	for _, tc := range testCases {
+		_, err := UnpackRevertReason(tc.payload)
+		require.Error(t, err, tc.name)
+	}
+
+	// require the offsets and lengths overflowing uint64 to be rejected without
+	// panicking
+	words := [][]byte{
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(math.MaxUint64).Bytes(), 32),
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(math.MaxUint64-15).Bytes(), 32),
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(math.MaxUint64-31).Bytes(), 32),
+		ethcmn.LeftPadBytes(new(big.Int).SetUint64(math.MaxUint64-63).Bytes(), 32),
+		ethcmn.LeftPadBytes(new(big.Int).Lsh(big.NewInt(1), 64).Bytes(), 32),
+		ethcmn.LeftPadBytes([]byte{0x20}, 32),
+		ethcmn.LeftPadBytes([]byte{0x21}, 32),
+		ethcmn.LeftPadBytes([]byte{0x40}, 32),
+	}
+
+	for _, offset := range words {
+		for _, length := range words {
+			data := append(append(append([]byte{}, payload[:4]...), offset...), length...)
+			data = append(data, payload[68:]...)
+
+			require.NotPanics(t, func() {
+				_, _ = UnpackRevertReason(data)
+			}, "offset %x, length %x", offset, length)
+		}
+	}
+
+	_, err = UnpackRevertReason(append(append(payload[:4:4], words[1]...), words[0]...))
+	require.Error(t, err)
+
+	_, err = UnpackRevertReason(append(append(payload[:4:4], words[5]...), words[0]...))
+	require.Error(t, err)
+}
+
+func TestIntrinsicGas(t *testing.T) {
+	addr := ethcmn.BytesToAddress([]byte("address"))
+	keys := []ethcmn.Hash{ethcmn.BigToHash(big.NewInt(1)), ethcmn.BigToHash(big.NewInt(2))}
+
+	testCases := []struct {
+		name             string
+		data             []byte
+		accessList       AccessList
+		contractCreation bool
+		expected         uint64
+	}{
+		{"transfer", nil, nil, false, 21000},
+		{"contract creation", nil, nil, true, 53000},
+		{"data", []byte{0, 1}, nil, false, 21020},
+		{"empty access list", nil, AccessList{}, false, 21000},
+		{"access list address", nil, AccessList{{Address: addr}}, false, 23400},
+		{"access list storage keys", nil, AccessList{{Address: addr, StorageKeys: keys}}, false, 27200},
+		{
+			"access list and data", []byte{0, 1},
+			AccessList{{Address: addr, StorageKeys: keys}, {Address: addr}}, true, 53000 + 20 + 2*2400 + 2*1900,
+		},
+	}
+
+	for _, tc := range testCases {
+		gas, err := IntrinsicGas(tc.data, tc.accessList, tc.contractCreation)
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.expected, gas, tc.name)
+	}
+}
+
+func TestGetContractAddress(t *testing.T) {
+	from := ethcmn.HexToAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+
+	testCases := []struct {
+		nonce    uint64
+		expected string
+	}{
+		{0, "0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d"},
+		{1, "0x343c43a37d37dff08ae8c4a11544c718abb4fcf8"},
+		{2, "0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91"},
+		{3, "0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, ethcmn.HexToAddress(tc.expected), GetContractAddress(from, tc.nonce), "nonce %d", tc.nonce)
+	}
+}
+
+func TestGetContractAddress2(t *testing.T) {
+	// test vectors from EIP-1014
+	testCases := []struct {
+		from     string
+		salt     string
+		initCode string
+		expected string
+	}{
+		{
+			"0x0000000000000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"0x00",
+			"0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38",
+		},
+		{
+			"0xdeadbeef00000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"0x00",
+			"0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3",
+		},
+		{
+			"0xdeadbeef00000000000000000000000000000000",
+			"0x000000000000000000000000feed000000000000000000000000000000000000",
+			"0x00",
+			"0xD04116cDd17beBE565EB2422F2497E06cC1C9833",
+		},
+		{
+			"0x0000000000000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"0xdeadbeef",
+			"0x70f2b2914A2a4b783FaEFb75f459A580616Fcb5e",
+		},
+		{
+			"0x00000000000000000000000000000000deadbeef",
+			"0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			"0xdeadbeef",
+			"0x60f3f640a8508fC6a86d45DF051962668E1e8AC7",
+		},
+		{
+			"0x00000000000000000000000000000000deadbeef",
+			"0x00000000000000000000000000000000000000000000000000000000cafebabe",
+			"0xdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+			"0x1d8bfDC5D46DC4f61D6b6115972536eBE6A8854C",
+		},
+		{
+			"0x0000000000000000000000000000000000000000",
+			"0x0000000000000000000000000000000000000000000000000000000000000000",
+			"0x",
+			"0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0",
+		},
+	}
+
+	for _, tc := range testCases {
+		var salt [32]byte
+		copy(salt[:], ethcmn.FromHex(tc.salt))
+		initCodeHash := ethcrypto.Keccak256Hash(ethcmn.FromHex(tc.initCode))
+
+		require.Equal(
+			t, ethcmn.HexToAddress(tc.expected),
+			GetContractAddress2(ethcmn.HexToAddress(tc.from), salt, initCodeHash),
+			"init code %s", tc.initCode,
+		)
+	}
+}